@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"app/internal/ratelimit"
+	"app/internal/vehicle/loader"
+	"app/internal/vehicle/reloader"
+	"app/internal/vehicle/saver"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewControllerAdmin returns a new instance of an admin controller.
+func NewControllerAdmin(qt *ratelimit.QuotaDaily, rl *reloader.Reloader, sn *saver.Snapshotter) *ControllerAdmin {
+	return &ControllerAdmin{qt: qt, rl: rl, sn: sn}
+}
+
+// ControllerAdmin is an struct that represents the controller of the administration endpoints.
+type ControllerAdmin struct {
+	// qt counts the daily requests of every client.
+	qt *ratelimit.QuotaDaily
+	// rl reloads the vehicles of every tenant.
+	rl *reloader.Reloader
+	// sn writes the vehicles of every tenant to disk.
+	sn *saver.Snapshotter
+}
+
+// QuotasHandler is an struct that represents the daily quota usage of the clients.
+type QuotasHandler struct {
+	Day   string                 `json:"day"`
+	Usage []ratelimit.QuotaUsage `json:"usage"`
+}
+
+// GetQuotas returns the daily quota usage of every client.
+func (c *ControllerAdmin) GetQuotas() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// process
+		day, usage := c.qt.Usage()
+
+		// response
+		code := http.StatusOK
+		body := ResponseBody{
+			Message: "Success",
+			Data:    QuotasHandler{Day: day, Usage: usage},
+			Error:   false,
+		}
+		ctx.JSON(code, body)
+	}
+}
+
+// GetReload returns the status of the last reload of the vehicles.
+func (c *ControllerAdmin) GetReload() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// process
+		st := c.rl.Status()
+
+		// response
+		code := http.StatusOK
+		body := ResponseBody{
+			Message: "Success",
+			Data:    st,
+			Error:   false,
+		}
+		ctx.JSON(code, body)
+	}
+}
+
+// Reload reloads the vehicles of every tenant and returns the status of the reload.
+func (c *ControllerAdmin) Reload() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// process
+		st, err := c.rl.Reload(ctx.Request.Context(), reloader.TriggerManual)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, loader.ErrLoaderVehicleInvalid) {
+				code = http.StatusUnprocessableEntity
+			}
+			ctx.JSON(code, ResponseBody{Message: err.Error(), Data: st, Error: true})
+			return
+		}
+
+		// response
+		code := http.StatusOK
+		body := ResponseBody{
+			Message: "Vehículos recargados",
+			Data:    st,
+			Error:   false,
+		}
+		ctx.JSON(code, body)
+	}
+}
+
+// GetSnapshot returns the status of the last snapshot of the vehicles.
+func (c *ControllerAdmin) GetSnapshot() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// process
+		st := c.sn.Status()
+
+		// response
+		code := http.StatusOK
+		body := ResponseBody{
+			Message: "Success",
+			Data:    st,
+			Error:   false,
+		}
+		ctx.JSON(code, body)
+	}
+}
+
+// Snapshot writes the vehicles of every tenant to disk and returns the status of the snapshot.
+func (c *ControllerAdmin) Snapshot() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// process
+		st, err := c.sn.Snapshot(ctx.Request.Context(), saver.TriggerManual)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, saver.ErrSaverSnapshotDisabled) {
+				code = http.StatusServiceUnavailable
+			}
+			ctx.JSON(code, ResponseBody{Message: err.Error(), Data: st, Error: true})
+			return
+		}
+
+		// response
+		code := http.StatusOK
+		body := ResponseBody{
+			Message: "Vehículos guardados",
+			Data:    st,
+			Error:   false,
+		}
+		ctx.JSON(code, body)
+	}
+}
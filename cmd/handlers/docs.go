@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"embed"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+// docsPage is the Swagger UI page of the document of the api.
+//
+//go:embed static/docs.html
+var docsPage []byte
+
+// swaggerUI are the files of Swagger UI 5.18.2 the page loads, taken from the swagger-ui-dist package,
+// so the page works offline and runs no script served by a third party.
+//
+//go:embed static/swagger-ui/swagger-ui.css static/swagger-ui/swagger-ui-bundle.js
+var swaggerUI embed.FS
+
+// NewControllerDocs returns a new instance of a docs controller.
+func NewControllerDocs() *ControllerDocs {
+	return &ControllerDocs{}
+}
+
+// ControllerDocs is an struct that represents the controller of the OpenAPI document of the api.
+// The document describes its own routes, so it is set once every route is registered.
+type ControllerDocs struct {
+	// spec is the OpenAPI document, as json.
+	spec []byte
+}
+
+// SetSpec sets the OpenAPI document served. It must be called before the api serves requests.
+func (c *ControllerDocs) SetSpec(spec []byte) {
+	c.spec = spec
+}
+
+// Spec responds the OpenAPI document of the api.
+func (c *ControllerDocs) Spec() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "application/json; charset=utf-8", c.spec)
+	}
+}
+
+// UI responds the page that renders the OpenAPI document of the api with Swagger UI.
+func (c *ControllerDocs) UI() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", docsPage)
+	}
+}
+
+// Asset responds a file of Swagger UI loaded by the page, and 404 for any other file.
+func (c *ControllerDocs) Asset() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		name := ctx.Param("file")
+		data, err := fs.ReadFile(swaggerUI, path.Join("static/swagger-ui", name))
+		if err != nil {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		ctx.Data(http.StatusOK, mime.TypeByExtension(path.Ext(name)), data)
+	}
+}
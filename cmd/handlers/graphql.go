@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"app/internal/graph"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// NewControllerGraphQL returns a new instance of a GraphQL controller.
+func NewControllerGraphQL(schema *graph.Schema, timeout time.Duration) *ControllerGraphQL {
+	return &ControllerGraphQL{schema: schema, timeout: timeout}
+}
+
+// ControllerGraphQL is an struct that represents the controller of the GraphQL endpoint.
+// The queries and mutations are answered with json, the subscriptions are streamed as server-sent events.
+type ControllerGraphQL struct {
+	schema *graph.Schema
+	// timeout is the deadline of the queries and mutations. Zero disables it.
+	timeout time.Duration
+}
+
+// RequestGraphQL is the body of a GraphQL request.
+type RequestGraphQL struct {
+	Query         string         `json:"query" openapi:"minLength=1"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// ResponseGraphQL is the body of a GraphQL response, and of every event of a subscription.
+type ResponseGraphQL struct {
+	Data   any            `json:"data,omitempty"`
+	Errors []ErrorGraphQL `json:"errors,omitempty"`
+}
+
+// ErrorGraphQL is an error of a GraphQL response. The extensions carry its code.
+type ErrorGraphQL struct {
+	Message    string            `json:"message"`
+	Locations  []LocationGraphQL `json:"locations,omitempty"`
+	Path       []any             `json:"path,omitempty"`
+	Extensions map[string]any    `json:"extensions,omitempty"`
+}
+
+// LocationGraphQL is the location of an error in the query.
+type LocationGraphQL struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Post executes the operation of the json body: a query, a mutation or a subscription.
+func (c *ControllerGraphQL) Post() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// request
+		request, problem := requestGraphQL(ctx)
+		if problem != "" {
+			ctx.JSON(http.StatusBadRequest, ResponseGraphQL{Errors: []ErrorGraphQL{{Message: problem}}})
+			return
+		}
+
+		c.serve(ctx, request, false)
+	}
+}
+
+// Get executes the query or the subscription of the query parameters query, operationName and variables.
+// The mutations are only allowed with POST.
+func (c *ControllerGraphQL) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// request
+		request, problem := requestGraphQL(ctx)
+		if problem != "" {
+			ctx.JSON(http.StatusBadRequest, ResponseGraphQL{Errors: []ErrorGraphQL{{Message: problem}}})
+			return
+		}
+
+		c.serve(ctx, request, true)
+	}
+}
+
+// Limit limits the requests with the limiter of their operation: the mutations with write, any other with read.
+// The malformed requests are limited as reads, and answered with 400 by the handlers.
+func (c *ControllerGraphQL) Limit(read, write gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		limit := read
+		if request, problem := requestGraphQL(ctx); problem == "" && graph.OperationType(graph.Request{Query: request.Query, OperationName: request.OperationName}) == "mutation" {
+			limit = write
+		}
+		limit(ctx)
+	}
+}
+
+// requestGraphQL returns the request of the json body of a POST, which is kept to be read again,
+// or of the query parameters query, operationName and variables of a GET.
+// It returns the message to respond if the request is malformed.
+func requestGraphQL(ctx *gin.Context) (request RequestGraphQL, problem string) {
+	if ctx.Request.Method == http.MethodPost {
+		body, err := io.ReadAll(ctx.Request.Body)
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil || json.Unmarshal(body, &request) != nil || request.Query == "" {
+			problem = "Bad Request: consulta mal formada."
+		}
+		return
+	}
+
+	request = RequestGraphQL{Query: ctx.Query("query"), OperationName: ctx.Query("operationName")}
+	if variables := ctx.Query("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+			problem = "Bad Request: variables mal formadas."
+			return
+		}
+	}
+	if request.Query == "" {
+		problem = "Bad Request: consulta mal formada."
+	}
+	return
+}
+
+// serve parses the request and executes its operation. The parse, validation and limit errors are answered with 400.
+func (c *ControllerGraphQL) serve(ctx *gin.Context, request RequestGraphQL, get bool) {
+	op, res := c.schema.Parse(graph.Request{Query: request.Query, OperationName: request.OperationName, Variables: request.Variables})
+	if res != nil {
+		ctx.JSON(http.StatusBadRequest, responseGraphQL(res))
+		return
+	}
+
+	// process
+	switch {
+	case op.Type == "mutation" && get:
+		ctx.Header("Allow", http.MethodPost)
+		ctx.JSON(http.StatusMethodNotAllowed, ResponseGraphQL{Errors: []ErrorGraphQL{{Message: "Method Not Allowed: las mutaciones requieren POST."}}})
+		return
+	case op.Type == "subscription":
+		c.stream(ctx, op)
+		return
+	}
+
+	reqCtx := ctx.Request.Context()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(reqCtx, c.timeout)
+		defer cancel()
+	}
+	res = c.schema.Execute(reqCtx, op)
+
+	// response
+	ctx.JSON(http.StatusOK, responseGraphQL(res))
+}
+
+// stream streams the results of a subscription as server-sent events: a next event per change,
+// until the client disconnects or the subscription ends, and then a complete event.
+func (c *ControllerGraphQL) stream(ctx *gin.Context, op *graph.Operation) {
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	// -> the results are drained until the channel is closed, so the subscription is never left blocked
+	for res := range c.schema.Subscribe(ctx.Request.Context(), op) {
+		if ctx.Request.Context().Err() != nil {
+			continue
+		}
+		data, err := json.Marshal(responseGraphQL(res))
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(ctx.Writer, "event: next\ndata: %s\n\n", data)
+		ctx.Writer.Flush()
+	}
+	if ctx.Request.Context().Err() == nil {
+		fmt.Fprint(ctx.Writer, "event: complete\ndata:\n\n")
+		ctx.Writer.Flush()
+	}
+}
+
+// responseGraphQL returns the response of a result.
+func responseGraphQL(res *graphql.Result) (r ResponseGraphQL) {
+	r.Data = res.Data
+	for _, e := range res.Errors {
+		r.Errors = append(r.Errors, errorGraphQL(e))
+	}
+	return
+}
+
+// errorGraphQL returns the error of a response for an error of a result.
+func errorGraphQL(e gqlerrors.FormattedError) (r ErrorGraphQL) {
+	r = ErrorGraphQL{Message: e.Message, Path: e.Path, Extensions: e.Extensions}
+	for _, l := range e.Locations {
+		r.Locations = append(r.Locations, LocationGraphQL{Line: l.Line, Column: l.Column})
+	}
+	return
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"app/internal/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewControllerHealth returns a new instance of a health controller.
+func NewControllerHealth(h *health.Health) *ControllerHealth {
+	return &ControllerHealth{h: h}
+}
+
+// ControllerHealth is an struct that represents the controller of the probes of the process.
+type ControllerHealth struct {
+	// h is the health state of the process.
+	h *health.Health
+}
+
+// HealthHandler is an struct that represents the status of a probe.
+type HealthHandler struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Liveness responds 200 as long as the process serves http requests.
+func (c *ControllerHealth) Liveness() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, ResponseBody{Message: "Success", Data: HealthHandler{Status: "alive"}, Error: false})
+	}
+}
+
+// Readiness responds 200 once the vehicles are loaded, and 503 before that and while shutting down.
+func (c *ControllerHealth) Readiness() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ok, reason := c.h.Ready()
+		if !ok {
+			ctx.JSON(http.StatusServiceUnavailable, ResponseBody{Message: "Service Unavailable", Data: HealthHandler{Status: "not ready", Reason: reason}, Error: true})
+			return
+		}
+		ctx.JSON(http.StatusOK, ResponseBody{Message: "Success", Data: HealthHandler{Status: "ready"}, Error: false})
+	}
+}
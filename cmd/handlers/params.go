@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"app/internal/metrics"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ParamError is an struct that represents an invalid path or query parameter.
+type ParamError struct {
+	// Field is the name of the parameter.
+	Field string `json:"field"`
+	// In is where the parameter is: path or query.
+	In string `json:"in"`
+	// Message tells what is wrong with the parameter.
+	Message string `json:"message"`
+}
+
+// params is an struct that binds the path and query parameters of a request,
+// collecting the errors of every parameter instead of stopping at the first one.
+type params struct {
+	ctx *gin.Context
+	// errs are the errors of the parameters bound so far.
+	errs []ParamError
+	// envelope answers the errors with the envelope of the api v2.
+	envelope bool
+}
+
+// newParams returns the binder of the parameters of the request.
+func newParams(ctx *gin.Context) *params {
+	return &params{ctx: ctx}
+}
+
+// newParamsV2 returns the binder of the parameters of a request of the api v2.
+func newParamsV2(ctx *gin.Context) *params {
+	return &params{ctx: ctx, envelope: true}
+}
+
+// pathInt returns the integer path parameter.
+func (p *params) pathInt(name string) (v int) {
+	v, err := strconv.Atoi(p.ctx.Param(name))
+	if err != nil {
+		p.fail(name, "path", "must be an integer")
+	}
+	return
+}
+
+// pathId returns the id path parameter, a positive integer.
+func (p *params) pathId(name string) (v int) {
+	v, err := strconv.Atoi(p.ctx.Param(name))
+	if err != nil || v <= 0 {
+		p.fail(name, "path", "must be a positive integer")
+	}
+	return
+}
+
+// queryInt returns the optional integer query parameter, or def when the request does not have it.
+func (p *params) queryInt(name string, def int) (v int) {
+	s, ok := p.ctx.GetQuery(name)
+	if !ok {
+		return def
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		p.fail(name, "query", "must be an integer")
+	}
+	return
+}
+
+// queryFloat returns the optional number query parameter, or def when the request does not have it.
+func (p *params) queryFloat(name string, def float64) (v float64) {
+	s, ok := p.ctx.GetQuery(name)
+	if !ok {
+		return def
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		p.fail(name, "query", "must be a number")
+	}
+	return
+}
+
+// min checks the parameter is not lower than the minimum, unless it is already invalid or an open bound.
+func (p *params) min(name, in string, v, min float64) {
+	if !p.failed(name) && !math.IsInf(v, 0) && v < min {
+		p.fail(name, in, "must not be lower than "+strconv.FormatFloat(min, 'f', -1, 64))
+	}
+}
+
+// max checks the parameter is not greater than the maximum, unless it is already invalid or an open bound.
+func (p *params) max(name, in string, v, max float64) {
+	if !p.failed(name) && !math.IsInf(v, 0) && v > max {
+		p.fail(name, in, "must not be greater than "+strconv.FormatFloat(max, 'f', -1, 64))
+	}
+}
+
+// ordered checks the low parameter is not greater than the high one, unless any of them is already invalid.
+func (p *params) ordered(in, low string, lowV float64, high string, highV float64) {
+	if !p.failed(low) && !p.failed(high) && lowV > highV {
+		p.fail(low, in, "must not be greater than "+high)
+	}
+}
+
+// fail adds the error of a parameter.
+func (p *params) fail(name, in, message string) {
+	p.errs = append(p.errs, ParamError{Field: name, In: in, Message: message})
+}
+
+// failed returns whether the parameter has an error.
+func (p *params) failed(name string) bool {
+	for _, e := range p.errs {
+		if e.Field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// valid returns whether every parameter is valid.
+// Otherwise it writes the response listing the errors of the parameters.
+func (p *params) valid() bool {
+	if len(p.errs) == 0 {
+		return true
+	}
+	p.ctx.Set(metrics.KeyErrorKind, "invalid_param")
+	if p.envelope {
+		p.ctx.JSON(http.StatusBadRequest, EnvelopeV2{Error: &ErrorV2{
+			Code:    "invalid_parameters",
+			Message: "parámetros inválidos.",
+			Details: gin.H{"errors": p.errs},
+		}})
+		return false
+	}
+	p.ctx.JSON(http.StatusBadRequest, ResponseBody{
+		Message: "Bad Request: parámetros inválidos.",
+		Data:    gin.H{"errors": p.errs},
+		Error:   true,
+	})
+	return false
+}
@@ -1,33 +1,53 @@
 package handlers
 
 import (
+	"app/internal/auth"
 	"app/internal/domain"
+	"app/internal/metrics"
+	"app/internal/openapi"
+	"app/internal/tenant"
 	"app/internal/vehicle/service"
 	"errors"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"math"
 	"net/http"
-	"strconv"
 )
 
+// tracer creates the spans of the handlers.
+var tracer = otel.Tracer("app/cmd/handlers")
+
+func init() {
+	// -> the bounds of the domain constrain the fields of the requests in the document of the api
+	openapi.Define("max_speed", domain.MaxSpeedLimit)
+}
+
 // NewControllerVehicle returns a new instance of a vehicle controller.
-func NewControllerVehicle(st service.ServiceVehicle) *ControllerVehicle {
-	return &ControllerVehicle{st: st}
+func NewControllerVehicle(st service.ServiceVehicleTenants, lg *slog.Logger) *ControllerVehicle {
+	return &ControllerVehicle{st: st, lg: lg}
 }
 
 // ControllerVehicle is an struct that represents a vehicle controller.
 type ControllerVehicle struct {
-	// StorageVehicle is the storage of vehicles.
-	st service.ServiceVehicle
+	// st resolves the vehicle service of the tenant of every request.
+	st service.ServiceVehicleTenants
+	// lg is the logger of the controller.
+	lg *slog.Logger
 }
 
+// RequestVehicle is the body of the creation of a vehicle.
+// The openapi tags constrain the fields in the document of the api, which the requests are validated against.
 type RequestVehicle struct {
-	Id           int     `json:"id"`
-	Brand        string  `json:"brand"`
+	Id           int     `json:"id" openapi:"minimum=1"`
+	Brand        string  `json:"brand" openapi:"minLength=1"`
 	Model        string  `json:"model"`
-	Registration string  `json:"registration"`
+	Registration string  `json:"registration" openapi:"minLength=1"`
 	Year         int     `json:"year"`
 	Color        string  `json:"color"`
-	MaxSpeed     int     `json:"max_speed"`
+	MaxSpeed     int     `json:"max_speed" openapi:"minimum=0,maximum=max_speed"`
 	FuelType     string  `json:"fuel_type"`
 	Transmission string  `json:"transmission"`
 	Passengers   int     `json:"passengers"`
@@ -36,6 +56,11 @@ type RequestVehicle struct {
 	Weight       float64 `json:"weight"`
 }
 
+// RequestSpeed is the body of the update of the max speed of a vehicle.
+type RequestSpeed struct {
+	MaxSpeed int `json:"max_speed" openapi:"minimum=0,maximum=max_speed"`
+}
+
 // GetAll returns all vehicles.
 type VehicleHandler struct {
 	Id           int     `json:"id"`
@@ -64,6 +89,15 @@ type ResponseBody struct {
 	Error   bool   `json:"error"`
 }
 
+// ResponseError is the body of the error responses. Some of them carry the data of the error,
+// and the ones of the service a null list of vehicles.
+type ResponseError struct {
+	Message  string            `json:"message"`
+	Data     any               `json:"data,omitempty"`
+	Vehicles []*VehicleHandler `json:"vehicles,omitempty"`
+	Error    bool              `json:"error"`
+}
+
 func requestVehicleToVehicle(vehicle RequestVehicle) *domain.Vehicle {
 	return &domain.Vehicle{
 		Id: vehicle.Id,
@@ -102,41 +136,156 @@ func vehicleToResponseVehicle(vehicle *domain.Vehicle) *VehicleHandler {
 	}
 }
 
-func validateErrors(err error) (code int, body ResponseBodyList) {
+// validateErrors returns the response for an error of the service, and the kind of the error.
+func validateErrors(err error) (code int, body ResponseBodyList, kind string) {
 	switch {
 	case errors.Is(err, service.ErrServiceVehicleNotFound):
 		code = http.StatusNotFound
 		body = ResponseBodyList{Message: "Not found", Error: true}
+		kind = "not_found"
 		return
 	case errors.Is(err, service.ErrServiceVehicleExist):
 		code = http.StatusConflict
 		body = ResponseBodyList{Message: "Identificador del vehículo ya existente", Error: true}
+		kind = "exist"
 		return
 	case errors.Is(err, service.ErrServiceVehicleNotFoundWithValue):
 		code = http.StatusNotFound
 		body = ResponseBodyList{Message: "No se encontraron vehículos con esos criterios.", Error: true}
+		kind = "not_found_with_value"
 		return
 	case errors.Is(err, service.ErrServiceImposibleMaxSpeed):
 		code = http.StatusBadRequest
 		body = ResponseBodyList{Message: "Velocidad mal formada o fuera de rango.", Error: true}
+		kind = "imposible_max_speed"
+		return
+	case errors.Is(err, service.ErrServiceVehicleTimeout):
+		code = http.StatusGatewayTimeout
+		body = ResponseBodyList{Message: "Gateway Timeout: tiempo de respuesta excedido", Error: true}
+		kind = "timeout"
+		return
+	case errors.Is(err, service.ErrServiceVehicleCanceled):
+		code = http.StatusServiceUnavailable
+		body = ResponseBodyList{Message: "Service Unavailable: solicitud cancelada", Error: true}
+		kind = "canceled"
+		return
+	case errors.Is(err, service.ErrServiceTenantNotFound):
+		code = http.StatusNotFound
+		body = ResponseBodyList{Message: "Tenant not found", Error: true}
+		kind = "tenant_not_found"
 		return
 	default:
 		code = http.StatusInternalServerError
 		body = ResponseBodyList{Message: "Internal server error", Error: true}
+		kind = "internal"
+		return
+	}
+}
+
+// responseError writes the response for an error of the service, records it in the span of the handler
+// and logs the server errors. The kind of the error is stored in the context so the metrics middleware counts it.
+func (c *ControllerVehicle) responseError(ctx *gin.Context, err error) {
+	code, body, kind := validateErrors(err)
+	ctx.Set(metrics.KeyErrorKind, kind)
+	span := trace.SpanFromContext(ctx.Request.Context())
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	if code >= http.StatusInternalServerError {
+		c.lg.ErrorContext(ctx.Request.Context(), "vehicle request failed", "route", ctx.FullPath(), "error", err)
+	}
+	ctx.JSON(code, body)
+}
+
+// span starts the span of the handler as a child of the span of the request, for the duration of the handler.
+// The returned function ends it.
+func (c *ControllerVehicle) span(ctx *gin.Context, name string) (end func()) {
+	req := ctx.Request
+	spanCtx, span := tracer.Start(req.Context(), "ControllerVehicle."+name)
+	ctx.Request = req.WithContext(spanCtx)
+	end = func() {
+		span.End()
+		ctx.Request = req
+	}
+	return
+}
+
+// service returns the vehicle service of the tenant of the request.
+// It writes the response and returns false if the tenant does not exist.
+func (c *ControllerVehicle) service(ctx *gin.Context) (sv service.ServiceVehicle, ok bool) {
+	sv, err := c.st.Tenant(tenant.FromContext(ctx.Request.Context()))
+	if err != nil {
+		c.responseError(ctx, err)
+		return
+	}
+	ok = true
+	return
+}
+
+// responseForbiddenBrand writes the response for a brand the principal of the request is not allowed to access.
+func responseForbiddenBrand(ctx *gin.Context, brand string) {
+	ctx.JSON(http.StatusForbidden, ResponseBody{
+		Message: "Forbidden: sin acceso a vehículos de la marca " + brand,
+		Data:    gin.H{"brand": brand},
+		Error:   true,
+	})
+}
+
+// filterByBrand keeps the vehicles whose brand the principal of the request is allowed to access.
+func filterByBrand(ctx *gin.Context, vehicles []*domain.Vehicle) (v []*domain.Vehicle, err error) {
+	principal := auth.PrincipalFromContext(ctx.Request.Context())
+	for _, vehicle := range vehicles {
+		if principal.HasBrand(vehicle.Attributes.Brand) {
+			v = append(v, vehicle)
+		}
+	}
+	if len(v) == 0 {
+		err = service.ErrServiceVehicleNotFoundWithValue
 		return
 	}
+	return
+}
+
+// authorizeVehicle checks the principal of the request is allowed to access the stored vehicle with the given id.
+// It writes the response and returns false otherwise.
+func (c *ControllerVehicle) authorizeVehicle(ctx *gin.Context, sv service.ServiceVehicle, id int) bool {
+	principal := auth.PrincipalFromContext(ctx.Request.Context())
+	if principal == nil || len(principal.Brands) == 0 {
+		return true
+	}
+
+	vehicle, err := sv.GetById(ctx.Request.Context(), id)
+	if err != nil {
+		c.responseError(ctx, err)
+		return false
+	}
+	if !principal.HasBrand(vehicle.Attributes.Brand) {
+		responseForbiddenBrand(ctx, vehicle.Attributes.Brand)
+		return false
+	}
+	return true
 }
 
 func (c *ControllerVehicle) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		defer c.span(ctx, "GetAll")()
+
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
 		// request
 		// ...
 
 		// process
-		vehicles, err := c.st.GetAll()
+		vehicles, err := sv.GetAll(ctx.Request.Context())
 		if err != nil {
-			code, body := validateErrors(err)
-			ctx.JSON(code, body)
+			c.responseError(ctx, err)
+			return
+		}
+		vehicles, err = filterByBrand(ctx, vehicles)
+		if err != nil {
+			c.responseError(ctx, err)
 			return
 		}
 
@@ -152,6 +301,13 @@ func (c *ControllerVehicle) GetAll() gin.HandlerFunc {
 
 func (c *ControllerVehicle) AddVehicle() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		defer c.span(ctx, "AddVehicle")()
+
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
 		// request
 		var requestVehicle RequestVehicle
 		err := ctx.ShouldBindJSON(&requestVehicle)
@@ -163,11 +319,14 @@ func (c *ControllerVehicle) AddVehicle() gin.HandlerFunc {
 			})
 			return
 		}
+		if !auth.PrincipalFromContext(ctx.Request.Context()).HasBrand(requestVehicle.Brand) {
+			responseForbiddenBrand(ctx, requestVehicle.Brand)
+			return
+		}
 		// process
-		vehicle, err := c.st.AddVehicle(requestVehicleToVehicle(requestVehicle))
+		vehicle, err := sv.AddVehicle(ctx.Request.Context(), requestVehicleToVehicle(requestVehicle))
 		if err != nil {
-			code, body := validateErrors(err)
-			ctx.JSON(code, body)
+			c.responseError(ctx, err)
 			return
 		}
 		// response
@@ -183,6 +342,13 @@ func (c *ControllerVehicle) AddVehicle() gin.HandlerFunc {
 
 func (c *ControllerVehicle) AddVehicles() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		defer c.span(ctx, "AddVehicles")()
+
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
 		// request
 		var requestVehicle []RequestVehicle
 		err := ctx.ShouldBindJSON(&requestVehicle)
@@ -194,15 +360,21 @@ func (c *ControllerVehicle) AddVehicles() gin.HandlerFunc {
 			})
 			return
 		}
+		principal := auth.PrincipalFromContext(ctx.Request.Context())
+		for _, vehicle := range requestVehicle {
+			if !principal.HasBrand(vehicle.Brand) {
+				responseForbiddenBrand(ctx, vehicle.Brand)
+				return
+			}
+		}
 		// process
 		var vehicles []*domain.Vehicle
 		for _, vehicle := range requestVehicle {
 			vehicles = append(vehicles, requestVehicleToVehicle(vehicle))
 		}
-		addedVehicles, err := c.st.AddVehicles(vehicles)
+		addedVehicles, err := sv.AddVehicles(ctx.Request.Context(), vehicles)
 		if err != nil {
-			code, body := validateErrors(err)
-			ctx.JSON(code, body)
+			c.responseError(ctx, err)
 			return
 		}
 		// response
@@ -222,16 +394,30 @@ func (c *ControllerVehicle) AddVehicles() gin.HandlerFunc {
 
 func (c *ControllerVehicle) GetByColorAndYear() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		defer c.span(ctx, "GetByColorAndYear")()
+
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
 		// request
 		color := ctx.Param("color")
-		year := ctx.Param("year")
-		intYear, _ := strconv.Atoi(year)
+		pr := newParams(ctx)
+		intYear := pr.pathInt("year")
+		if !pr.valid() {
+			return
+		}
 
 		// process
-		vehicles, err := c.st.GetByColorAndYear(color, intYear)
+		vehicles, err := sv.GetByColorAndYear(ctx.Request.Context(), color, intYear)
+		if err != nil {
+			c.responseError(ctx, err)
+			return
+		}
+		vehicles, err = filterByBrand(ctx, vehicles)
 		if err != nil {
-			code, body := validateErrors(err)
-			ctx.JSON(code, body)
+			c.responseError(ctx, err)
 			return
 		}
 
@@ -250,18 +436,36 @@ func (c *ControllerVehicle) GetByColorAndYear() gin.HandlerFunc {
 
 func (c *ControllerVehicle) GetByBrandAndPeriod() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		defer c.span(ctx, "GetByBrandAndPeriod")()
+
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
 		// request
 		brand := ctx.Param("brand")
-		start := ctx.Param("start_year")
-		end := ctx.Param("end_year")
-		intStart, _ := strconv.Atoi(start)
-		intEnd, _ := strconv.Atoi(end)
+		pr := newParams(ctx)
+		intStart := pr.pathInt("start_year")
+		intEnd := pr.pathInt("end_year")
+		pr.ordered("path", "start_year", float64(intStart), "end_year", float64(intEnd))
+		if !pr.valid() {
+			return
+		}
+		if !auth.PrincipalFromContext(ctx.Request.Context()).HasBrand(brand) {
+			responseForbiddenBrand(ctx, brand)
+			return
+		}
 
 		// process
-		vehicles, err := c.st.GetByBrandAndPeriod(brand, intStart, intEnd)
+		vehicles, err := sv.GetByBrandAndPeriod(ctx.Request.Context(), brand, intStart, intEnd)
+		if err != nil {
+			c.responseError(ctx, err)
+			return
+		}
+		vehicles, err = filterByBrand(ctx, vehicles)
 		if err != nil {
-			code, body := validateErrors(err)
-			ctx.JSON(code, body)
+			c.responseError(ctx, err)
 			return
 		}
 
@@ -277,11 +481,21 @@ func (c *ControllerVehicle) GetByBrandAndPeriod() gin.HandlerFunc {
 
 func (c *ControllerVehicle) UpdateSpeed() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		defer c.span(ctx, "UpdateSpeed")()
+
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
 		// request
-		id := ctx.Param("id")
-		intId, _ := strconv.Atoi(id)
-		var requestVehicle RequestVehicle
-		err := ctx.ShouldBindJSON(&requestVehicle)
+		pr := newParams(ctx)
+		intId := pr.pathId("id")
+		if !pr.valid() {
+			return
+		}
+		var requestSpeed RequestSpeed
+		err := ctx.ShouldBindJSON(&requestSpeed)
 		if err != nil {
 			ctx.JSON(http.StatusBadRequest, ResponseBodyList{
 				Message: "Bad Request: Velocidad mal formada o fuera de rango.",
@@ -291,13 +505,14 @@ func (c *ControllerVehicle) UpdateSpeed() gin.HandlerFunc {
 			return
 		}
 
+		if !c.authorizeVehicle(ctx, sv, intId) {
+			return
+		}
 		// process
-		vehicle := requestVehicleToVehicle(requestVehicle)
-		vehicle.Id = intId
-		updateVehicle, err := c.st.UpdateSpeed(vehicle)
+		vehicle := &domain.Vehicle{Id: intId, Attributes: domain.VehicleAttributes{MaxSpeed: requestSpeed.MaxSpeed}}
+		updateVehicle, err := sv.UpdateSpeed(ctx.Request.Context(), vehicle)
 		if err != nil {
-			code, body := validateErrors(err)
-			ctx.JSON(code, body)
+			c.responseError(ctx, err)
 			return
 		}
 
@@ -313,14 +528,24 @@ func (c *ControllerVehicle) UpdateSpeed() gin.HandlerFunc {
 
 func (c *ControllerVehicle) GetSpeedAverageByBrand() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		defer c.span(ctx, "GetSpeedAverageByBrand")()
+
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
 		// request
 		brand := ctx.Param("brand")
+		if !auth.PrincipalFromContext(ctx.Request.Context()).HasBrand(brand) {
+			responseForbiddenBrand(ctx, brand)
+			return
+		}
 
 		// process
-		average, err := c.st.GetSpeedAverageByBrand(brand)
+		average, err := sv.GetSpeedAverageByBrand(ctx.Request.Context(), brand)
 		if err != nil {
-			code, body := validateErrors(err)
-			ctx.JSON(code, body)
+			c.responseError(ctx, err)
 			return
 		}
 
@@ -337,14 +562,25 @@ func (c *ControllerVehicle) GetSpeedAverageByBrand() gin.HandlerFunc {
 
 func (c *ControllerVehicle) GetByFuelType() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		defer c.span(ctx, "GetByFuelType")()
+
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
 		// request
 		fuelType := ctx.Param("type")
 
 		// process
-		vehicles, err := c.st.GetByFuelType(fuelType)
+		vehicles, err := sv.GetByFuelType(ctx.Request.Context(), fuelType)
+		if err != nil {
+			c.responseError(ctx, err)
+			return
+		}
+		vehicles, err = filterByBrand(ctx, vehicles)
 		if err != nil {
-			code, body := validateErrors(err)
-			ctx.JSON(code, body)
+			c.responseError(ctx, err)
 			return
 		}
 
@@ -365,40 +601,62 @@ func (c *ControllerVehicle) GetByFuelType() gin.HandlerFunc {
 
 func (c *ControllerVehicle) DeleteVehicle() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		defer c.span(ctx, "DeleteVehicle")()
+
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
 		// request
-		id := ctx.Param("id")
-		intId, _ := strconv.Atoi(id)
+		pr := newParams(ctx)
+		intId := pr.pathId("id")
+		if !pr.valid() {
+			return
+		}
+		if !c.authorizeVehicle(ctx, sv, intId) {
+			return
+		}
 		// process
-		vehicle, err := c.st.DeleteVehicle(intId)
+		_, err := sv.DeleteVehicle(ctx.Request.Context(), intId)
 		if err != nil {
-			code, body := validateErrors(err)
-			ctx.JSON(code, body)
+			c.responseError(ctx, err)
 			return
 		}
-		// response
-		code := http.StatusNoContent
-		body := ResponseBody{
-			Message: "Vehículo eliminado exitosamente.",
-			Data:    vehicleToResponseVehicle(vehicle), //si hay un 204 no muestra por mas que lo ponga
-			Error:   false,
-		}
-		ctx.JSON(code, body)
+		// response: a 204 carries no body
+		ctx.Status(http.StatusNoContent)
 	}
 }
 
 func (c *ControllerVehicle) GetByWeight() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		defer c.span(ctx, "GetByWeight")()
+
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
 		// request
-		weightMin := ctx.Query("weight_min")
-		weightMax := ctx.Query("weight_max")
-		weightMaxInt, _ := strconv.Atoi(weightMax)
-		weightMinInt, _ := strconv.Atoi(weightMin)
+		// -> both bounds are optional, a missing one leaves the range open on its side
+		pr := newParams(ctx)
+		weightMin := pr.queryFloat("weight_min", math.Inf(-1))
+		weightMax := pr.queryFloat("weight_max", math.Inf(1))
+		pr.min("weight_min", "query", weightMin, 0)
+		pr.ordered("query", "weight_min", weightMin, "weight_max", weightMax)
+		if !pr.valid() {
+			return
+		}
 
 		// process
-		vehicles, err := c.st.GetByWeight(float64(weightMinInt), float64(weightMaxInt))
+		vehicles, err := sv.GetByWeight(ctx.Request.Context(), weightMin, weightMax)
+		if err != nil {
+			c.responseError(ctx, err)
+			return
+		}
+		vehicles, err = filterByBrand(ctx, vehicles)
 		if err != nil {
-			code, body := validateErrors(err)
-			ctx.JSON(code, body)
+			c.responseError(ctx, err)
 			return
 		}
 		// response
@@ -0,0 +1,503 @@
+package handlers
+
+import (
+	"app/internal/auth"
+	"app/internal/domain"
+	"app/internal/metrics"
+	"app/internal/tenant"
+	"app/internal/vehicle/service"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BasePathV2 is the path the routes of the api v2 are served under.
+const BasePathV2 = "/api/v2"
+
+// NewControllerVehicleV2 returns a new instance of a vehicle controller of the api v2.
+func NewControllerVehicleV2(st service.ServiceVehicleTenants, defaultLimit, maxLimit int, lg *slog.Logger) *ControllerVehicleV2 {
+	return &ControllerVehicleV2{st: st, defaultLimit: defaultLimit, maxLimit: maxLimit, lg: lg}
+}
+
+// ControllerVehicleV2 is an struct that represents the vehicle controller of the api v2.
+// Every response but the empty ones is an EnvelopeV2, the collections are filtered with query parameters
+// and paginated, and the resources link to the related ones and to the actions the principal may take on them.
+type ControllerVehicleV2 struct {
+	// st resolves the vehicle service of the tenant of every request, the same one of the api v1.
+	st service.ServiceVehicleTenants
+	// defaultLimit is the page size of the collections when the request does not set one.
+	defaultLimit int
+	// maxLimit is the maximum page size of the collections.
+	maxLimit int
+	// lg is the logger of the controller.
+	lg *slog.Logger
+}
+
+// EnvelopeV2 is the body of every response of the api v2 but the empty ones: the data of the resource
+// or the error, the links to the related resources, and the pagination of the collections.
+type EnvelopeV2 struct {
+	Data  any      `json:"data,omitempty"`
+	Error *ErrorV2 `json:"error,omitempty"`
+	Links LinksV2  `json:"links,omitempty"`
+	Meta  *PageV2  `json:"meta,omitempty"`
+}
+
+// ErrorV2 is the error of a response of the api v2. The code is stable and meant for the clients,
+// the message for the people, and the details, when there are any, tell what caused it.
+type ErrorV2 struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+// LinksV2 are the links of a resource, by relation: self, the collection it belongs to, the related resources,
+// and the actions on it, which carry their method.
+type LinksV2 map[string]LinkV2
+
+// LinkV2 is a link to a resource, or to an action when it has a method.
+type LinkV2 struct {
+	Href   string `json:"href"`
+	Method string `json:"method,omitempty"`
+}
+
+// PageV2 is the pagination of a collection.
+type PageV2 struct {
+	// Total is the number of resources that match the filters, in every page.
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// VehicleV2 is a vehicle of the api v2, with its links.
+type VehicleV2 struct {
+	VehicleHandler
+	Links LinksV2 `json:"links"`
+}
+
+// SpeedAverageV2 is the average max speed of the vehicles of a brand.
+type SpeedAverageV2 struct {
+	Brand           string  `json:"brand"`
+	AverageMaxSpeed float64 `json:"average_max_speed"`
+}
+
+// filterV2 is an struct that represents the filters of the vehicle collection. The strings are compared
+// case insensitively, the empty ones are not checked, and the ranges include their bounds.
+type filterV2 struct {
+	brand, model, color, fuelType, transmission string
+	yearMin, yearMax, maxSpeedMin, maxSpeedMax  int
+	weightMin, weightMax                        float64
+}
+
+// match returns true if the vehicle meets every filter.
+func (f filterV2) match(v *domain.Vehicle) bool {
+	a := v.Attributes
+	equal := func(want, got string) bool { return want == "" || strings.EqualFold(want, got) }
+	return equal(f.brand, a.Brand) && equal(f.model, a.Model) && equal(f.color, a.Color) &&
+		equal(f.fuelType, a.FuelType) && equal(f.transmission, a.Transmission) &&
+		a.Year >= f.yearMin && a.Year <= f.yearMax && a.MaxSpeed >= f.maxSpeedMin && a.MaxSpeed <= f.maxSpeedMax &&
+		a.Weight >= f.weightMin && a.Weight <= f.weightMax
+}
+
+// errorV2 returns the status code and the error of the api v2 for an error of the service, and the kind of the error.
+func errorV2(err error) (code int, e *ErrorV2, kind string) {
+	switch {
+	case errors.Is(err, service.ErrServiceVehicleNotFound):
+		return http.StatusNotFound, &ErrorV2{Code: "not_found", Message: "vehicle not found"}, "not_found"
+	case errors.Is(err, service.ErrServiceVehicleNotFoundWithValue):
+		return http.StatusNotFound, &ErrorV2{Code: "not_found", Message: "no vehicles match the criteria"}, "not_found_with_value"
+	case errors.Is(err, service.ErrServiceTenantNotFound):
+		return http.StatusNotFound, &ErrorV2{Code: "tenant_not_found", Message: "tenant not found"}, "tenant_not_found"
+	case errors.Is(err, service.ErrServiceVehicleExist):
+		return http.StatusConflict, &ErrorV2{Code: "conflict", Message: "vehicle id already exists"}, "exist"
+	case errors.Is(err, service.ErrServiceImposibleMaxSpeed):
+		return http.StatusBadRequest, &ErrorV2{Code: "invalid_body", Message: "max speed malformed or out of range"}, "imposible_max_speed"
+	case errors.Is(err, service.ErrServiceInvalidParam):
+		return http.StatusBadRequest, &ErrorV2{Code: "invalid_parameters", Message: "invalid parameters"}, "invalid_param"
+	case errors.Is(err, service.ErrServiceVehicleTimeout):
+		return http.StatusGatewayTimeout, &ErrorV2{Code: "timeout", Message: "operation timed out"}, "timeout"
+	case errors.Is(err, service.ErrServiceVehicleCanceled):
+		return http.StatusServiceUnavailable, &ErrorV2{Code: "canceled", Message: "operation canceled"}, "canceled"
+	default:
+		return http.StatusInternalServerError, &ErrorV2{Code: "internal", Message: "internal server error"}, "internal"
+	}
+}
+
+// responseError writes the response for an error of the service and logs the server errors.
+// The kind of the error is stored in the context so the metrics middleware counts it.
+func (c *ControllerVehicleV2) responseError(ctx *gin.Context, err error) {
+	code, e, kind := errorV2(err)
+	ctx.Set(metrics.KeyErrorKind, kind)
+	if code >= http.StatusInternalServerError {
+		c.lg.ErrorContext(ctx.Request.Context(), "vehicle request failed", "route", ctx.FullPath(), "error", err)
+	}
+	ctx.JSON(code, EnvelopeV2{Error: e})
+}
+
+// responseInvalidBody writes the response for a body that can not be decoded.
+func responseInvalidBody(ctx *gin.Context) {
+	ctx.Set(metrics.KeyErrorKind, "invalid_body")
+	ctx.JSON(http.StatusBadRequest, EnvelopeV2{Error: &ErrorV2{Code: "invalid_body", Message: "body malformed or incomplete"}})
+}
+
+// responseForbiddenBrandV2 writes the response for a brand the principal of the request is not allowed to access.
+func responseForbiddenBrandV2(ctx *gin.Context, brand string) {
+	ctx.JSON(http.StatusForbidden, EnvelopeV2{Error: &ErrorV2{
+		Code:    "forbidden",
+		Message: "no access to the vehicles of the brand " + brand,
+		Details: gin.H{"brand": brand},
+	}})
+}
+
+// service returns the vehicle service of the tenant of the request.
+// It writes the response and returns false if the tenant does not exist.
+func (c *ControllerVehicleV2) service(ctx *gin.Context) (sv service.ServiceVehicle, ok bool) {
+	sv, err := c.st.Tenant(tenant.FromContext(ctx.Request.Context()))
+	if err != nil {
+		c.responseError(ctx, err)
+		return
+	}
+	ok = true
+	return
+}
+
+// get returns the vehicle with the id, if the principal of the request is allowed to access it.
+// It writes the response and returns false otherwise.
+func (c *ControllerVehicleV2) get(ctx *gin.Context, sv service.ServiceVehicle, id int) (v *domain.Vehicle, ok bool) {
+	v, err := sv.GetById(ctx.Request.Context(), id)
+	if err != nil {
+		c.responseError(ctx, err)
+		return
+	}
+	if !auth.PrincipalFromContext(ctx.Request.Context()).HasBrand(v.Attributes.Brand) {
+		responseForbiddenBrandV2(ctx, v.Attributes.Brand)
+		return
+	}
+	ok = true
+	return
+}
+
+// vehicleV2 returns a vehicle with its links: itself, its collection, the vehicles and the average speed of its brand,
+// and the actions the principal of the request is allowed to take on it.
+func vehicleV2(ctx *gin.Context, v *domain.Vehicle) VehicleV2 {
+	self := BasePathV2 + "/vehicles/" + strconv.Itoa(v.Id)
+	links := LinksV2{
+		"self":          {Href: self},
+		"collection":    {Href: BasePathV2 + "/vehicles"},
+		"brand":         {Href: BasePathV2 + "/vehicles?" + url.Values{"brand": {v.Attributes.Brand}}.Encode()},
+		"average_speed": {Href: BasePathV2 + "/brands/" + url.PathEscape(v.Attributes.Brand) + "/average_speed"},
+	}
+	principal := auth.PrincipalFromContext(ctx.Request.Context())
+	if principal == nil || principal.HasPermission(auth.PermissionVehiclesWrite) {
+		links["update"] = LinkV2{Href: self, Method: http.MethodPatch}
+	}
+	if principal == nil || principal.HasPermission(auth.PermissionVehiclesDelete) {
+		links["delete"] = LinkV2{Href: self, Method: http.MethodDelete}
+	}
+	return VehicleV2{VehicleHandler: *vehicleToResponseVehicle(v), Links: links}
+}
+
+// vehiclesV2 returns the vehicles with their links.
+func vehiclesV2(ctx *gin.Context, vehicles []*domain.Vehicle) []VehicleV2 {
+	v := make([]VehicleV2, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		v = append(v, vehicleV2(ctx, vehicle))
+	}
+	return v
+}
+
+// pageLinks returns the links of a page of the collection of the request: itself, the first, previous, next
+// and last pages, keeping the filters of the request.
+func pageLinks(ctx *gin.Context, page PageV2) LinksV2 {
+	href := func(offset int) LinkV2 {
+		query := ctx.Request.URL.Query()
+		query.Set("limit", strconv.Itoa(page.Limit))
+		query.Set("offset", strconv.Itoa(offset))
+		return LinkV2{Href: ctx.Request.URL.Path + "?" + query.Encode()}
+	}
+	last := 0
+	if page.Total > 0 {
+		last = (page.Total - 1) / page.Limit * page.Limit
+	}
+	links := LinksV2{"self": href(page.Offset), "first": href(0), "last": href(last)}
+	if page.Offset > 0 {
+		links["prev"] = href(max(page.Offset-page.Limit, 0))
+	}
+	if page.Offset+page.Limit < page.Total {
+		links["next"] = href(page.Offset + page.Limit)
+	}
+	return links
+}
+
+// Index returns the links to the resources of the api v2, the entry point of its clients.
+func (c *ControllerVehicleV2) Index() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// response
+		ctx.JSON(http.StatusOK, EnvelopeV2{Links: LinksV2{
+			"self":     {Href: BasePathV2},
+			"vehicles": {Href: BasePathV2 + "/vehicles"},
+			"openapi":  {Href: "/openapi.json"},
+		}})
+	}
+}
+
+// List returns a page of the vehicles that match the filters of the query, sorted by id.
+// A collection without vehicles is not an error, but an empty page.
+func (c *ControllerVehicleV2) List() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
+		// request
+		pr := newParamsV2(ctx)
+		f := filterV2{
+			brand: ctx.Query("brand"), model: ctx.Query("model"), color: ctx.Query("color"),
+			fuelType: ctx.Query("fuel_type"), transmission: ctx.Query("transmission"),
+			yearMin:     pr.queryInt("year_min", math.MinInt),
+			yearMax:     pr.queryInt("year_max", math.MaxInt),
+			maxSpeedMin: pr.queryInt("max_speed_min", math.MinInt),
+			maxSpeedMax: pr.queryInt("max_speed_max", math.MaxInt),
+			weightMin:   pr.queryFloat("weight_min", math.Inf(-1)),
+			weightMax:   pr.queryFloat("weight_max", math.Inf(1)),
+		}
+		pr.ordered("query", "year_min", float64(f.yearMin), "year_max", float64(f.yearMax))
+		pr.ordered("query", "max_speed_min", float64(f.maxSpeedMin), "max_speed_max", float64(f.maxSpeedMax))
+		pr.ordered("query", "weight_min", f.weightMin, "weight_max", f.weightMax)
+		// -> the exact year narrows the range of years
+		if _, ok := ctx.GetQuery("year"); ok {
+			if year := pr.queryInt("year", 0); !pr.failed("year") {
+				f.yearMin, f.yearMax = max(f.yearMin, year), min(f.yearMax, year)
+			}
+		}
+		page := PageV2{Limit: pr.queryInt("limit", c.defaultLimit), Offset: pr.queryInt("offset", 0)}
+		pr.min("limit", "query", float64(page.Limit), 1)
+		pr.max("limit", "query", float64(page.Limit), float64(c.maxLimit))
+		pr.min("offset", "query", float64(page.Offset), 0)
+		if !pr.valid() {
+			return
+		}
+
+		// process
+		vehicles, err := sv.GetAll(ctx.Request.Context())
+		if err != nil && !errors.Is(err, service.ErrServiceVehicleNotFound) {
+			c.responseError(ctx, err)
+			return
+		}
+		principal := auth.PrincipalFromContext(ctx.Request.Context())
+		var matched []*domain.Vehicle
+		for _, vehicle := range vehicles {
+			if principal.HasBrand(vehicle.Attributes.Brand) && f.match(vehicle) {
+				matched = append(matched, vehicle)
+			}
+		}
+		sort.Slice(matched, func(i, j int) bool { return matched[i].Id < matched[j].Id })
+		page.Total = len(matched)
+		start := min(page.Offset, page.Total)
+		end := min(start+page.Limit, page.Total)
+
+		// response
+		ctx.JSON(http.StatusOK, EnvelopeV2{Data: vehiclesV2(ctx, matched[start:end]), Links: pageLinks(ctx, page), Meta: &page})
+	}
+}
+
+// Get returns the vehicle with the id.
+func (c *ControllerVehicleV2) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
+		// request
+		pr := newParamsV2(ctx)
+		id := pr.pathId("id")
+		if !pr.valid() {
+			return
+		}
+
+		// process
+		vehicle, ok := c.get(ctx, sv, id)
+		if !ok {
+			return
+		}
+
+		// response
+		v := vehicleV2(ctx, vehicle)
+		ctx.JSON(http.StatusOK, EnvelopeV2{Data: v, Links: v.Links})
+	}
+}
+
+// Create adds a vehicle and answers 201 with its location.
+func (c *ControllerVehicleV2) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
+		// request
+		var request RequestVehicle
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			responseInvalidBody(ctx)
+			return
+		}
+		if !auth.PrincipalFromContext(ctx.Request.Context()).HasBrand(request.Brand) {
+			responseForbiddenBrandV2(ctx, request.Brand)
+			return
+		}
+
+		// process
+		vehicle, err := sv.AddVehicle(ctx.Request.Context(), requestVehicleToVehicle(request))
+		if err != nil {
+			c.responseError(ctx, err)
+			return
+		}
+
+		// response
+		v := vehicleV2(ctx, vehicle)
+		ctx.Header("Location", v.Links["self"].Href)
+		ctx.JSON(http.StatusCreated, EnvelopeV2{Data: v, Links: v.Links})
+	}
+}
+
+// CreateBatch adds several vehicles, all or none, and answers 201 as Create.
+func (c *ControllerVehicleV2) CreateBatch() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
+		// request
+		var request []RequestVehicle
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			responseInvalidBody(ctx)
+			return
+		}
+		principal := auth.PrincipalFromContext(ctx.Request.Context())
+		vehicles := make([]*domain.Vehicle, 0, len(request))
+		for _, vehicle := range request {
+			if !principal.HasBrand(vehicle.Brand) {
+				responseForbiddenBrandV2(ctx, vehicle.Brand)
+				return
+			}
+			vehicles = append(vehicles, requestVehicleToVehicle(vehicle))
+		}
+
+		// process
+		added, err := sv.AddVehicles(ctx.Request.Context(), vehicles)
+		if err != nil {
+			c.responseError(ctx, err)
+			return
+		}
+
+		// response
+		ctx.JSON(http.StatusCreated, EnvelopeV2{Data: vehiclesV2(ctx, added), Links: LinksV2{"collection": {Href: BasePathV2 + "/vehicles"}}})
+	}
+}
+
+// Patch updates the max speed of the vehicle with the id.
+func (c *ControllerVehicleV2) Patch() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
+		// request
+		pr := newParamsV2(ctx)
+		id := pr.pathId("id")
+		if !pr.valid() {
+			return
+		}
+		var request RequestSpeed
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			responseInvalidBody(ctx)
+			return
+		}
+		if _, ok := c.get(ctx, sv, id); !ok {
+			return
+		}
+
+		// process
+		vehicle, err := sv.UpdateSpeed(ctx.Request.Context(), &domain.Vehicle{Id: id, Attributes: domain.VehicleAttributes{MaxSpeed: request.MaxSpeed}})
+		if err != nil {
+			c.responseError(ctx, err)
+			return
+		}
+
+		// response
+		v := vehicleV2(ctx, vehicle)
+		ctx.JSON(http.StatusOK, EnvelopeV2{Data: v, Links: v.Links})
+	}
+}
+
+// Delete deletes the vehicle with the id and answers 204 without a body.
+func (c *ControllerVehicleV2) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
+		// request
+		pr := newParamsV2(ctx)
+		id := pr.pathId("id")
+		if !pr.valid() {
+			return
+		}
+		if _, ok := c.get(ctx, sv, id); !ok {
+			return
+		}
+
+		// process
+		if _, err := sv.DeleteVehicle(ctx.Request.Context(), id); err != nil {
+			c.responseError(ctx, err)
+			return
+		}
+
+		// response
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// SpeedAverage returns the average max speed of the vehicles of the brand.
+func (c *ControllerVehicleV2) SpeedAverage() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sv, ok := c.service(ctx)
+		if !ok {
+			return
+		}
+
+		// request
+		brand := ctx.Param("brand")
+		if !auth.PrincipalFromContext(ctx.Request.Context()).HasBrand(brand) {
+			responseForbiddenBrandV2(ctx, brand)
+			return
+		}
+
+		// process
+		average, err := sv.GetSpeedAverageByBrand(ctx.Request.Context(), brand)
+		if err != nil {
+			c.responseError(ctx, err)
+			return
+		}
+
+		// response
+		ctx.JSON(http.StatusOK, EnvelopeV2{
+			Data: SpeedAverageV2{Brand: brand, AverageMaxSpeed: average},
+			Links: LinksV2{
+				"self":     {Href: BasePathV2 + "/brands/" + url.PathEscape(brand) + "/average_speed"},
+				"vehicles": {Href: BasePathV2 + "/vehicles?" + url.Values{"brand": {brand}}.Encode()},
+			},
+		})
+	}
+}
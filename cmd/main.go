@@ -1,58 +1,154 @@
 package main
 
 import (
-	"app/cmd/handlers"
-	"app/internal/vehicle/loader"
-	"app/internal/vehicle/repository"
-	"app/internal/vehicle/service"
+	"app/cmd/server"
+	"app/internal/config"
+	"app/internal/logging"
+	"app/internal/tracing"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/soheilhy/cmux"
 )
 
 func main() {
-	// env
+	// config: defaults, then the configuration file, then the environment (and .env), then the flags
 	godotenv.Load(".env")
+	cfg, err := config.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
 
-	// dependencies
-	ldVh := loader.NewLoaderVehicleJSON(os.Getenv("FILE_PATH_VEHICLES_JSON"))
-	dbVh, err := ldVh.Load()
+	// logger
+	lg, err := logging.New(os.Stdout, logging.Config{
+		Format: cfg.Log.Format,
+		Level:  cfg.Log.Level,
+		Redact: cfg.Log.Redact,
+	})
 	if err != nil {
 		panic(err)
 	}
 
-	rpVh := repository.NewRepositoryVehicleInMemory(dbVh)
-	svVh := service.NewServiceVehicleDefault(rpVh)
-	ctVh := handlers.NewControllerVehicle(svVh)
+	// tracing
+	shutdownTracing, err := tracing.Setup(tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		File:        cfg.Tracing.File,
+		ServiceName: "vehicle-api",
+	})
+	if err != nil {
+		panic(err)
+	}
 
-	// server
-	rt := gin.New()
-	// -> middlewares
-	rt.Use(gin.Recovery())
-	rt.Use(gin.Logger())
-	// -> handlers
-	api := rt.Group("/api/v1")
-	grVh := api.Group("/vehicles")
-	{
-		grVh.GET("", ctVh.GetAll())
-		grVh.GET("/color/:color/year/:year", ctVh.GetByColorAndYear())
-		grVh.GET("/brand/:brand/between/:start_year/:end_year", ctVh.GetByBrandAndPeriod())
-		grVh.GET("/average_speed/brand/:brand", ctVh.GetSpeedAverageByBrand())
-		grVh.GET("/fuel_type/:type", ctVh.GetByFuelType())
-		grVh.GET("/weight", ctVh.GetByWeight())
+	// dependencies, middlewares and routes
+	api, err := server.New(cfg, lg)
+	if err != nil {
+		panic(err)
+	}
 
-		grVh.POST("", ctVh.AddVehicle())
-		grVh.POST("/batch", ctVh.AddVehicles())
+	// run
+	srv := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           api.Router,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+	}
+	// -> hooks run once the connections are drained, e.g. to flush the repositories that persist their vehicles
+	onShutdown := []func(ctx context.Context) error{api.Shutdown, shutdownTracing}
 
-		grVh.PUT("/:id/update_speed", ctVh.UpdateSpeed())
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-		grVh.DELETE("/:id", ctVh.DeleteVehicle())
+	lis, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		panic(err)
+	}
+	// -> the gRPC api listens on its own address, or shares the one of the server, told apart by the content type
+	var lisGRPC net.Listener
+	var mux cmux.CMux
+	if api.GRPC != nil {
+		if cfg.GRPC.Addr == cfg.Server.Addr {
+			mux = cmux.New(lis)
+			lisGRPC = mux.MatchWithWriters(cmux.HTTP2MatchHeaderFieldSendSettings("content-type", "application/grpc"))
+			lis = mux.Match(cmux.Any())
+		} else if lisGRPC, err = net.Listen("tcp", cfg.GRPC.Addr); err != nil {
+			panic(err)
+		}
+	}
+
+	errs := make(chan error, 4)
+	go func() {
+		lg.Info("server listening", "addr", srv.Addr)
+		errs <- srv.Serve(lis)
+	}()
+	if lisGRPC != nil {
+		go func() {
+			lg.Info("grpc server listening", "addr", cfg.GRPC.Addr)
+			errs <- api.GRPC.Serve(lisGRPC)
+		}()
+	}
+	if mux != nil {
+		go func() {
+			errs <- mux.Serve()
+		}()
+	}
+	go func() {
+		if err := api.Load(ctx); err != nil {
+			errs <- err
+			return
+		}
+		api.Run(ctx)
+	}()
 
+	exitCode := 0
+	select {
+	case err := <-errs:
+		lg.Error("server failed", "error", err)
+		exitCode = 1
+	case <-ctx.Done():
+		lg.Info("shutdown signal received")
 	}
 
-	// run
-	if err := rt.Run(os.Getenv("SERVER_ADDR")); err != nil {
-		panic(err)
+	// shutdown: stop being ready, let the load balancers notice, then drain the connections
+	api.Health.SetShuttingDown()
+	time.Sleep(cfg.Server.ShutdownDelay)
+	ctxShutdown, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	// -> the calls and streams in flight are let finish, as the http requests, until the shutdown times out
+	stoppedGRPC := make(chan struct{})
+	go func() {
+		defer close(stoppedGRPC)
+		if api.GRPC == nil {
+			return
+		}
+		go func() {
+			<-ctxShutdown.Done()
+			api.GRPC.Stop()
+		}()
+		api.GRPC.GracefulStop()
+	}()
+	if err := srv.Shutdown(ctxShutdown); err != nil {
+		lg.Error("server shutdown failed", "error", err)
+		exitCode = 1
+	}
+	<-stoppedGRPC
+	for _, hook := range onShutdown {
+		if err := hook(ctxShutdown); err != nil {
+			lg.Error("shutdown hook failed", "error", err)
+			exitCode = 1
+		}
 	}
+	lg.Info("server stopped")
+	os.Exit(exitCode)
 }
@@ -0,0 +1,88 @@
+package middlewares
+
+import (
+	"app/internal/auth"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewAuth returns a new instance of an auth middleware.
+// A nil authenticator disables authentication and authorization.
+func NewAuth(au auth.Authenticator) *Auth {
+	return &Auth{au: au}
+}
+
+// Auth is an struct that represents an auth middleware.
+type Auth struct {
+	// au is the authenticator of the api keys.
+	au auth.Authenticator
+}
+
+// errorBody is the body returned when a middleware aborts a request.
+type errorBody struct {
+	Message string `json:"message"`
+	Data    any    `json:"data"`
+	Error   bool   `json:"error"`
+}
+
+// Token returns the api key of the request, taken from the Authorization bearer or the X-API-Key header.
+func Token(r *http.Request) string {
+	if authorization := r.Header.Get("Authorization"); strings.HasPrefix(authorization, "Bearer ") {
+		return strings.TrimPrefix(authorization, "Bearer ")
+	}
+	return r.Header.Get("X-API-Key")
+}
+
+// Authenticate resolves the principal of the request and stores it in the request context.
+func (a *Auth) Authenticate() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if a.au == nil {
+			ctx.Next()
+			return
+		}
+
+		principal, err := a.au.Authenticate(Token(ctx.Request))
+		if err != nil {
+			ctx.Header("WWW-Authenticate", "Bearer")
+			abort(ctx, http.StatusUnauthorized, "unauthorized", errorBody{Message: "Unauthorized: api key missing or invalid", Error: true})
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(auth.NewContext(ctx.Request.Context(), principal))
+		ctx.Next()
+	}
+}
+
+// Restrict aborts the request with 403 when authentication is disabled, for the routes that must never be open.
+func (a *Auth) Restrict() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if a.au == nil {
+			abort(ctx, http.StatusForbidden, "forbidden", errorBody{Message: "Forbidden: authentication is not configured", Error: true})
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// Require aborts the request with 403 unless the principal was granted the permission.
+func (a *Auth) Require(perm auth.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if a.au == nil {
+			ctx.Next()
+			return
+		}
+
+		principal := auth.PrincipalFromContext(ctx.Request.Context())
+		if principal == nil || !principal.HasPermission(perm) {
+			abort(ctx, http.StatusForbidden, "forbidden", errorBody{
+				Message: "Forbidden: missing permission " + string(perm),
+				Data:    gin.H{"required_permission": perm},
+				Error:   true,
+			})
+			return
+		}
+		ctx.Next()
+	}
+}
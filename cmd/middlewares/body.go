@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"app/internal/metrics"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimit reads the bodies of the requests up to n bytes, and aborts the ones beyond it with 413. The body is
+// restored already read, so the middlewares and the handlers after it never read more than n bytes.
+// A limit of zero does not limit them.
+func BodyLimit(n int64) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if n <= 0 || ctx.Request.Body == nil || ctx.Request.Body == http.NoBody {
+			ctx.Next()
+			return
+		}
+
+		// -> the declared length is refused without reading the body
+		if ctx.Request.ContentLength > n {
+			tooLarge(ctx)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, n))
+		if err != nil {
+			var errMax *http.MaxBytesError
+			if errors.As(err, &errMax) {
+				tooLarge(ctx)
+				return
+			}
+			abort(ctx, http.StatusBadRequest, "invalid_body", errorBody{Message: "Bad Request: cuerpo ilegible", Error: true})
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		ctx.Next()
+	}
+}
+
+// tooLarge aborts the request with 413.
+func tooLarge(ctx *gin.Context) {
+	ctx.Set(metrics.KeyErrorKind, "body_too_large")
+	abort(ctx, http.StatusRequestEntityTooLarge, "body_too_large", errorBody{Message: "Request Entity Too Large: cuerpo demasiado grande", Error: true})
+}
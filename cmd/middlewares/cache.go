@@ -0,0 +1,193 @@
+package middlewares
+
+import (
+	"app/internal/auth"
+	"app/internal/httpcache"
+	"app/internal/tenant"
+	"bytes"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewCaching returns a new instance of a caching middleware over the version of the data of the tenants of the source.
+// The clients may reuse a response for maxAge without revalidating it, zero makes them revalidate every time.
+// A nil cache only sets the caching headers and answers the conditional requests.
+// The responses vary with the headers of vary, such as the api key and the tenant.
+func NewCaching(src httpcache.Source, ch httpcache.Cache, maxAge time.Duration, vary ...string) *Caching {
+	control := "private, no-cache"
+	if maxAge > 0 {
+		control = "private, max-age=" + strconv.Itoa(int(maxAge.Seconds()))
+	}
+	return &Caching{src: src, ch: ch, control: control, vary: strings.Join(vary, ", "), now: time.Now}
+}
+
+// Caching is an struct that represents a middleware caching the responses of the read routes.
+// The successful responses carry an ETag and a Last-Modified header driven by the version of the data,
+// so the clients revalidate them with If-None-Match or If-Modified-Since and get a 304 while it does not change.
+type Caching struct {
+	// src versions the data the responses are computed from.
+	src httpcache.Source
+	// ch stores the responses, nil if they are not stored.
+	ch httpcache.Cache
+	// control is the Cache-Control header of the responses.
+	control string
+	// vary are the request headers, other than Accept-Encoding, the responses vary with.
+	vary string
+	// now returns the current time.
+	now func() time.Time
+}
+
+// cachingWriter is an struct that sets the caching headers of a response as it is written, if it is successful,
+// and copies its body to store it.
+type cachingWriter struct {
+	gin.ResponseWriter
+	// headers sets the caching headers.
+	headers func(h http.Header)
+	// body is the copy of the body, nil if the response is not stored.
+	body *bytes.Buffer
+	// checked is set once the status of the response is known.
+	checked bool
+}
+
+// check sets the caching headers once the status of the response is known, if it is successful.
+func (w *cachingWriter) check() {
+	if w.checked {
+		return
+	}
+	w.checked = true
+	if w.Status() == http.StatusOK {
+		w.headers(w.Header())
+	}
+}
+
+// WriteHeaderNow writes the header of the response, with the caching headers if it is successful.
+func (w *cachingWriter) WriteHeaderNow() {
+	w.check()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Write writes the data to the response and to the copy of the body.
+func (w *cachingWriter) Write(data []byte) (int, error) {
+	w.check()
+	if w.body != nil {
+		w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString writes the string to the response and to the copy of the body.
+func (w *cachingWriter) WriteString(s string) (int, error) {
+	w.check()
+	if w.body != nil {
+		w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Handle answers with 304 the conditional requests of a client that holds the current response,
+// serves the stored response computed from the current version of the data if any, and stores the
+// successful responses otherwise. The responses of the api depend on the tenant and the user too,
+// so both scope the validators and the stored responses.
+func (c *Caching) Handle() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// -> the version is read before the response is computed, so the response is at least as recent,
+		// and the requests of an unknown tenant are left to the handlers
+		id := tenant.FromContext(ctx.Request.Context())
+		n, modified, err := c.src.Version(id)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+		var user string
+		if principal := auth.PrincipalFromContext(ctx.Request.Context()); principal != nil {
+			user = principal.Name
+		}
+		scope := id + "|" + user
+		etag := entityTag(n, modified, scope)
+		modified, date := lastModified(modified, c.now())
+		headers := func(h http.Header) {
+			h.Set("Cache-Control", c.control)
+			h.Set("ETag", etag)
+			h.Set("Last-Modified", date.Format(http.TimeFormat))
+			if c.vary != "" {
+				h.Add("Vary", c.vary)
+			}
+		}
+
+		if notModified(ctx.Request, etag, modified) {
+			headers(ctx.Writer.Header())
+			ctx.AbortWithStatus(http.StatusNotModified)
+			return
+		}
+
+		w := &cachingWriter{ResponseWriter: ctx.Writer, headers: headers}
+		key := scope + "|" + ctx.Request.URL.Path + "?" + ctx.Request.URL.Query().Encode()
+		if c.ch != nil {
+			if r, ok := c.ch.Get(key, n); ok {
+				headers(ctx.Writer.Header())
+				ctx.Header("X-Cache", "HIT")
+				ctx.Data(r.Status, r.Header.Get("Content-Type"), r.Body)
+				ctx.Abort()
+				return
+			}
+			ctx.Header("X-Cache", "MISS")
+			w.body = &bytes.Buffer{}
+		}
+
+		ctx.Writer = w
+		ctx.Next()
+
+		if w.body == nil || w.Status() != http.StatusOK {
+			return
+		}
+		c.ch.Set(key, &httpcache.Response{
+			Status:  w.Status(),
+			Header:  http.Header{"Content-Type": w.Header().Values("Content-Type")},
+			Body:    w.body.Bytes(),
+			Version: n,
+		})
+	}
+}
+
+// entityTag returns the weak entity tag of the responses of a scope at a version of the data.
+// The time of the modification tells apart the versions counted by different processes.
+func entityTag(n uint64, modified time.Time, scope string) string {
+	h := fnv.New64a()
+	h.Write([]byte(strconv.FormatUint(n, 10) + "|" + strconv.FormatInt(modified.UnixNano(), 10) + "|" + scope))
+	return `W/"` + strconv.FormatUint(h.Sum64(), 16) + `"`
+}
+
+// lastModified returns the date the data is not modified after, and the date of the Last-Modified header.
+// The dates of http have no fraction of a second, so the modification is rounded up to the end of its second.
+// While that second is not over the data may change again within it, and the header is the start of the second
+// instead, which a client sending it back as If-Modified-Since does not revalidate with.
+func lastModified(modified, now time.Time) (end, header time.Time) {
+	end = modified.UTC().Truncate(time.Second).Add(time.Second)
+	header = end
+	if now.Before(end) {
+		header = end.Add(-time.Second)
+	}
+	return
+}
+
+// notModified reports whether the client holds the current response: by its entity tag if the request
+// has an If-None-Match header, by the date the data is not modified after otherwise.
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		// -> the comparison is weak, as the responses are the same whatever their encoding
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	return err == nil && !modified.After(since)
+}
@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// source is an struct that implements the httpcache.Source interface with a version set by the tests.
+type source struct {
+	n        uint64
+	modified time.Time
+}
+
+func (s *source) Version(tenant string) (n uint64, modified time.Time, err error) {
+	return s.n, s.modified, nil
+}
+
+func TestLastModified(t *testing.T) {
+	second := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := []struct {
+		name     string
+		modified time.Time
+		now      time.Time
+		end      time.Time
+		header   time.Time
+	}{
+		{"second over", second.Add(500 * time.Millisecond), second.Add(2 * time.Second), second.Add(time.Second), second.Add(time.Second)},
+		{"second not over", second.Add(500 * time.Millisecond), second.Add(700 * time.Millisecond), second.Add(time.Second), second},
+		{"start of the second", second, second.Add(time.Second), second.Add(time.Second), second.Add(time.Second)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			end, header := lastModified(c.modified, c.now)
+			if !end.Equal(c.end) || !header.Equal(c.header) {
+				t.Errorf("lastModified = %v, %v, want %v, %v", end, header, c.end, c.header)
+			}
+		})
+	}
+}
+
+func TestCaching_ModifiedWithinTheSecond(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	second := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := second.Add(100 * time.Millisecond)
+	src := &source{n: 1, modified: now}
+	c := NewCaching(src, nil, 0)
+	c.now = func() time.Time { return now }
+	rt := gin.New()
+	rt.GET("/", c.Handle(), func(ctx *gin.Context) { ctx.String(http.StatusOK, "ok") })
+	get := func(since string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if since != "" {
+			req.Header.Set("If-Modified-Since", since)
+		}
+		rec := httptest.NewRecorder()
+		rt.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// -> the data changes again within the second the client got its response in
+	since := get("").Header().Get("Last-Modified")
+	now = now.Add(100 * time.Millisecond)
+	src.n, src.modified = 2, now
+	if rec := get(since); rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	// -> once the second is over the date revalidates the response
+	now = now.Add(time.Second)
+	since = get("").Header().Get("Last-Modified")
+	if rec := get(since); rec.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotModified)
+	}
+}
@@ -0,0 +1,253 @@
+package middlewares
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	// ErrCompressEncoding is returned when an encoding is not supported.
+	ErrCompressEncoding = errors.New("middlewares: unsupported content encoding")
+	// ErrCompressLevel is returned when a compression level is not supported.
+	ErrCompressLevel = errors.New("middlewares: unsupported compression level")
+)
+
+// encodingWriter is the interface of the writers compressing into a content encoding, reset to be reused.
+type encodingWriter interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// encoder is an struct that represents a content encoding the responses are compressed with.
+type encoder struct {
+	name string
+	// pool reuses the writers, as some are costly to create.
+	pool sync.Pool
+}
+
+// newEncoder returns the encoder of the content encoding at the level: fastest, default or best.
+func newEncoder(name, level string) (e *encoder, err error) {
+	levels := map[string]map[string]int{
+		"gzip": {"fastest": gzip.BestSpeed, "default": gzip.DefaultCompression, "best": gzip.BestCompression},
+		"br":   {"fastest": brotli.BestSpeed, "default": brotli.DefaultCompression, "best": brotli.BestCompression},
+		"zstd": {"fastest": int(zstd.SpeedFastest), "default": int(zstd.SpeedDefault), "best": int(zstd.SpeedBestCompression)},
+	}
+	if _, ok := levels[name]; !ok {
+		err = fmt.Errorf("%w. %s", ErrCompressEncoding, name)
+		return
+	}
+	l, ok := levels[name][level]
+	if !ok {
+		err = fmt.Errorf("%w. %s", ErrCompressLevel, level)
+		return
+	}
+
+	e = &encoder{name: name}
+	switch name {
+	case "gzip":
+		e.pool.New = func() any {
+			w, _ := gzip.NewWriterLevel(nil, l)
+			return w
+		}
+	case "br":
+		e.pool.New = func() any { return brotli.NewWriterLevel(nil, l) }
+	case "zstd":
+		e.pool.New = func() any {
+			w, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevel(l)), zstd.WithEncoderConcurrency(1))
+			return w
+		}
+	}
+	return
+}
+
+// encode returns the data compressed.
+func (e *encoder) encode(data []byte) (encoded []byte, err error) {
+	var b bytes.Buffer
+	w := e.pool.Get().(encodingWriter)
+	defer e.pool.Put(w)
+
+	w.Reset(&b)
+	if _, err = w.Write(data); err != nil {
+		return
+	}
+	if err = w.Close(); err != nil {
+		return
+	}
+	encoded = b.Bytes()
+	return
+}
+
+// NewCompress returns a new instance of a compression middleware offering the encodings, in order of preference,
+// at the level: fastest, default or best. The responses smaller than minSize bytes are not compressed.
+func NewCompress(encodings []string, level string, minSize int) (c *Compress, err error) {
+	c = &Compress{minSize: minSize}
+	for _, name := range encodings {
+		var e *encoder
+		if e, err = newEncoder(name, level); err != nil {
+			return
+		}
+		c.encoders = append(c.encoders, e)
+	}
+	return
+}
+
+// Compress is an struct that represents a middleware compressing the responses in the content encoding
+// negotiated with the Accept-Encoding header of the request: zstd, br or gzip.
+type Compress struct {
+	// encoders are the encodings offered, in order of preference.
+	encoders []*encoder
+	// minSize is the size from which a response is compressed.
+	minSize int
+}
+
+// compressWriter is an struct that holds back the body of a response to compress it once complete.
+// A response that is flushed, such as a stream of events, is written as is from then on.
+type compressWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+	// written is set once the handlers wrote the header or the body.
+	written bool
+	// streaming is set once the response is flushed.
+	streaming bool
+}
+
+// WriteHeaderNow holds back the header until the response is complete.
+func (w *compressWriter) WriteHeaderNow() {
+	w.written = true
+	if w.streaming {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// Write holds back the data until the response is complete.
+func (w *compressWriter) Write(data []byte) (int, error) {
+	w.written = true
+	if w.streaming {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+// WriteString holds back the string until the response is complete.
+func (w *compressWriter) WriteString(s string) (int, error) {
+	w.written = true
+	if w.streaming {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.body.WriteString(s)
+}
+
+// Written reports whether the handlers wrote the header or the body, even if they are held back.
+func (w *compressWriter) Written() bool {
+	return w.written || w.ResponseWriter.Written()
+}
+
+// Flush writes what was held back as is, and every write from then on.
+func (w *compressWriter) Flush() {
+	if !w.streaming {
+		w.streaming = true
+		w.ResponseWriter.WriteHeaderNow()
+		w.ResponseWriter.Write(w.body.Bytes())
+		w.body.Reset()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Handle compresses the complete responses of a compressible content type in the negotiated encoding.
+// A response is not compressed twice, nor when it has no body.
+func (c *Compress) Handle() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		w := &compressWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = w
+		// -> a panic drops what was held back, so the recovery writes its response from scratch
+		defer func() { ctx.Writer = w.ResponseWriter }()
+		ctx.Next()
+
+		if w.streaming {
+			return
+		}
+		body := w.body.Bytes()
+		h := w.Header()
+		if compressible(h.Get("Content-Type")) {
+			h.Add("Vary", "Accept-Encoding")
+			if e := c.negotiate(ctx.GetHeader("Accept-Encoding")); e != nil && len(body) >= c.minSize && len(body) > 0 && h.Get("Content-Encoding") == "" {
+				if encoded, err := e.encode(body); err == nil {
+					h.Set("Content-Encoding", e.name)
+					h.Del("Content-Length")
+					body = encoded
+				}
+			}
+		}
+		if len(body) == 0 {
+			if w.written {
+				w.ResponseWriter.WriteHeaderNow()
+			}
+			return
+		}
+		w.ResponseWriter.Write(body)
+	}
+}
+
+// negotiate returns the encoder of the encoding the client accepts with the highest weight, the most preferred
+// on a tie, or nil if the client accepts none of them.
+func (c *Compress) negotiate(accept string) (e *encoder) {
+	if accept == "" {
+		return
+	}
+	weights := make(map[string]float64)
+	for _, item := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(item, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if weight, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		weights[name] = weight
+	}
+
+	best := 0.0
+	for _, enc := range c.encoders {
+		weight, ok := weights[enc.name]
+		if !ok {
+			weight = weights["*"]
+		}
+		if weight > best {
+			e, best = enc, weight
+		}
+	}
+	return
+}
+
+// compressible reports whether the content type is worth compressing: text, json, javascript or xml.
+// The streams of events are flushed as they go, and are never compressed.
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mediaType == "text/event-stream":
+		return false
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return true
+	case mediaType == "application/javascript", mediaType == "application/xml", strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecated marks the responses of deprecated routes with the Deprecation header of RFC 9745, the date they were
+// deprecated on, and a Link header to the successor of the route, so the clients can notice and move on.
+func Deprecated(since time.Time, successor string) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(since.Unix(), 10)
+	link := "<" + successor + `>; rel="successor-version"`
+	return func(ctx *gin.Context) {
+		ctx.Header("Deprecation", deprecation)
+		ctx.Header("Link", link)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// keyEnvelope is the key of the gin context that marks the requests answered with the envelope of the api v2.
+const keyEnvelope = "middlewares.envelope"
+
+// envelopeError is the body returned when a middleware aborts a request of the api v2, as the error of handlers.EnvelopeV2.
+type envelopeError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details any    `json:"details,omitempty"`
+	} `json:"error"`
+}
+
+// Envelope marks the requests of the api v2, so the middlewares that abort them answer with its error envelope
+// instead of the body of the api v1.
+func Envelope() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(keyEnvelope, true)
+		ctx.Next()
+	}
+}
+
+// abort aborts the request with the status and the body, or with the error envelope of the api v2 and the code
+// when the request is marked so. The envelope carries the message without its status text and the data as details.
+func abort(ctx *gin.Context, status int, code string, body errorBody) {
+	if !ctx.GetBool(keyEnvelope) {
+		ctx.AbortWithStatusJSON(status, body)
+		return
+	}
+	var e envelopeError
+	e.Error.Code = code
+	e.Error.Message = strings.TrimPrefix(body.Message, http.StatusText(status)+": ")
+	e.Error.Details = body.Data
+	ctx.AbortWithStatusJSON(status, e)
+}
@@ -0,0 +1,111 @@
+package middlewares
+
+import (
+	"app/internal/auth"
+	"app/internal/idempotency"
+	"app/internal/tenant"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderIdempotencyKey is the header carrying the idempotency key of a request.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// NewIdempotency returns a new instance of an idempotency middleware.
+func NewIdempotency(st idempotency.Store) *Idempotency {
+	return &Idempotency{st: st}
+}
+
+// Idempotency is an struct that represents an idempotency middleware.
+type Idempotency struct {
+	// st stores the responses of the idempotent requests.
+	st idempotency.Store
+}
+
+// responseRecorder is an struct that copies the body written to a gin response.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write writes the data to the response and to the copy of the body.
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString writes the string to the response and to the copy of the body.
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Handle replays the stored response of a request retried with the same Idempotency-Key header.
+// Requests without the header are not affected.
+func (i *Idempotency) Handle() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := ctx.GetHeader(HeaderIdempotencyKey)
+		if header == "" {
+			ctx.Next()
+			return
+		}
+
+		// the key is scoped to the tenant, the user and the route of the request
+		var user string
+		if principal := auth.PrincipalFromContext(ctx.Request.Context()); principal != nil {
+			user = principal.Name
+		}
+		key := tenant.FromContext(ctx.Request.Context()) + "|" + user + "|" + ctx.Request.Method + " " + ctx.FullPath() + "|" + header
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			abort(ctx, http.StatusBadRequest, "invalid_body", errorBody{Message: "Bad Request: cuerpo ilegible", Error: true})
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(append([]byte(ctx.Request.URL.RequestURI()+"\n"), body...))
+
+		stored, err := i.st.Begin(key, hex.EncodeToString(sum[:]))
+		switch {
+		case errors.Is(err, idempotency.ErrIdempotencyKeyReused):
+			abort(ctx, http.StatusUnprocessableEntity, "idempotency_key_reused", errorBody{Message: "Unprocessable Entity: Idempotency-Key reutilizada con otro contenido", Error: true})
+			return
+		case errors.Is(err, idempotency.ErrIdempotencyInProgress):
+			abort(ctx, http.StatusConflict, "idempotency_in_progress", errorBody{Message: "Conflict: solicitud con la misma Idempotency-Key en curso", Error: true})
+			return
+		case stored != nil:
+			ctx.Header("Idempotent-Replayed", "true")
+			ctx.Data(stored.Status, stored.Header.Get("Content-Type"), stored.Body)
+			ctx.Abort()
+			return
+		}
+
+		// server errors and panics are not stored so the client can retry them
+		completed := false
+		defer func() {
+			if !completed {
+				i.st.Release(key)
+			}
+		}()
+
+		rec := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = rec
+		ctx.Next()
+
+		if rec.Status() >= http.StatusInternalServerError {
+			return
+		}
+		i.st.Complete(key, &idempotency.Response{
+			Status: rec.Status(),
+			Header: http.Header{"Content-Type": rec.Header().Values("Content-Type")},
+			Body:   rec.body.Bytes(),
+		})
+		completed = true
+	}
+}
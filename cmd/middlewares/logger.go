@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"app/internal/logging"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderRequestID is the header carrying the request id.
+const HeaderRequestID = "X-Request-ID"
+
+// NewLogger returns a new instance of a logger middleware.
+func NewLogger(lg *slog.Logger) *Logger {
+	return &Logger{lg: lg}
+}
+
+// Logger is an struct that represents a logger middleware.
+type Logger struct {
+	// lg is the logger of the requests.
+	lg *slog.Logger
+}
+
+// RequestID stores the request id in the request context, reusing the X-Request-ID header when the client sends one.
+func (l *Logger) RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(HeaderRequestID)
+		if id == "" || len(id) > 128 {
+			b := make([]byte, 16)
+			_, _ = rand.Read(b)
+			id = hex.EncodeToString(b)
+		}
+		ctx.Header(HeaderRequestID, id)
+		ctx.Request = ctx.Request.WithContext(logging.NewContext(ctx.Request.Context(), id))
+		ctx.Next()
+	}
+}
+
+// Log logs every request once it is served.
+func (l *Logger) Log() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		l.lg.Log(ctx.Request.Context(), level, "request served",
+			"method", ctx.Request.Method,
+			"path", ctx.Request.URL.Path,
+			"route", ctx.FullPath(),
+			"status", status,
+			"latency", time.Since(start),
+			"client_ip", ctx.ClientIP(),
+			"bytes", ctx.Writer.Size(),
+		)
+	}
+}
+
+// Recovery logs the panics of the handlers and responds with 500.
+func (l *Logger) Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(ctx *gin.Context, recovered any) {
+		l.lg.ErrorContext(ctx.Request.Context(), "panic recovered", "panic", recovered, "route", ctx.FullPath())
+		abort(ctx, http.StatusInternalServerError, "internal", errorBody{Message: "Internal server error", Error: true})
+	})
+}
@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"app/internal/metrics"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewMetrics returns a new instance of a metrics middleware.
+func NewMetrics(mt *metrics.HTTP) *Metrics {
+	return &Metrics{mt: mt}
+}
+
+// Metrics is an struct that represents a metrics middleware.
+type Metrics struct {
+	// mt are the metrics of the http requests.
+	mt *metrics.HTTP
+}
+
+// Measure counts every request and observes its latency per route.
+func (m *Metrics) Measure() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.mt.Requests.Inc(ctx.Request.Method, route, strconv.Itoa(ctx.Writer.Status()))
+		m.mt.Duration.Observe(time.Since(start).Seconds(), ctx.Request.Method, route)
+		if kind := ctx.GetString(metrics.KeyErrorKind); kind != "" {
+			m.mt.Errors.Inc(kind)
+		}
+	}
+}
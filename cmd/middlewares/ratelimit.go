@@ -0,0 +1,84 @@
+package middlewares
+
+import (
+	"app/internal/auth"
+	"app/internal/ratelimit"
+	"app/internal/tenant"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitKey returns the key a request is limited by.
+type RateLimitKey func(ctx *gin.Context) string
+
+// RateLimitKeyIP limits the requests by client ip.
+func RateLimitKeyIP(ctx *gin.Context) string {
+	return "ip:" + ctx.ClientIP()
+}
+
+// RateLimitKeyAPIKey limits the requests by the user of the api key, falling back to the client ip for anonymous requests.
+// The api keys themselves are never used, so they are not exposed by the quota counters.
+func RateLimitKeyAPIKey(ctx *gin.Context) string {
+	if principal := auth.PrincipalFromContext(ctx.Request.Context()); principal != nil {
+		return "user:" + principal.Name
+	}
+	return RateLimitKeyIP(ctx)
+}
+
+// RateLimitKeyTenant limits the requests by tenant.
+func RateLimitKeyTenant(ctx *gin.Context) string {
+	return "tenant:" + tenant.FromContext(ctx.Request.Context())
+}
+
+// NewRateLimit returns a new instance of a rate limit middleware.
+// A nil quota does not count the daily requests.
+func NewRateLimit(key RateLimitKey, qt *ratelimit.QuotaDaily) *RateLimit {
+	return &RateLimit{key: key, qt: qt}
+}
+
+// RateLimit is an struct that represents a rate limit middleware.
+type RateLimit struct {
+	// key returns the key a request is limited by.
+	key RateLimitKey
+	// qt counts the daily requests of every key.
+	qt *ratelimit.QuotaDaily
+}
+
+// Limit aborts the request with 429 when its key exceeds the rate of the limiter or its daily quota.
+// A nil limiter only applies the daily quota.
+func (r *RateLimit) Limit(lm ratelimit.Limiter) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := r.key(ctx)
+
+		if lm != nil {
+			res := lm.Allow(key)
+			ctx.Header("RateLimit-Limit", strconv.Itoa(res.Limit))
+			ctx.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			ctx.Header("RateLimit-Reset", seconds(res.Reset))
+			if !res.Allowed {
+				ctx.Header("Retry-After", seconds(res.RetryAfter))
+				abort(ctx, http.StatusTooManyRequests, "rate_limited", errorBody{Message: "Too Many Requests: límite de solicitudes excedido", Error: true})
+				return
+			}
+		}
+
+		if r.qt != nil {
+			if ok, reset := r.qt.Consume(key); !ok {
+				ctx.Header("Retry-After", seconds(reset))
+				abort(ctx, http.StatusTooManyRequests, "quota_exceeded", errorBody{Message: "Too Many Requests: cuota diaria agotada", Error: true})
+				return
+			}
+		}
+
+		ctx.Next()
+	}
+}
+
+// seconds formats a duration as whole seconds, rounded up.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
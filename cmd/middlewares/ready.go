@@ -0,0 +1,21 @@
+package middlewares
+
+import (
+	"app/internal/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Ready aborts the requests with 503 until the vehicles are loaded. The requests are still served while the process
+// shuts down, as only the readiness probe tells the load balancers to stop sending them.
+func Ready(h *health.Health) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ok, reason := h.Loaded(); !ok {
+			ctx.Header("Retry-After", "1")
+			abort(ctx, http.StatusServiceUnavailable, "unavailable", errorBody{Message: "Service Unavailable: " + reason, Error: true})
+			return
+		}
+		ctx.Next()
+	}
+}
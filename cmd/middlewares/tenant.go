@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"app/internal/auth"
+	"app/internal/tenant"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewTenant returns a new instance of a tenant middleware.
+func NewTenant(rs tenant.Resolver) *Tenant {
+	return &Tenant{rs: rs}
+}
+
+// Tenant is an struct that represents a tenant middleware.
+type Tenant struct {
+	// rs is the resolver of the tenant of the requests.
+	rs tenant.Resolver
+}
+
+// Resolve resolves the tenant of the request and stores it in the request context.
+// Requests that do not carry any tenant belong to the default tenant.
+func (t *Tenant) Resolve() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := t.rs.Resolve(ctx.Request)
+		switch {
+		case errors.Is(err, tenant.ErrTenantNotResolved):
+			id = tenant.Default
+		case err != nil:
+			abort(ctx, http.StatusBadRequest, "invalid_tenant", errorBody{Message: "Bad Request: tenant inválido", Error: true})
+			return
+		}
+
+		// a principal bound to a tenant can not reach the fleet of another tenant
+		principal := auth.PrincipalFromContext(ctx.Request.Context())
+		if principal != nil && principal.Tenant != "" && principal.Tenant != id {
+			abort(ctx, http.StatusForbidden, "forbidden", errorBody{
+				Message: "Forbidden: sin acceso al tenant " + id,
+				Data:    gin.H{"tenant": id},
+				Error:   true,
+			})
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(tenant.NewContext(ctx.Request.Context(), id))
+		ctx.Next()
+	}
+}
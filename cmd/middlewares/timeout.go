@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout sets a deadline on the context of the request.
+// The handlers abort their work once it is exceeded, and the request is answered with 504 if nothing was written yet.
+// A zero duration sets no deadline.
+func Timeout(d time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if d <= 0 {
+			ctx.Next()
+			return
+		}
+
+		c, cancel := context.WithTimeout(ctx.Request.Context(), d)
+		defer cancel()
+		ctx.Request = ctx.Request.WithContext(c)
+		ctx.Next()
+
+		deadline, _ := c.Deadline()
+		if (errors.Is(c.Err(), context.DeadlineExceeded) || !time.Now().Before(deadline)) && !ctx.Writer.Written() {
+			abort(ctx, http.StatusGatewayTimeout, "timeout", errorBody{Message: "Gateway Timeout: tiempo de respuesta excedido", Error: true})
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"app/internal/metrics"
+	"app/internal/openapi"
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewValidator returns a new instance of a validator middleware.
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// Validator is an struct that represents a middleware validating the requests and the responses
+// against the OpenAPI document of the api. The routes the document does not describe are not validated.
+type Validator struct {
+	// doc is the document of the api.
+	doc *openapi.Document
+}
+
+// SetDocument sets the document the requests and responses are validated against.
+// The document describes the routes of the router, so it is set once every route is registered,
+// and before the api serves requests.
+func (v *Validator) SetDocument(doc *openapi.Document) {
+	v.doc = doc
+}
+
+// operation returns the operation of the route of the request, nil if it is not documented.
+func (v *Validator) operation(ctx *gin.Context) *openapi.Operation {
+	if v.doc == nil || ctx.FullPath() == "" {
+		return nil
+	}
+	return v.doc.Operation(ctx.Request.Method, ctx.FullPath())
+}
+
+// Requests rejects with 400 the requests whose parameters or body do not match the document,
+// listing every error located by its parameter and its JSON pointer.
+func (v *Validator) Requests() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		op := v.operation(ctx)
+		if op == nil {
+			ctx.Next()
+			return
+		}
+
+		// parameters
+		errs := v.doc.ValidateParams(op, func(in, name string) (raw string, ok bool) {
+			switch in {
+			case "path":
+				return ctx.Params.Get(name)
+			case "query":
+				return ctx.GetQuery(name)
+			case "header":
+				values := ctx.Request.Header.Values(name)
+				if len(values) == 0 {
+					return
+				}
+				return values[0], true
+			}
+			return
+		})
+		if len(errs) > 0 {
+			ctx.Set(metrics.KeyErrorKind, "invalid_param")
+			abort(ctx, http.StatusBadRequest, "invalid_parameters", errorBody{Message: "Bad Request: parámetros inválidos.", Data: gin.H{"errors": errs}, Error: true})
+			return
+		}
+
+		// body, restored for the handlers
+		if op.RequestBody != nil {
+			body, err := io.ReadAll(ctx.Request.Body)
+			if err != nil {
+				abort(ctx, http.StatusBadRequest, "invalid_body", errorBody{Message: "Bad Request: cuerpo ilegible", Error: true})
+				return
+			}
+			ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+			if errs = v.doc.ValidateBody(op, body); len(errs) > 0 {
+				ctx.Set(metrics.KeyErrorKind, "invalid_body")
+				abort(ctx, http.StatusBadRequest, "invalid_body", errorBody{Message: "Bad Request: cuerpo inválido.", Data: gin.H{"errors": errs}, Error: true})
+				return
+			}
+		}
+		ctx.Next()
+	}
+}
+
+// Responses checks the responses of the documented routes against the document once they are written,
+// and calls report with the errors of the ones that do not match it. It is meant for the tests,
+// as it catches the handlers drifting from the document, and does not change the responses.
+func (v *Validator) Responses(report func(ctx *gin.Context, errs []openapi.ValidationError)) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		rec := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = rec
+		ctx.Next()
+
+		op := v.operation(ctx)
+		if op == nil {
+			return
+		}
+		if errs := v.doc.ValidateResponse(op, rec.Status(), rec.Header().Get("Content-Type"), rec.body.Bytes()); len(errs) > 0 {
+			report(ctx, errs)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Command openapigen writes the OpenAPI document of the vehicle api, generated from its routes and types,
+// to the file committed to the repository. With -check it compares them instead, and fails if the file is stale.
+//
+//	go run ./cmd/openapigen [-check] [-out docs/openapi/openapi.json]
+package main
+
+import (
+	"app/cmd/server/servertest"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	out := flag.String("out", servertest.Spec, "file of the OpenAPI document")
+	check := flag.Bool("check", false, "compare the document to the file instead of writing it")
+	flag.Parse()
+
+	if err := servertest.CheckSpec(*out, !*check); err != nil {
+		fmt.Fprintln(os.Stderr, "openapigen:", err)
+		if *check {
+			fmt.Fprintln(os.Stderr, "openapigen: regenerate it with go run ./cmd/openapigen")
+		}
+		os.Exit(1)
+	}
+	if !*check {
+		fmt.Printf("ok   %s written\n", *out)
+	}
+}
@@ -0,0 +1,232 @@
+package rpc
+
+import (
+	"app/cmd/middlewares"
+	"app/internal/auth"
+	"app/internal/health"
+	"app/internal/metrics"
+	"app/internal/tenant"
+	vehiclev1 "app/proto/vehicle/v1"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// permissions are the permissions required by each method of the vehicle service.
+var permissions = map[string]auth.Permission{
+	vehiclev1.VehicleService_GetVehicle_FullMethodName:                   auth.PermissionVehiclesRead,
+	vehiclev1.VehicleService_ListVehicles_FullMethodName:                 auth.PermissionVehiclesRead,
+	vehiclev1.VehicleService_ListVehiclesByColorAndYear_FullMethodName:   auth.PermissionVehiclesRead,
+	vehiclev1.VehicleService_ListVehiclesByBrandAndPeriod_FullMethodName: auth.PermissionVehiclesRead,
+	vehiclev1.VehicleService_ListVehiclesByFuelType_FullMethodName:       auth.PermissionVehiclesRead,
+	vehiclev1.VehicleService_ListVehiclesByWeight_FullMethodName:         auth.PermissionVehiclesRead,
+	vehiclev1.VehicleService_GetSpeedAverageByBrand_FullMethodName:       auth.PermissionVehiclesRead,
+	vehiclev1.VehicleService_AddVehicle_FullMethodName:                   auth.PermissionVehiclesWrite,
+	vehiclev1.VehicleService_AddVehicles_FullMethodName:                  auth.PermissionVehiclesWrite,
+	vehiclev1.VehicleService_UpdateSpeed_FullMethodName:                  auth.PermissionVehiclesWrite,
+	vehiclev1.VehicleService_DeleteVehicle_FullMethodName:                auth.PermissionVehiclesDelete,
+}
+
+// NewInterceptors returns a new instance of the interceptors of the gRPC server.
+// A nil authenticator disables authentication and authorization, a nil rate limit does not limit the calls,
+// and nil metrics do not measure them.
+func NewInterceptors(h *health.Health, au auth.Authenticator, rs tenant.Resolver, rl *RateLimit, mt *metrics.GRPC, lg *slog.Logger) *Interceptors {
+	return &Interceptors{h: h, au: au, rs: rs, rl: rl, mt: mt, tracer: otel.Tracer("app/cmd/rpc"), lg: lg}
+}
+
+// Interceptors is an struct that represents the interceptors of the gRPC server, the counterpart of the middlewares
+// of the vehicle routes: the calls are traced and measured, refused until the vehicles are loaded, authenticated
+// with the api key of their metadata, authorized for their method, bound to their tenant and rate limited,
+// and logged once served.
+type Interceptors struct {
+	// h is the readiness of the process.
+	h *health.Health
+	// au is the authenticator of the api keys.
+	au auth.Authenticator
+	// rs is the resolver of the tenant of the calls.
+	rs tenant.Resolver
+	// rl limits the rate and the daily quota of the calls.
+	rl *RateLimit
+	// mt are the metrics of the calls.
+	mt *metrics.GRPC
+	// tracer creates the spans of the calls.
+	tracer trace.Tracer
+	// lg is the logger of the calls.
+	lg *slog.Logger
+}
+
+// Unary returns the interceptor of the unary calls.
+func (i *Interceptors) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+		ctx, end := i.start(ctx, info.FullMethod)
+		defer func() { end(err) }()
+
+		c, err := i.context(ctx, info.FullMethod)
+		if err != nil {
+			return
+		}
+		return handler(c, req)
+	}
+}
+
+// Stream returns the interceptor of the streaming calls.
+func (i *Interceptors) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx, end := i.start(ss.Context(), info.FullMethod)
+		defer func() { end(err) }()
+
+		ctx, err = i.context(ctx, info.FullMethod)
+		if err != nil {
+			return
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// context returns the context of a call, carrying its principal and its tenant,
+// or the status of the call if it is refused. The calls to other services than the vehicle one,
+// such as the reflection, are let through as they are.
+func (i *Interceptors) context(ctx context.Context, method string) (c context.Context, err error) {
+	perm, ok := permissions[method]
+	if !ok {
+		c = ctx
+		return
+	}
+	if ok, reason := i.h.Loaded(); !ok {
+		err = status.Error(codes.Unavailable, reason)
+		return
+	}
+
+	// -> the tenant resolvers of the REST api read the metadata as the headers of a request
+	md, _ := metadata.FromIncomingContext(ctx)
+	req := (&http.Request{Header: make(http.Header)}).WithContext(ctx)
+	for key, values := range md {
+		if key == ":authority" {
+			req.Host = strings.Join(values, "")
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	if i.au != nil {
+		principal, e := i.au.Authenticate(middlewares.Token(req))
+		if e != nil {
+			err = status.Error(codes.Unauthenticated, "api key missing or invalid")
+			return
+		}
+		if !principal.HasPermission(perm) {
+			err = status.Error(codes.PermissionDenied, "missing permission "+string(perm))
+			return
+		}
+		req = req.WithContext(auth.NewContext(req.Context(), principal))
+	}
+
+	id, e := i.rs.Resolve(req)
+	switch {
+	case errors.Is(e, tenant.ErrTenantNotResolved):
+		id = tenant.Default
+	case e != nil:
+		err = status.Error(codes.InvalidArgument, "invalid tenant")
+		return
+	}
+	// a principal bound to a tenant can not reach the fleet of another tenant
+	if principal := auth.PrincipalFromContext(req.Context()); principal != nil && principal.Tenant != "" && principal.Tenant != id {
+		err = status.Error(codes.PermissionDenied, "no access to the tenant "+id)
+		return
+	}
+	c = tenant.NewContext(req.Context(), id)
+
+	if i.rl != nil {
+		err = i.rl.Allow(c, perm)
+	}
+	return
+}
+
+// start starts the server span of a call, continuing the trace of its traceparent metadata,
+// and returns its context and the function that ends it once the call is served:
+// it ends the span, measures the call and logs it.
+func (i *Interceptors) start(ctx context.Context, method string) (c context.Context, end func(err error)) {
+	start := time.Now()
+	service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	md, _ := metadata.FromIncomingContext(ctx)
+	parent := otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	c, span := i.tracer.Start(parent, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", name),
+		),
+	)
+
+	end = func(err error) {
+		defer span.End()
+		code := status.Code(err)
+		span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+		level := slog.LevelInfo
+		switch code {
+		case codes.OK:
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			level = slog.LevelError
+			span.SetStatus(otelcodes.Error, code.String())
+		default:
+			level = slog.LevelWarn
+		}
+		if i.mt != nil {
+			i.mt.Calls.Inc(method, code.String())
+			i.mt.Duration.Observe(time.Since(start).Seconds(), method)
+		}
+		i.lg.Log(c, level, "call served", "method", method, "code", code.String(), "latency", time.Since(start))
+	}
+	return
+}
+
+// metadataCarrier is a type that adapts the metadata of a call to the carrier of the propagators.
+type metadataCarrier metadata.MD
+
+// Get returns the first value of the key.
+func (m metadataCarrier) Get(key string) string {
+	if values := metadata.MD(m).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Set sets the value of the key.
+func (m metadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+// Keys returns the keys of the metadata.
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// serverStream is an struct that represents a server stream with the context of its call.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the call.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
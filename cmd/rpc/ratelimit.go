@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"app/internal/auth"
+	"app/internal/ratelimit"
+	"app/internal/tenant"
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimitKey returns the key a call is limited by, from the context of the call once authenticated
+// and bound to its tenant. The keys are the ones of the rate limit middleware of the REST api,
+// so a client shares its limits and its quota across both apis.
+type RateLimitKey func(ctx context.Context) string
+
+// RateLimitKeyIP limits the calls by client ip.
+func RateLimitKeyIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "ip:"
+	}
+	ip, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		ip = p.Addr.String()
+	}
+	return "ip:" + ip
+}
+
+// RateLimitKeyAPIKey limits the calls by the user of the api key, falling back to the client ip for anonymous calls.
+func RateLimitKeyAPIKey(ctx context.Context) string {
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		return "user:" + principal.Name
+	}
+	return RateLimitKeyIP(ctx)
+}
+
+// RateLimitKeyTenant limits the calls by tenant.
+func RateLimitKeyTenant(ctx context.Context) string {
+	return "tenant:" + tenant.FromContext(ctx)
+}
+
+// NewRateLimit returns a new instance of the rate limit of the calls.
+// A nil limiter does not limit the rate of its calls, and a nil quota does not count the daily calls.
+func NewRateLimit(key RateLimitKey, read, write ratelimit.Limiter, qt *ratelimit.QuotaDaily) *RateLimit {
+	return &RateLimit{key: key, read: read, write: write, qt: qt}
+}
+
+// RateLimit is an struct that represents the rate limit of the calls, the counterpart of the rate limit middleware:
+// the calls reading the vehicles share the limiter of the read routes, the other calls the one of the write routes.
+type RateLimit struct {
+	// key returns the key a call is limited by.
+	key RateLimitKey
+	// read limits the calls that read the vehicles.
+	read ratelimit.Limiter
+	// write limits the calls that change the vehicles.
+	write ratelimit.Limiter
+	// qt counts the daily calls of every key.
+	qt *ratelimit.QuotaDaily
+}
+
+// Allow returns a ResourceExhausted status when the key of the call exceeds the rate of the limiter
+// of its permission or its daily quota, with the seconds to wait in the retry-after header.
+func (r *RateLimit) Allow(ctx context.Context, perm auth.Permission) (err error) {
+	key := r.key(ctx)
+
+	lm := r.write
+	if perm == auth.PermissionVehiclesRead {
+		lm = r.read
+	}
+	if lm != nil {
+		if res := lm.Allow(key); !res.Allowed {
+			grpc.SetHeader(ctx, metadata.Pairs("retry-after", seconds(res.RetryAfter)))
+			err = status.Error(codes.ResourceExhausted, "límite de solicitudes excedido")
+			return
+		}
+	}
+
+	if r.qt != nil {
+		if ok, reset := r.qt.Consume(key); !ok {
+			grpc.SetHeader(ctx, metadata.Pairs("retry-after", seconds(reset)))
+			err = status.Error(codes.ResourceExhausted, "cuota diaria agotada")
+			return
+		}
+	}
+	return
+}
+
+// seconds formats a duration as whole seconds, rounded up.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
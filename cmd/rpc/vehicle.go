@@ -0,0 +1,389 @@
+// Package rpc serves the vehicle services of every tenant over gRPC, as defined in proto/vehicle/v1.
+// It shares the services of the REST api, and holds the principals to the same permissions, brands and tenants.
+package rpc
+
+import (
+	"app/internal/auth"
+	"app/internal/domain"
+	"app/internal/tenant"
+	"app/internal/vehicle/service"
+	vehiclev1 "app/proto/vehicle/v1"
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewServerVehicle returns a new instance of a gRPC vehicle server.
+func NewServerVehicle(st service.ServiceVehicleTenants, lg *slog.Logger) *ServerVehicle {
+	return &ServerVehicle{st: st, lg: lg}
+}
+
+// ServerVehicle is an struct that implements the vehiclev1.VehicleServiceServer interface.
+type ServerVehicle struct {
+	vehiclev1.UnimplementedVehicleServiceServer
+	// st resolves the vehicle service of the tenant of each call.
+	st service.ServiceVehicleTenants
+	// lg is the logger of the server.
+	lg *slog.Logger
+}
+
+// service returns the vehicle service of the tenant of the call.
+func (s *ServerVehicle) service(ctx context.Context) (sv service.ServiceVehicle, err error) {
+	sv, err = s.st.Tenant(tenant.FromContext(ctx))
+	if err != nil {
+		err = s.statusError(ctx, "Tenant", err)
+		return
+	}
+	return
+}
+
+// statusError returns the status of an error of the service, and logs the unexpected ones.
+func (s *ServerVehicle) statusError(ctx context.Context, op string, err error) error {
+	st := serviceStatus(err)
+	if st.Code() == codes.Internal {
+		s.lg.ErrorContext(ctx, "vehicle call failed", "operation", op, "error", err)
+	}
+	return st.Err()
+}
+
+// serviceStatus returns the status of an error of the service. The unexpected errors are not disclosed.
+func serviceStatus(err error) *status.Status {
+	switch {
+	case errors.Is(err, service.ErrServiceVehicleNotFound):
+		return status.New(codes.NotFound, "vehicle not found")
+	case errors.Is(err, service.ErrServiceVehicleNotFoundWithValue):
+		return status.New(codes.NotFound, "no vehicles match the criteria")
+	case errors.Is(err, service.ErrServiceTenantNotFound):
+		return status.New(codes.NotFound, "tenant not found")
+	case errors.Is(err, service.ErrServiceVehicleExist):
+		return status.New(codes.AlreadyExists, "vehicle id already exists")
+	case errors.Is(err, service.ErrServiceImposibleMaxSpeed):
+		return status.New(codes.InvalidArgument, "max speed malformed or out of range")
+	case errors.Is(err, service.ErrServiceInvalidParam):
+		return status.New(codes.InvalidArgument, "invalid parameters")
+	case errors.Is(err, service.ErrServiceVehicleTimeout):
+		return status.New(codes.DeadlineExceeded, "operation timed out")
+	case errors.Is(err, service.ErrServiceVehicleCanceled):
+		return status.New(codes.Canceled, "operation canceled")
+	default:
+		return status.New(codes.Internal, "internal error")
+	}
+}
+
+// brand returns an error if the principal of the call is not allowed to access the vehicles of the brand.
+func brand(ctx context.Context, b string) error {
+	if !auth.PrincipalFromContext(ctx).HasBrand(b) {
+		return status.Error(codes.PermissionDenied, "no access to the vehicles of the brand "+b)
+	}
+	return nil
+}
+
+// authorize returns an error if the principal of the call is not allowed to access the stored vehicle with the id.
+func (s *ServerVehicle) authorize(ctx context.Context, sv service.ServiceVehicle, op string, id int) error {
+	principal := auth.PrincipalFromContext(ctx)
+	if principal == nil || len(principal.Brands) == 0 {
+		return nil
+	}
+	v, err := sv.GetById(ctx, id)
+	if err != nil {
+		return s.statusError(ctx, op, err)
+	}
+	return brand(ctx, v.Attributes.Brand)
+}
+
+// send streams the vehicles the principal of the call is allowed to access, or fails with not found if there are none.
+func send(stream grpc.ServerStreamingServer[vehiclev1.Vehicle], vehicles []*domain.Vehicle) (err error) {
+	principal := auth.PrincipalFromContext(stream.Context())
+	allowed := make([]*domain.Vehicle, 0, len(vehicles))
+	for _, v := range vehicles {
+		if principal.HasBrand(v.Attributes.Brand) {
+			allowed = append(allowed, v)
+		}
+	}
+	if len(allowed) == 0 {
+		return serviceStatus(service.ErrServiceVehicleNotFoundWithValue).Err()
+	}
+	for _, v := range allowed {
+		if err = stream.Send(toProto(v)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// GetVehicle returns the vehicle with the id.
+func (s *ServerVehicle) GetVehicle(ctx context.Context, req *vehiclev1.GetVehicleRequest) (v *vehiclev1.Vehicle, err error) {
+	sv, err := s.service(ctx)
+	if err != nil {
+		return
+	}
+	vehicle, err := sv.GetById(ctx, int(req.GetId()))
+	if err != nil {
+		err = s.statusError(ctx, "GetById", err)
+		return
+	}
+	if err = brand(ctx, vehicle.Attributes.Brand); err != nil {
+		return
+	}
+	v = toProto(vehicle)
+	return
+}
+
+// ListVehicles streams every vehicle.
+func (s *ServerVehicle) ListVehicles(req *vehiclev1.ListVehiclesRequest, stream grpc.ServerStreamingServer[vehiclev1.Vehicle]) error {
+	ctx := stream.Context()
+	sv, err := s.service(ctx)
+	if err != nil {
+		return err
+	}
+	vehicles, err := sv.GetAll(ctx)
+	if err != nil {
+		return s.statusError(ctx, "GetAll", err)
+	}
+	return send(stream, vehicles)
+}
+
+// ListVehiclesByColorAndYear streams the vehicles of a color made in a year.
+func (s *ServerVehicle) ListVehiclesByColorAndYear(req *vehiclev1.ListVehiclesByColorAndYearRequest, stream grpc.ServerStreamingServer[vehiclev1.Vehicle]) error {
+	ctx := stream.Context()
+	sv, err := s.service(ctx)
+	if err != nil {
+		return err
+	}
+	vehicles, err := sv.GetByColorAndYear(ctx, req.GetColor(), int(req.GetYear()))
+	if err != nil {
+		return s.statusError(ctx, "GetByColorAndYear", err)
+	}
+	return send(stream, vehicles)
+}
+
+// ListVehiclesByBrandAndPeriod streams the vehicles of a brand made between two years, both included.
+func (s *ServerVehicle) ListVehiclesByBrandAndPeriod(req *vehiclev1.ListVehiclesByBrandAndPeriodRequest, stream grpc.ServerStreamingServer[vehiclev1.Vehicle]) error {
+	ctx := stream.Context()
+	if req.GetStartYear() > req.GetEndYear() {
+		return status.Error(codes.InvalidArgument, "start_year must not be after end_year")
+	}
+	if err := brand(ctx, req.GetBrand()); err != nil {
+		return err
+	}
+	sv, err := s.service(ctx)
+	if err != nil {
+		return err
+	}
+	// -> the period of the service excludes its end year, the one of the call includes it
+	vehicles, err := sv.GetByBrandAndPeriod(ctx, req.GetBrand(), int(req.GetStartYear()), int(req.GetEndYear())+1)
+	if err != nil {
+		return s.statusError(ctx, "GetByBrandAndPeriod", err)
+	}
+	return send(stream, vehicles)
+}
+
+// ListVehiclesByFuelType streams the vehicles of a fuel type.
+func (s *ServerVehicle) ListVehiclesByFuelType(req *vehiclev1.ListVehiclesByFuelTypeRequest, stream grpc.ServerStreamingServer[vehiclev1.Vehicle]) error {
+	ctx := stream.Context()
+	sv, err := s.service(ctx)
+	if err != nil {
+		return err
+	}
+	vehicles, err := sv.GetByFuelType(ctx, req.GetFuelType())
+	if err != nil {
+		return s.statusError(ctx, "GetByFuelType", err)
+	}
+	return send(stream, vehicles)
+}
+
+// ListVehiclesByWeight streams the vehicles whose weight is in a range, open on the sides without a bound.
+func (s *ServerVehicle) ListVehiclesByWeight(req *vehiclev1.ListVehiclesByWeightRequest, stream grpc.ServerStreamingServer[vehiclev1.Vehicle]) error {
+	ctx := stream.Context()
+	// -> both bounds are optional, a missing one leaves the range open on its side
+	min, max := math.Inf(-1), math.Inf(1)
+	if req.Min != nil {
+		min = req.GetMin()
+	}
+	if req.Max != nil {
+		max = req.GetMax()
+	}
+	if req.Min != nil && min < 0 || min > max {
+		return status.Error(codes.InvalidArgument, "min must not be negative nor greater than max")
+	}
+	sv, err := s.service(ctx)
+	if err != nil {
+		return err
+	}
+	vehicles, err := sv.GetByWeight(ctx, min, max)
+	if err != nil {
+		return s.statusError(ctx, "GetByWeight", err)
+	}
+	return send(stream, vehicles)
+}
+
+// GetSpeedAverageByBrand returns the average max speed of the vehicles of a brand.
+func (s *ServerVehicle) GetSpeedAverageByBrand(ctx context.Context, req *vehiclev1.GetSpeedAverageByBrandRequest) (a *vehiclev1.SpeedAverage, err error) {
+	if err = brand(ctx, req.GetBrand()); err != nil {
+		return
+	}
+	sv, err := s.service(ctx)
+	if err != nil {
+		return
+	}
+	average, err := sv.GetSpeedAverageByBrand(ctx, req.GetBrand())
+	if err != nil {
+		err = s.statusError(ctx, "GetSpeedAverageByBrand", err)
+		return
+	}
+	a = &vehiclev1.SpeedAverage{Brand: req.GetBrand(), Average: average}
+	return
+}
+
+// AddVehicle adds a vehicle.
+func (s *ServerVehicle) AddVehicle(ctx context.Context, req *vehiclev1.AddVehicleRequest) (v *vehiclev1.Vehicle, err error) {
+	vehicle, err := fromProto(req.GetVehicle())
+	if err != nil {
+		return
+	}
+	if err = brand(ctx, vehicle.Attributes.Brand); err != nil {
+		return
+	}
+	sv, err := s.service(ctx)
+	if err != nil {
+		return
+	}
+	vehicle, err = sv.AddVehicle(ctx, vehicle)
+	if err != nil {
+		err = s.statusError(ctx, "AddVehicle", err)
+		return
+	}
+	v = toProto(vehicle)
+	return
+}
+
+// AddVehicles adds several vehicles, all or none.
+func (s *ServerVehicle) AddVehicles(ctx context.Context, req *vehiclev1.AddVehiclesRequest) (res *vehiclev1.AddVehiclesResponse, err error) {
+	vehicles := make([]*domain.Vehicle, 0, len(req.GetVehicles()))
+	for i, v := range req.GetVehicles() {
+		vehicle, e := fromProto(v)
+		if e != nil {
+			err = status.Errorf(codes.InvalidArgument, "vehicles[%d]: %s", i, status.Convert(e).Message())
+			return
+		}
+		if err = brand(ctx, vehicle.Attributes.Brand); err != nil {
+			return
+		}
+		vehicles = append(vehicles, vehicle)
+	}
+	sv, err := s.service(ctx)
+	if err != nil {
+		return
+	}
+	vehicles, err = sv.AddVehicles(ctx, vehicles)
+	if err != nil {
+		err = s.statusError(ctx, "AddVehicles", err)
+		return
+	}
+	res = &vehiclev1.AddVehiclesResponse{}
+	for _, v := range vehicles {
+		res.Vehicles = append(res.Vehicles, toProto(v))
+	}
+	return
+}
+
+// UpdateSpeed updates the max speed of a vehicle.
+func (s *ServerVehicle) UpdateSpeed(ctx context.Context, req *vehiclev1.UpdateSpeedRequest) (v *vehiclev1.Vehicle, err error) {
+	if req.GetMaxSpeed() < 0 || req.GetMaxSpeed() > domain.MaxSpeedLimit {
+		err = status.Errorf(codes.InvalidArgument, "max_speed must be between 0 and %d", domain.MaxSpeedLimit)
+		return
+	}
+	sv, err := s.service(ctx)
+	if err != nil {
+		return
+	}
+	id := int(req.GetId())
+	if err = s.authorize(ctx, sv, "UpdateSpeed", id); err != nil {
+		return
+	}
+	vehicle, err := sv.UpdateSpeed(ctx, &domain.Vehicle{Id: id, Attributes: domain.VehicleAttributes{MaxSpeed: int(req.GetMaxSpeed())}})
+	if err != nil {
+		err = s.statusError(ctx, "UpdateSpeed", err)
+		return
+	}
+	v = toProto(vehicle)
+	return
+}
+
+// DeleteVehicle deletes a vehicle and returns it as it was.
+func (s *ServerVehicle) DeleteVehicle(ctx context.Context, req *vehiclev1.DeleteVehicleRequest) (v *vehiclev1.Vehicle, err error) {
+	sv, err := s.service(ctx)
+	if err != nil {
+		return
+	}
+	id := int(req.GetId())
+	if err = s.authorize(ctx, sv, "DeleteVehicle", id); err != nil {
+		return
+	}
+	vehicle, err := sv.DeleteVehicle(ctx, id)
+	if err != nil {
+		err = s.statusError(ctx, "DeleteVehicle", err)
+		return
+	}
+	v = toProto(vehicle)
+	return
+}
+
+// toProto returns the message of a vehicle.
+func toProto(v *domain.Vehicle) *vehiclev1.Vehicle {
+	return &vehiclev1.Vehicle{
+		Id:           int64(v.Id),
+		Brand:        v.Attributes.Brand,
+		Model:        v.Attributes.Model,
+		Registration: v.Attributes.Registration,
+		Year:         int32(v.Attributes.Year),
+		Color:        v.Attributes.Color,
+		MaxSpeed:     int32(v.Attributes.MaxSpeed),
+		FuelType:     v.Attributes.FuelType,
+		Transmission: v.Attributes.Transmission,
+		Passengers:   int32(v.Attributes.Passengers),
+		Height:       v.Attributes.Height,
+		Width:        v.Attributes.Width,
+		Weight:       v.Attributes.Weight,
+	}
+}
+
+// fromProto returns the vehicle of a message, checked as on the REST api.
+func fromProto(v *vehiclev1.Vehicle) (vehicle *domain.Vehicle, err error) {
+	switch {
+	case v == nil:
+		err = status.Error(codes.InvalidArgument, "vehicle is required")
+	case v.GetId() < 1:
+		err = status.Error(codes.InvalidArgument, "id must be positive")
+	case v.GetBrand() == "", v.GetRegistration() == "":
+		err = status.Error(codes.InvalidArgument, "brand and registration must not be empty")
+	case v.GetMaxSpeed() < 0 || v.GetMaxSpeed() > domain.MaxSpeedLimit:
+		err = status.Errorf(codes.InvalidArgument, "max_speed must be between 0 and %d", domain.MaxSpeedLimit)
+	}
+	if err != nil {
+		return
+	}
+	vehicle = &domain.Vehicle{
+		Id: int(v.GetId()),
+		Attributes: domain.VehicleAttributes{
+			Brand:        v.GetBrand(),
+			Model:        v.GetModel(),
+			Registration: v.GetRegistration(),
+			Year:         int(v.GetYear()),
+			Color:        v.GetColor(),
+			MaxSpeed:     int(v.GetMaxSpeed()),
+			FuelType:     v.GetFuelType(),
+			Transmission: v.GetTransmission(),
+			Passengers:   int(v.GetPassengers()),
+			Height:       v.GetHeight(),
+			Width:        v.GetWidth(),
+			Weight:       v.GetWeight(),
+		},
+	}
+	return
+}
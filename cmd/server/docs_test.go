@@ -0,0 +1,41 @@
+package server_test
+
+import (
+	"app/cmd/server/servertest"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDocs(t *testing.T) {
+	h, err := servertest.NewHarness(t.TempDir(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := h.Do(servertest.Request{Method: http.MethodGet, Path: "/docs"}).Body.String()
+
+	// -> every file the page loads is served by the api itself
+	files := regexp.MustCompile(`(?:href|src)="([^"]+)"`).FindAllStringSubmatch(page, -1)
+	if len(files) != 2 {
+		t.Fatalf("files of the page = %v, want the stylesheet and the script", files)
+	}
+	types := map[string]string{".css": "text/css; charset=utf-8", ".js": "text/javascript; charset=utf-8"}
+	for _, file := range files {
+		path := file[1]
+		if !strings.HasPrefix(path, "/docs/") {
+			t.Errorf("file %q is not served by the api", path)
+			continue
+		}
+		rec := h.Do(servertest.Request{Method: http.MethodGet, Path: path})
+		if rec.Code != http.StatusOK || rec.Body.Len() == 0 {
+			t.Errorf("GET %s: status = %d, %d bytes, want %d and the file", path, rec.Code, rec.Body.Len(), http.StatusOK)
+		}
+		if got, want := rec.Header().Get("Content-Type"), types[path[strings.LastIndex(path, "."):]]; got != want {
+			t.Errorf("GET %s: content type = %q, want %q", path, got, want)
+		}
+	}
+	if mismatches := h.API.Mismatches(); len(mismatches) > 0 {
+		t.Errorf("responses do not match the OpenAPI document:\n%s", strings.Join(mismatches, "\n"))
+	}
+}
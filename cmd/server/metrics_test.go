@@ -0,0 +1,74 @@
+package server_test
+
+import (
+	"app/cmd/server/servertest"
+	"app/internal/config"
+	"app/internal/metrics"
+	"app/pkg/vehicleclient"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMetrics(t *testing.T) {
+	h, err := servertest.NewHarness(t.TempDir(), func(cfg *config.Config) { cfg.Features.ValidateResponses = false })
+	if err != nil {
+		t.Fatal(err)
+	}
+	// -> a route registered after the middlewares, failing as a handler with a bug would
+	h.API.Router.GET("/panic", func(ctx *gin.Context) { panic("boom") })
+
+	for _, r := range []servertest.Request{
+		{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: map[string]string{"Authorization": "Bearer analyst-key"}},
+		{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: map[string]string{"Authorization": "Bearer analyst-key"}},
+		{Method: http.MethodGet, Path: "/api/v1/vehicles"},
+		{Method: http.MethodGet, Path: "/panic"},
+	} {
+		h.Do(r)
+	}
+	// -> the gRPC calls are measured as well
+	c, err := h.Client(vehicleclient.Options{APIKey: "analyst-key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	defer c.Close()
+	c.GetVehicle(context.Background(), 1)
+	c.GetVehicle(context.Background(), 99)
+
+	rec := h.Do(servertest.Request{Method: http.MethodGet, Path: "/metrics"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Header().Get("Content-Type"); got != metrics.ContentType {
+		t.Errorf("content type = %q, want %q", got, metrics.ContentType)
+	}
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# HELP http_requests_total Number of http requests per route and status.",
+		"# TYPE http_requests_total counter",
+		`http_requests_total{method="GET",route="/api/v1/vehicles",status="200"} 2`,
+		`http_requests_total{method="GET",route="/api/v1/vehicles",status="401"} 1`,
+		`http_requests_total{method="GET",route="/panic",status="500"} 1`,
+		"# TYPE http_request_duration_seconds histogram",
+		`http_request_duration_seconds_bucket{method="GET",route="/api/v1/vehicles",le="+Inf"} 3`,
+		`http_request_duration_seconds_count{method="GET",route="/api/v1/vehicles"} 3`,
+		"# TYPE grpc_calls_total counter",
+		`grpc_calls_total{method="/vehicle.v1.VehicleService/GetVehicle",code="OK"} 1`,
+		`grpc_calls_total{method="/vehicle.v1.VehicleService/GetVehicle",code="NotFound"} 1`,
+		`grpc_call_duration_seconds_count{method="/vehicle.v1.VehicleService/GetVehicle"} 2`,
+		"# TYPE vehicles_total gauge",
+		`vehicles_total{tenant="acme"} 2`,
+		`vehicles_total{tenant="default"} 8`,
+		"# TYPE vehicles_by_fuel_type gauge",
+		`vehicles_by_fuel_type{tenant="default",fuel_type="diesel"} 3`,
+		`vehicles_by_fuel_type{tenant="default",fuel_type="gasoline"} 3`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("metrics missing line %q\n%s", line, body)
+		}
+	}
+}
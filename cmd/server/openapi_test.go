@@ -0,0 +1,14 @@
+package server_test
+
+import (
+	"app/cmd/server/servertest"
+	"testing"
+)
+
+// TestOpenAPI compares the OpenAPI document generated from the routes of the api to the file committed to the
+// repository, byte for byte, so a route or a type cannot change without regenerating it with cmd/openapigen.
+func TestOpenAPI(t *testing.T) {
+	if err := servertest.CheckSpec("../../"+servertest.Spec, false); err != nil {
+		t.Fatalf("%v\nregenerate it with go run ./cmd/openapigen", err)
+	}
+}
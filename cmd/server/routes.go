@@ -0,0 +1,246 @@
+package server
+
+import (
+	"app/cmd/handlers"
+	"app/internal/config"
+	"app/internal/openapi"
+	"app/internal/vehicle/reloader"
+	"app/internal/vehicle/saver"
+	"fmt"
+	"net/http"
+)
+
+// info is the metadata of the OpenAPI document of the api.
+var info = openapi.Info{
+	Title:       "Vehicles API",
+	Version:     "2.0.0",
+	Description: "Queries and changes the vehicles of every tenant.",
+}
+
+// routes returns the documentation of every route the api may register.
+// New fails when a registered route is missing from it, so the document never falls behind the router.
+func routes(cfg *config.Config) []openapi.Route {
+	// bodies
+	list := handlers.ResponseBodyList{}
+	vehicle := handlers.ResponseBody{Data: &handlers.VehicleHandler{}}
+	failure := handlers.ResponseError{}
+	health := handlers.ResponseBody{Data: handlers.HealthHandler{}}
+	vehicleV2 := handlers.EnvelopeV2{Data: &handlers.VehicleV2{}, Links: handlers.LinksV2{}}
+	vehiclesV2 := handlers.EnvelopeV2{Data: []handlers.VehicleV2{}, Links: handlers.LinksV2{}, Meta: &handlers.PageV2{}}
+	failureV2 := handlers.EnvelopeV2{Error: &handlers.ErrorV2{}}
+	graphqlResult := openapi.Contents{handlers.ResponseGraphQL{},
+		openapi.Content{Type: "text/event-stream", Schema: &openapi.Schema{Type: openapi.Types{"string"}}}}
+
+	// parameters
+	tenantHeader := openapi.Param{Name: cfg.Tenant.Header, In: "header", Description: "Tenant of the vehicles, the default one if missing.", Type: ""}
+	idempotencyKey := openapi.Param{Name: "Idempotency-Key", In: "header", Description: "Key that makes retries of the creation safe.", Type: ""}
+	id := openapi.Param{Name: "id", In: "path", Description: "Id of the vehicle.", Type: 0, Constraints: "minimum=1"}
+	brand := openapi.Param{Name: "brand", In: "path", Type: ""}
+	query := func(name, description string, typ any) openapi.Param {
+		return openapi.Param{Name: name, In: "query", Description: description, Type: typ}
+	}
+
+	// responses of the vehicle routes, on top of the ones of each route
+	vehicles := func(r openapi.Route) openapi.Route {
+		r.Tags = []string{"vehicles"}
+		r.Auth = true
+		r.Params = append([]openapi.Param{tenantHeader}, r.Params...)
+		for _, code := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound,
+			http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+			if _, ok := r.Responses[code]; !ok {
+				r.Responses[code] = failure
+			}
+		}
+		if r.Body != nil {
+			r.Responses[http.StatusRequestEntityTooLarge] = failure
+		}
+		return r
+	}
+	deprecated := func(r openapi.Route) openapi.Route {
+		r = vehicles(r)
+		r.Deprecated = true
+		return r
+	}
+	// responses of the routes of the api v2, every error in its envelope
+	v2 := func(r openapi.Route) openapi.Route {
+		r.Tags = []string{"vehicles v2"}
+		r.Auth = true
+		r.Params = append([]openapi.Param{tenantHeader}, r.Params...)
+		for _, code := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound,
+			http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+			if _, ok := r.Responses[code]; !ok {
+				r.Responses[code] = failureV2
+			}
+		}
+		if r.Body != nil {
+			r.Responses[http.StatusRequestEntityTooLarge] = failureV2
+		}
+		return r
+	}
+	// responses of the read routes, revalidated by the clients with If-None-Match or If-Modified-Since
+	cached := func(r openapi.Route) openapi.Route {
+		r.Responses[http.StatusNotModified] = nil
+		return r
+	}
+	graphql := func(r openapi.Route) openapi.Route {
+		r = vehicles(r)
+		r.Tags = []string{"graphql"}
+		return r
+	}
+	admin := func(r openapi.Route) openapi.Route {
+		r.Tags = []string{"admin"}
+		r.Auth = true
+		for _, code := range []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusServiceUnavailable} {
+			if _, ok := r.Responses[code]; !ok {
+				r.Responses[code] = failure
+			}
+		}
+		return r
+	}
+
+	return []openapi.Route{
+		// probes and metrics
+		{Method: http.MethodGet, Path: "/healthz", OperationID: "liveness", Summary: "Liveness of the process", Tags: []string{"probes"},
+			Responses: map[int]any{http.StatusOK: health}},
+		{Method: http.MethodGet, Path: "/readyz", OperationID: "readiness", Summary: "Readiness of the process", Tags: []string{"probes"},
+			Responses: map[int]any{http.StatusOK: health, http.StatusServiceUnavailable: health}},
+		{Method: http.MethodGet, Path: "/metrics", OperationID: "metrics", Summary: "Metrics in the Prometheus text format", Tags: []string{"probes"},
+			Responses: map[int]any{http.StatusOK: openapi.Content{Type: "text/plain", Schema: &openapi.Schema{Type: openapi.Types{"string"}}}}},
+		{Method: http.MethodGet, Path: "/openapi.json", OperationID: "openapi", Summary: "This document", Tags: []string{"docs"},
+			Responses: map[int]any{http.StatusOK: openapi.Content{Type: "application/json", Schema: &openapi.Schema{Type: openapi.Types{"object"}}}}},
+		{Method: http.MethodGet, Path: "/docs", OperationID: "docs", Summary: "Swagger UI page of this document", Tags: []string{"docs"},
+			Responses: map[int]any{http.StatusOK: openapi.Content{Type: "text/html", Schema: &openapi.Schema{Type: openapi.Types{"string"}}}}},
+		{Method: http.MethodGet, Path: "/docs/:file", OperationID: "docsFile", Summary: "Stylesheet and script of the Swagger UI page", Tags: []string{"docs"},
+			Params: []openapi.Param{{Name: "file", In: "path", Description: "swagger-ui.css or swagger-ui-bundle.js.", Type: ""}},
+			Responses: map[int]any{
+				http.StatusOK: openapi.Contents{
+					openapi.Content{Type: "text/css", Schema: &openapi.Schema{Type: openapi.Types{"string"}}},
+					openapi.Content{Type: "text/javascript", Schema: &openapi.Schema{Type: openapi.Types{"string"}}},
+				},
+				http.StatusNotFound: nil,
+			}},
+
+		// vehicles
+		cached(deprecated(openapi.Route{Method: http.MethodGet, Path: "/api/v1/vehicles", OperationID: "getVehicles", Summary: "Every vehicle",
+			Responses: map[int]any{http.StatusOK: list}})),
+		cached(deprecated(openapi.Route{Method: http.MethodGet, Path: "/api/v1/vehicles/color/:color/year/:year", OperationID: "getVehiclesByColorAndYear",
+			Summary:   "Vehicles of a color made in a year",
+			Params:    []openapi.Param{{Name: "color", In: "path", Type: ""}, {Name: "year", In: "path", Type: 0}},
+			Responses: map[int]any{http.StatusOK: list}})),
+		cached(deprecated(openapi.Route{Method: http.MethodGet, Path: "/api/v1/vehicles/brand/:brand/between/:start_year/:end_year", OperationID: "getVehiclesByBrandAndPeriod",
+			Summary:   "Vehicles of a brand made between two years, both included",
+			Params:    []openapi.Param{brand, {Name: "start_year", In: "path", Type: 0}, {Name: "end_year", In: "path", Type: 0}},
+			Responses: map[int]any{http.StatusOK: list}})),
+		cached(deprecated(openapi.Route{Method: http.MethodGet, Path: "/api/v1/vehicles/average_speed/brand/:brand", OperationID: "getSpeedAverageByBrand",
+			Summary:   "Average max speed of the vehicles of a brand",
+			Params:    []openapi.Param{brand},
+			Responses: map[int]any{http.StatusOK: handlers.ResponseBody{Data: 0.0}}})),
+		cached(deprecated(openapi.Route{Method: http.MethodGet, Path: "/api/v1/vehicles/fuel_type/:type", OperationID: "getVehiclesByFuelType",
+			Summary:   "Vehicles of a fuel type",
+			Params:    []openapi.Param{{Name: "type", In: "path", Type: ""}},
+			Responses: map[int]any{http.StatusOK: list}})),
+		cached(deprecated(openapi.Route{Method: http.MethodGet, Path: "/api/v1/vehicles/weight", OperationID: "getVehiclesByWeight",
+			Summary: "Vehicles whose weight is in a range, open on the sides without a bound",
+			Params: []openapi.Param{
+				{Name: "weight_min", In: "query", Description: "Lower bound, included.", Type: 0.0, Constraints: "minimum=0"},
+				{Name: "weight_max", In: "query", Description: "Upper bound, included.", Type: 0.0},
+			},
+			Responses: map[int]any{http.StatusOK: list}})),
+		deprecated(openapi.Route{Method: http.MethodPost, Path: "/api/v1/vehicles", OperationID: "addVehicle", Summary: "Adds a vehicle",
+			Params:    []openapi.Param{idempotencyKey},
+			Body:      handlers.RequestVehicle{},
+			Responses: map[int]any{http.StatusOK: vehicle, http.StatusConflict: failure, http.StatusUnprocessableEntity: failure}}),
+		deprecated(openapi.Route{Method: http.MethodPost, Path: "/api/v1/vehicles/batch", OperationID: "addVehicles", Summary: "Adds several vehicles, all or none",
+			Params: []openapi.Param{idempotencyKey},
+			Body:   []handlers.RequestVehicle{},
+			Responses: map[int]any{http.StatusCreated: list,
+				http.StatusConflict: failure, http.StatusUnprocessableEntity: failure}}),
+		deprecated(openapi.Route{Method: http.MethodPut, Path: "/api/v1/vehicles/:id/update_speed", OperationID: "updateSpeed", Summary: "Updates the max speed of a vehicle",
+			Params:    []openapi.Param{id},
+			Body:      handlers.RequestSpeed{},
+			Responses: map[int]any{http.StatusOK: vehicle}}),
+		deprecated(openapi.Route{Method: http.MethodDelete, Path: "/api/v1/vehicles/:id", OperationID: "deleteVehicle", Summary: "Deletes a vehicle",
+			Params:    []openapi.Param{id},
+			Responses: map[int]any{http.StatusNoContent: nil}}),
+
+		// vehicles v2
+		{Method: http.MethodGet, Path: "/api/v2", OperationID: "indexV2", Summary: "Links to the resources of the api v2", Tags: []string{"vehicles v2"},
+			Responses: map[int]any{http.StatusOK: handlers.EnvelopeV2{Links: handlers.LinksV2{}}, http.StatusServiceUnavailable: failureV2}},
+		cached(v2(openapi.Route{Method: http.MethodGet, Path: "/api/v2/vehicles", OperationID: "listVehiclesV2",
+			Summary: "A page of the vehicles that match the filters, sorted by id",
+			Params: []openapi.Param{
+				query("brand", "Brand, compared case insensitively.", ""),
+				query("model", "Model, compared case insensitively.", ""),
+				query("color", "Color, compared case insensitively.", ""),
+				query("fuel_type", "Fuel type, compared case insensitively.", ""),
+				query("transmission", "Transmission, compared case insensitively.", ""),
+				query("year", "Year of manufacture.", 0),
+				query("year_min", "Lower bound of the year, included.", 0),
+				query("year_max", "Upper bound of the year, included.", 0),
+				query("max_speed_min", "Lower bound of the max speed, included.", 0),
+				query("max_speed_max", "Upper bound of the max speed, included.", 0),
+				query("weight_min", "Lower bound of the weight, included.", 0.0),
+				query("weight_max", "Upper bound of the weight, included.", 0.0),
+				{Name: "limit", In: "query", Description: "Size of the page.", Type: 0, Constraints: fmt.Sprintf("minimum=1,maximum=%d", cfg.Pagination.MaxLimit)},
+				{Name: "offset", In: "query", Description: "Vehicles skipped before the page.", Type: 0, Constraints: "minimum=0"},
+			},
+			Responses: map[int]any{http.StatusOK: vehiclesV2}})),
+		cached(v2(openapi.Route{Method: http.MethodGet, Path: "/api/v2/vehicles/:id", OperationID: "getVehicleV2", Summary: "A vehicle",
+			Params:    []openapi.Param{id},
+			Responses: map[int]any{http.StatusOK: vehicleV2}})),
+		v2(openapi.Route{Method: http.MethodPost, Path: "/api/v2/vehicles", OperationID: "createVehicleV2", Summary: "Adds a vehicle",
+			Params:    []openapi.Param{idempotencyKey},
+			Body:      handlers.RequestVehicle{},
+			Responses: map[int]any{http.StatusCreated: vehicleV2, http.StatusConflict: failureV2, http.StatusUnprocessableEntity: failureV2}}),
+		v2(openapi.Route{Method: http.MethodPost, Path: "/api/v2/vehicles/batch", OperationID: "createVehiclesV2", Summary: "Adds several vehicles, all or none",
+			Params:    []openapi.Param{idempotencyKey},
+			Body:      []handlers.RequestVehicle{},
+			Responses: map[int]any{http.StatusCreated: handlers.EnvelopeV2{Data: []handlers.VehicleV2{}, Links: handlers.LinksV2{}}, http.StatusConflict: failureV2, http.StatusUnprocessableEntity: failureV2}}),
+		v2(openapi.Route{Method: http.MethodPatch, Path: "/api/v2/vehicles/:id", OperationID: "patchVehicleV2", Summary: "Updates the max speed of a vehicle",
+			Params:    []openapi.Param{id},
+			Body:      handlers.RequestSpeed{},
+			Responses: map[int]any{http.StatusOK: vehicleV2}}),
+		v2(openapi.Route{Method: http.MethodDelete, Path: "/api/v2/vehicles/:id", OperationID: "deleteVehicleV2", Summary: "Deletes a vehicle",
+			Params:    []openapi.Param{id},
+			Responses: map[int]any{http.StatusNoContent: nil}}),
+		cached(v2(openapi.Route{Method: http.MethodGet, Path: "/api/v2/brands/:brand/average_speed", OperationID: "getSpeedAverageV2",
+			Summary:   "Average max speed of the vehicles of a brand",
+			Params:    []openapi.Param{brand},
+			Responses: map[int]any{http.StatusOK: handlers.EnvelopeV2{Data: handlers.SpeedAverageV2{}, Links: handlers.LinksV2{}}}})),
+
+		// graphql
+		graphql(openapi.Route{Method: http.MethodGet, Path: "/api/v1/graphql", OperationID: "getGraphQL",
+			Summary: "Executes a GraphQL query or subscription, the subscriptions streamed as server-sent events",
+			Params: []openapi.Param{
+				{Name: "query", In: "query", Required: true, Type: "", Constraints: "minLength=1"},
+				{Name: "operationName", In: "query", Type: ""},
+				{Name: "variables", In: "query", Description: "Variables of the operation, as a json object.", Type: ""},
+			},
+			Responses: map[int]any{http.StatusOK: graphqlResult, http.StatusBadRequest: handlers.ResponseGraphQL{},
+				http.StatusMethodNotAllowed: handlers.ResponseGraphQL{}}}),
+		graphql(openapi.Route{Method: http.MethodPost, Path: "/api/v1/graphql", OperationID: "postGraphQL",
+			Summary:   "Executes a GraphQL query, mutation or subscription, the subscriptions streamed as server-sent events",
+			Body:      handlers.RequestGraphQL{},
+			Responses: map[int]any{http.StatusOK: graphqlResult, http.StatusBadRequest: handlers.ResponseGraphQL{}}}),
+
+		// admin
+		admin(openapi.Route{Method: http.MethodGet, Path: "/api/v1/admin/quotas", OperationID: "getQuotas", Summary: "Usage of the daily quotas",
+			Responses: map[int]any{http.StatusOK: handlers.ResponseBody{Data: handlers.QuotasHandler{}}}}),
+		admin(openapi.Route{Method: http.MethodGet, Path: "/api/v1/admin/reload", OperationID: "getReload", Summary: "Status of the last reload",
+			Responses: map[int]any{http.StatusOK: handlers.ResponseBody{Data: reloader.Status{}}}}),
+		admin(openapi.Route{Method: http.MethodPost, Path: "/api/v1/admin/reload", OperationID: "reload", Summary: "Reloads the data files",
+			Responses: map[int]any{
+				http.StatusOK:                  handlers.ResponseBody{Data: reloader.Status{}},
+				http.StatusUnprocessableEntity: handlers.ResponseBody{Data: reloader.Status{}},
+				http.StatusInternalServerError: handlers.ResponseBody{Data: reloader.Status{}},
+			}}),
+		admin(openapi.Route{Method: http.MethodGet, Path: "/api/v1/admin/snapshot", OperationID: "getSnapshot", Summary: "Status of the last snapshot",
+			Responses: map[int]any{http.StatusOK: handlers.ResponseBody{Data: saver.Status{}}}}),
+		admin(openapi.Route{Method: http.MethodPost, Path: "/api/v1/admin/snapshot", OperationID: "snapshot", Summary: "Writes the vehicles to disk",
+			Responses: map[int]any{
+				http.StatusOK:                  handlers.ResponseBody{Data: saver.Status{}},
+				http.StatusInternalServerError: handlers.ResponseBody{Data: saver.Status{}},
+				http.StatusServiceUnavailable:  handlers.ResponseBody{Data: saver.Status{}},
+			}}),
+	}
+}
@@ -0,0 +1,396 @@
+// Package server wires the vehicle api of a configuration: the repositories, services, controllers, middlewares
+// and routes. It is shared by the main command and the end-to-end tests, so both run the very same router.
+package server
+
+import (
+	"app/cmd/handlers"
+	"app/cmd/middlewares"
+	"app/cmd/rpc"
+	"app/internal/auth"
+	"app/internal/config"
+	"app/internal/graph"
+	"app/internal/health"
+	"app/internal/httpcache"
+	"app/internal/idempotency"
+	"app/internal/metrics"
+	"app/internal/openapi"
+	"app/internal/ratelimit"
+	"app/internal/tenant"
+	"app/internal/vehicle/events"
+	"app/internal/vehicle/loader"
+	"app/internal/vehicle/reloader"
+	"app/internal/vehicle/repository"
+	"app/internal/vehicle/saver"
+	"app/internal/vehicle/service"
+	vehiclev1 "app/proto/vehicle/v1"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// deprecatedV1 is the date the vehicle routes of the api v1 were deprecated in favour of the ones of the api v2.
+var deprecatedV1 = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// Server is an struct that represents the wired vehicle api.
+type Server struct {
+	// Router serves every route of the api.
+	Router *gin.Engine
+	// Health is the liveness and readiness of the process.
+	Health *health.Health
+	// Reloader loads the data files into the repository.
+	Reloader *reloader.Reloader
+	// Snapshotter writes the vehicles back to disk.
+	Snapshotter *saver.Snapshotter
+	// Spec is the OpenAPI document of the routes of the api.
+	Spec *openapi.Document
+	// GRPC serves the gRPC api over the same services, nil when the feature is disabled.
+	GRPC *grpc.Server
+
+	// cfg is the configuration the api was wired from.
+	cfg *config.Config
+	// mu guards mismatches.
+	mu sync.Mutex
+	// mismatches are the responses that did not match the OpenAPI document, when their validation is enabled.
+	mismatches []string
+}
+
+// New returns the vehicle api of the configuration.
+// The repository starts empty and the api is not ready until Load fills it.
+func New(cfg *config.Config, lg *slog.Logger) (s *Server, err error) {
+	s = &Server{cfg: cfg}
+
+	// dependencies
+	// -> every tenant loads its own data file, the main file belongs to the default tenant
+	columns, err := loader.ParseColumns(cfg.Loader.CSVColumns)
+	if err != nil {
+		return
+	}
+	opts := loader.Options{
+		Format:    cfg.Loader.Format,
+		Delimiter: []rune(cfg.Loader.CSVDelimiter)[0],
+		Columns:   columns,
+		Mode:      loader.Mode(cfg.Loader.Mode),
+		Logger:    lg.With("layer", "loader"),
+	}
+	sources := func() (loaders map[string]loader.LoaderVehicle, err error) {
+		loaders = make(map[string]loader.LoaderVehicle)
+		if cfg.Loader.TenantsDir != "" {
+			loaders, err = loader.NewLoadersTenants(cfg.Loader.TenantsDir, opts)
+			if err != nil {
+				return
+			}
+		}
+		if cfg.Loader.Path != "" {
+			loaders[tenant.Default], err = loader.NewLoaderVehicle(cfg.Loader.Path, opts)
+		}
+		return
+	}
+
+	// -> the repository starts empty and is filled once the loaders finish, the process is not ready until then
+	s.Health = health.NewHealth()
+	rgMetrics := metrics.NewRegistry()
+	rpMem := repository.NewRepositoryVehicleTenantsInMemory(nil, lg.With("layer", "repository"))
+	rpVh := repository.NewRepositoryVehicleTenantsMetrics(rpMem, rpMem, rgMetrics)
+	// -> the changes made through the services are published to the GraphQL subscriptions
+	bus := events.NewBus(cfg.GraphQL.SubscriptionBuffer)
+	svVh := service.NewServiceVehicleTenantsEvents(service.NewServiceVehicleTenantsDefault(rpVh, lg.With("layer", "service")), bus)
+	ctVh := handlers.NewControllerVehicle(svVh, lg.With("layer", "handler"))
+	ctVhV2 := handlers.NewControllerVehicleV2(svVh, cfg.Pagination.DefaultLimit, cfg.Pagination.MaxLimit, lg.With("layer", "handler"))
+	// -> the data files are reloaded on change or on demand, without a restart
+	s.Reloader, err = reloader.NewReloader(sources, rpMem, reloader.Policy(cfg.Loader.ReloadPolicy), lg.With("layer", "reloader"))
+	if err != nil {
+		return
+	}
+
+	// -> snapshots write the vehicles back to disk, on demand and periodically if they changed
+	s.Snapshotter, err = saver.NewSnapshotter(rpMem, cfg.Snapshot.Dir, saver.Options{
+		Format:    cfg.Snapshot.Format,
+		Delimiter: opts.Delimiter,
+		Columns:   opts.Columns,
+	}, cfg.Snapshot.Gzip, lg.With("layer", "saver"))
+	if err != nil {
+		return
+	}
+
+	// -> authorization (disabled when no policy file is configured)
+	var au auth.Authenticator
+	if cfg.Auth.PolicyPath != "" {
+		var policy *auth.PolicyJSON
+		if policy, err = auth.LoadPolicyJSON(cfg.Auth.PolicyPath); err != nil {
+			return
+		}
+		if au, err = auth.NewAuthenticatorPolicy(policy); err != nil {
+			return
+		}
+	}
+	mwAuth := middlewares.NewAuth(au)
+
+	// -> tenancy: the tenant claim of the principal, then the header, then the subdomain
+	rsTenant := tenant.NewResolverChain(
+		tenant.NewResolverClaim(),
+		tenant.NewResolverHeader(cfg.Tenant.Header),
+		tenant.NewResolverSubdomain(cfg.Tenant.Domain),
+	)
+	mwTenant := middlewares.NewTenant(rsTenant)
+
+	// -> rate limiting: token buckets for read and write routes, plus a daily quota per client,
+	// shared with the gRPC calls so a client can not get around them by switching api
+	var rlKey middlewares.RateLimitKey
+	var rlKeyGRPC rpc.RateLimitKey
+	switch cfg.RateLimit.Key {
+	case "ip":
+		rlKey, rlKeyGRPC = middlewares.RateLimitKeyIP, rpc.RateLimitKeyIP
+	case "tenant":
+		rlKey, rlKeyGRPC = middlewares.RateLimitKeyTenant, rpc.RateLimitKeyTenant
+	default:
+		rlKey, rlKeyGRPC = middlewares.RateLimitKeyAPIKey, rpc.RateLimitKeyAPIKey
+	}
+	qt := ratelimit.NewQuotaDaily(cfg.RateLimit.QuotaDaily)
+	limitRead, limitWrite := noop, noop
+	var rlGRPC *rpc.RateLimit
+	if cfg.Features.RateLimit {
+		lmRead := newLimiter(cfg.RateLimit.ReadRPS, cfg.RateLimit.ReadBurst)
+		lmWrite := newLimiter(cfg.RateLimit.WriteRPS, cfg.RateLimit.WriteBurst)
+		mwRateLimit := middlewares.NewRateLimit(rlKey, qt)
+		limitRead = mwRateLimit.Limit(lmRead)
+		limitWrite = mwRateLimit.Limit(lmWrite)
+		rlGRPC = rpc.NewRateLimit(rlKeyGRPC, lmRead, lmWrite, qt)
+	}
+
+	// -> idempotency keys for the creation routes
+	idempotent := noop
+	if cfg.Features.Idempotency {
+		idempotent = middlewares.NewIdempotency(idempotency.NewStoreInMemory(cfg.Idempotency.Window)).Handle()
+	}
+
+	// -> validation against the OpenAPI document, set once every route is registered
+	mwValidator := middlewares.NewValidator()
+	validate := noop
+	if cfg.Features.ValidateRequests {
+		validate = mwValidator.Requests()
+	}
+
+	// -> http caching of the read routes, driven by the version of the vehicles, and their responses stored in process
+	var rc httpcache.Cache
+	if cfg.Features.ResponseCache {
+		rc = httpcache.NewCacheInMemory(cfg.Cache.Size)
+	}
+	cached := middlewares.NewCaching(rpMem, rc, cfg.Cache.MaxAge, "Authorization", "X-API-Key", cfg.Tenant.Header).Handle()
+
+	// -> per route timeouts, answered with 504 once exceeded
+	timeoutRead := middlewares.Timeout(cfg.Timeouts.Read)
+	timeoutWrite := middlewares.Timeout(cfg.Timeouts.Write)
+	timeoutBatch := middlewares.Timeout(cfg.Timeouts.Batch)
+
+	// -> graphql over the same services, with its own limits of depth and complexity
+	var ctGraphQL *handlers.ControllerGraphQL
+	if cfg.Features.GraphQL {
+		var schema *graph.Schema
+		schema, err = graph.NewSchema(svVh, bus, graph.Limits{
+			MaxDepth:        cfg.GraphQL.MaxDepth,
+			MaxComplexity:   cfg.GraphQL.MaxComplexity,
+			DefaultPageSize: cfg.GraphQL.DefaultPageSize,
+			MaxPageSize:     cfg.GraphQL.MaxPageSize,
+		}, lg.With("layer", "graphql"))
+		if err != nil {
+			return
+		}
+		ctGraphQL = handlers.NewControllerGraphQL(schema, cfg.Timeouts.Write)
+	}
+
+	// -> grpc over the same services, traced, measured, authenticated, bound to a tenant and rate limited as the vehicle routes
+	if cfg.Features.GRPC {
+		var mtGRPC *metrics.GRPC
+		if cfg.Features.Metrics {
+			mtGRPC = metrics.NewGRPC(rgMetrics)
+		}
+		ic := rpc.NewInterceptors(s.Health, au, rsTenant, rlGRPC, mtGRPC, lg.With("layer", "grpc"))
+		s.GRPC = grpc.NewServer(grpc.ChainUnaryInterceptor(ic.Unary()), grpc.ChainStreamInterceptor(ic.Stream()))
+		vehiclev1.RegisterVehicleServiceServer(s.GRPC, rpc.NewServerVehicle(svVh, lg.With("layer", "rpc")))
+		reflection.Register(s.GRPC)
+	}
+
+	ctAdmin := handlers.NewControllerAdmin(qt, s.Reloader, s.Snapshotter)
+	ctHealth := handlers.NewControllerHealth(s.Health)
+	ctDocs := handlers.NewControllerDocs()
+
+	// router
+	rt := gin.New()
+	// -> middlewares
+	mwLogger := middlewares.NewLogger(lg.With("layer", "http"))
+	rt.Use(middlewares.NewTracing().Trace())
+	rt.Use(mwLogger.RequestID())
+	rt.Use(mwLogger.Log())
+	// -> the metrics wrap the recovery, so the requests that panic are counted with their 500
+	if cfg.Features.Metrics {
+		rt.Use(middlewares.NewMetrics(metrics.NewHTTP(rgMetrics)).Measure())
+	}
+	rt.Use(mwLogger.Recovery())
+	// -> the responses are compressed once complete, so the validation sees them as written by the handlers
+	if cfg.Features.Compression {
+		var mwCompress *middlewares.Compress
+		if mwCompress, err = middlewares.NewCompress(cfg.Compression.Encodings, cfg.Compression.Level, cfg.Compression.MinSize); err != nil {
+			return
+		}
+		rt.Use(mwCompress.Handle())
+	}
+	if cfg.Features.ValidateResponses {
+		lgValidator := lg.With("layer", "validator")
+		rt.Use(mwValidator.Responses(func(ctx *gin.Context, errs []openapi.ValidationError) {
+			s.mismatch(ctx, errs)
+			lgValidator.ErrorContext(ctx.Request.Context(), "response does not match the OpenAPI document",
+				"method", ctx.Request.Method, "route", ctx.FullPath(), "status", ctx.Writer.Status(), "errors", errs)
+		}))
+	}
+	// -> probes and metrics
+	rt.GET("/healthz", ctHealth.Liveness())
+	rt.GET("/readyz", ctHealth.Readiness())
+	if cfg.Features.Metrics {
+		rt.GET("/metrics", gin.WrapH(rgMetrics.Handler()))
+	}
+	// -> documentation
+	rt.GET("/openapi.json", ctDocs.Spec())
+	rt.GET("/docs", ctDocs.UI())
+	rt.GET("/docs/:file", ctDocs.Asset())
+	// -> handlers
+	read := mwAuth.Require(auth.PermissionVehiclesRead)
+	write := mwAuth.Require(auth.PermissionVehiclesWrite)
+	remove := mwAuth.Require(auth.PermissionVehiclesDelete)
+	// -> the bodies are bounded once, before any middleware or handler reads them
+	limitBody := middlewares.BodyLimit(int64(cfg.Server.MaxBodyBytes))
+	api := rt.Group("/api/v1", middlewares.Ready(s.Health), limitBody)
+	// -> the vehicle routes of the api v1 keep working over the same services, marked as deprecated
+	grVh := api.Group("/vehicles", middlewares.Deprecated(deprecatedV1, handlers.BasePathV2+"/vehicles"), mwAuth.Authenticate(), mwTenant.Resolve())
+	{
+		grVh.GET("", read, limitRead, validate, cached, timeoutRead, ctVh.GetAll())
+		grVh.GET("/color/:color/year/:year", read, limitRead, validate, cached, timeoutRead, ctVh.GetByColorAndYear())
+		grVh.GET("/brand/:brand/between/:start_year/:end_year", read, limitRead, validate, cached, timeoutRead, ctVh.GetByBrandAndPeriod())
+		grVh.GET("/average_speed/brand/:brand", read, limitRead, validate, cached, timeoutRead, ctVh.GetSpeedAverageByBrand())
+		grVh.GET("/fuel_type/:type", read, limitRead, validate, cached, timeoutRead, ctVh.GetByFuelType())
+		grVh.GET("/weight", read, limitRead, validate, cached, timeoutRead, ctVh.GetByWeight())
+
+		grVh.POST("", write, limitWrite, validate, idempotent, timeoutWrite, ctVh.AddVehicle())
+		grVh.POST("/batch", write, limitWrite, validate, idempotent, timeoutBatch, ctVh.AddVehicles())
+
+		grVh.PUT("/:id/update_speed", write, limitWrite, validate, timeoutWrite, ctVh.UpdateSpeed())
+
+		grVh.DELETE("/:id", remove, limitWrite, validate, timeoutWrite, ctVh.DeleteVehicle())
+
+	}
+	if ctGraphQL != nil {
+		// -> the subscriptions outlive the route timeouts, the queries and mutations have their own deadline,
+		// and the mutations count against the write limits
+		grGraphQL := api.Group("/graphql", mwAuth.Authenticate(), mwTenant.Resolve(), read, ctGraphQL.Limit(limitRead, limitWrite), validate)
+		grGraphQL.GET("", ctGraphQL.Get())
+		grGraphQL.POST("", ctGraphQL.Post())
+	}
+	// -> api v2: the same services, answered with one envelope, filtered with query parameters and paginated
+	apiV2 := rt.Group(handlers.BasePathV2, middlewares.Envelope(), middlewares.Ready(s.Health), limitBody)
+	apiV2.GET("", ctVhV2.Index())
+	grVhV2 := apiV2.Group("/vehicles", mwAuth.Authenticate(), mwTenant.Resolve())
+	{
+		grVhV2.GET("", read, limitRead, validate, cached, timeoutRead, ctVhV2.List())
+		grVhV2.GET("/:id", read, limitRead, validate, cached, timeoutRead, ctVhV2.Get())
+		grVhV2.POST("", write, limitWrite, validate, idempotent, timeoutWrite, ctVhV2.Create())
+		grVhV2.POST("/batch", write, limitWrite, validate, idempotent, timeoutBatch, ctVhV2.CreateBatch())
+		grVhV2.PATCH("/:id", write, limitWrite, validate, timeoutWrite, ctVhV2.Patch())
+		grVhV2.DELETE("/:id", remove, limitWrite, validate, timeoutWrite, ctVhV2.Delete())
+	}
+	grBrandsV2 := apiV2.Group("/brands", mwAuth.Authenticate(), mwTenant.Resolve())
+	grBrandsV2.GET("/:brand/average_speed", read, limitRead, validate, cached, timeoutRead, ctVhV2.SpeedAverage())
+
+	// -> the admin routes are closed when no policy file is configured
+	grAdmin := api.Group("/admin", mwAuth.Restrict(), mwAuth.Authenticate(), mwAuth.Require(auth.PermissionVehiclesAdmin))
+	{
+		grAdmin.GET("/quotas", ctAdmin.GetQuotas())
+		grAdmin.GET("/reload", ctAdmin.GetReload())
+		grAdmin.POST("/reload", ctAdmin.Reload())
+		grAdmin.GET("/snapshot", ctAdmin.GetSnapshot())
+		grAdmin.POST("/snapshot", ctAdmin.Snapshot())
+	}
+
+	// -> the document is generated from the registered routes, and every one of them must be documented
+	endpoints := make([]openapi.Endpoint, 0, len(rt.Routes()))
+	for _, r := range rt.Routes() {
+		endpoints = append(endpoints, openapi.Endpoint{Method: r.Method, Path: r.Path})
+	}
+	if s.Spec, err = openapi.Generate(info, endpoints, routes(cfg)); err != nil {
+		return
+	}
+	spec, err := s.Spec.JSON()
+	if err != nil {
+		return
+	}
+	ctDocs.SetSpec(spec)
+	mwValidator.SetDocument(s.Spec)
+
+	s.Router = rt
+	return
+}
+
+// Load loads the data files into the repository and marks the api as ready.
+func (s *Server) Load(ctx context.Context) (err error) {
+	if _, err = s.Reloader.Reload(ctx, reloader.TriggerStartup); err != nil {
+		return
+	}
+	s.Health.SetReady()
+	return
+}
+
+// Run runs the periodic autosave and the watch of the data files, as configured, until the context is done.
+func (s *Server) Run(ctx context.Context) {
+	if s.cfg.Snapshot.Interval > 0 {
+		go s.Snapshotter.Autosave(ctx, s.cfg.Snapshot.Interval)
+	}
+	if s.cfg.Loader.WatchInterval > 0 {
+		s.Reloader.Watch(ctx, s.cfg.Loader.WatchInterval, s.cfg.Loader.Path, s.cfg.Loader.TenantsDir)
+	}
+	<-ctx.Done()
+}
+
+// Shutdown flushes the repository once the connections are drained: a last snapshot if the autosave is enabled.
+func (s *Server) Shutdown(ctx context.Context) (err error) {
+	if s.cfg.Snapshot.Interval > 0 {
+		_, err = s.Snapshotter.Snapshot(ctx, saver.TriggerShutdown)
+	}
+	return
+}
+
+// Mismatches returns the responses that did not match the OpenAPI document since the api was wired,
+// when the validation of the responses is enabled.
+func (s *Server) Mismatches() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.mismatches...)
+}
+
+// mismatch records a response that does not match the OpenAPI document.
+func (s *Server) mismatch(ctx *gin.Context, errs []openapi.ValidationError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range errs {
+		s.mismatches = append(s.mismatches, fmt.Sprintf("%s %s %d: %s", ctx.Request.Method, ctx.FullPath(), ctx.Writer.Status(), e))
+	}
+}
+
+// noop is a middleware that does nothing, in place of the disabled features.
+func noop(ctx *gin.Context) {
+	ctx.Next()
+}
+
+// newLimiter returns a token bucket limiter, or nil when the rate is zero.
+func newLimiter(rate float64, burst int) ratelimit.Limiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = int(rate) + 1
+	}
+	return ratelimit.NewLimiterTokenBucket(rate, burst)
+}
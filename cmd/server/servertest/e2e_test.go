@@ -0,0 +1,13 @@
+package servertest_test
+
+import (
+	"app/cmd/server/servertest"
+	"flag"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerate the golden files from the responses")
+
+func TestServer(t *testing.T) {
+	servertest.Run(t, servertest.Options{Golden: "testdata/golden", Update: *update})
+}
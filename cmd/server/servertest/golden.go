@@ -0,0 +1,213 @@
+package servertest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	// ErrGoldenMismatch is returned when a response differs from its golden file.
+	ErrGoldenMismatch = errors.New("servertest: response differs from the golden file")
+	// ErrSpecMismatch is returned when a response does not match the OpenAPI document of the api.
+	ErrSpecMismatch = errors.New("servertest: response does not match the OpenAPI document")
+)
+
+// volatileHeaders are the headers left out of the golden files, as they change on every run.
+var volatileHeaders = map[string]bool{
+	"Content-Length": true,
+	"Date":           true,
+	"Etag":           true,
+	"Last-Modified":  true,
+	"X-Request-Id":   true,
+}
+
+// volatileFields are the json fields whose values are replaced in the golden files, as they change on every run.
+var volatileFields = map[string]bool{
+	"day":      true,
+	"duration": true,
+	"time":     true,
+}
+
+// Format returns the golden text of a request and its response: the request line and body, the status,
+// the headers but the volatile ones, and the body with its vehicles sorted by id and the volatile fields replaced.
+// A compressed body is decompressed, and the directory of the fixtures is replaced by $FIXTURES.
+func Format(r Request, rec *httptest.ResponseRecorder, dir string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s\n", r.Method, r.Path)
+	if r.Body != "" {
+		fmt.Fprintf(&b, "%s\n", r.Body)
+	}
+
+	fmt.Fprintf(&b, "\nHTTP %d %s\n", rec.Code, http.StatusText(rec.Code))
+	keys := make([]string, 0, len(rec.Header()))
+	for key := range rec.Header() {
+		if !volatileHeaders[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s: %s\n", key, strings.Join(rec.Header()[key], ", "))
+	}
+
+	body := decompress(rec.Header().Get("Content-Encoding"), rec.Body.Bytes())
+	if dir != "" {
+		body = strings.ReplaceAll(body, dir, "$FIXTURES")
+	}
+	if body != "" {
+		fmt.Fprintf(&b, "\n%s\n", normalize(body))
+	}
+	return b.Bytes()
+}
+
+// decompress returns the body decompressed from its content encoding, or a note if it can not be.
+func decompress(encoding string, body []byte) string {
+	var r io.Reader
+	var err error
+	switch encoding {
+	case "":
+		return string(body)
+	case "gzip":
+		r, err = gzip.NewReader(bytes.NewReader(body))
+	case "br":
+		r = brotli.NewReader(bytes.NewReader(body))
+	case "zstd":
+		var d *zstd.Decoder
+		if d, err = zstd.NewReader(bytes.NewReader(body)); err == nil {
+			defer d.Close()
+			r = d
+		}
+	default:
+		return fmt.Sprintf("<%d bytes of %s>", len(body), encoding)
+	}
+	if err == nil {
+		body, err = io.ReadAll(r)
+	}
+	if err != nil {
+		return fmt.Sprintf("<malformed %s: %v>", encoding, err)
+	}
+	return string(body)
+}
+
+// normalize returns the json body indented, with its vehicles sorted by id and the volatile fields replaced.
+// Any other body is returned as is.
+func normalize(body string) string {
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return strings.TrimRight(body, "\n")
+	}
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(normalizeValue(v)); err != nil {
+		return strings.TrimRight(body, "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// normalizeValue normalizes a decoded json value.
+func normalizeValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if _, ok := value.(string); ok && volatileFields[key] {
+				v[key] = "<" + key + ">"
+				continue
+			}
+			v[key] = normalizeValue(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = normalizeValue(value)
+		}
+		// the vehicles are listed in no particular order
+		sort.SliceStable(v, func(i, j int) bool {
+			return id(v[i]) < id(v[j])
+		})
+	}
+	return v
+}
+
+// id returns the id of a decoded vehicle, or zero for any other value.
+func id(v any) (id float64) {
+	if m, ok := v.(map[string]any); ok {
+		if n, ok := m["id"].(json.Number); ok {
+			id, _ = n.Float64()
+		}
+	}
+	return
+}
+
+// Compare compares the golden text to the golden file, which it writes instead when updating.
+func Compare(path string, got []byte, update bool) (err error) {
+	if update {
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return
+		}
+		return os.WriteFile(path, got, 0o644)
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		err = fmt.Errorf("%w. %v, regenerate the golden files with -update", ErrGoldenMismatch, err)
+		return
+	}
+	if !bytes.Equal(want, got) {
+		err = fmt.Errorf("%w. %s:\n%s", ErrGoldenMismatch, path, diff(string(want), string(got)))
+		return
+	}
+	return
+}
+
+// diff returns the lines removed from want, prefixed by -, and added to got, prefixed by +.
+func diff(want, got string) string {
+	a, b := strings.Split(want, "\n"), strings.Split(got, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var d strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&d, "-%s\n", a[i])
+			i++
+		default:
+			fmt.Fprintf(&d, "+%s\n", b[j])
+			j++
+		}
+	}
+	return d.String()
+}
@@ -0,0 +1,292 @@
+package servertest
+
+import (
+	"app/internal/config"
+	"app/pkg/vehicleclient"
+	vehiclev1 "app/proto/vehicle/v1"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrGRPCDisabled is returned when a gRPC scenario runs on a harness without the gRPC api.
+	ErrGRPCDisabled = errors.New("servertest: the gRPC api is disabled")
+)
+
+// ScenarioGRPC is an struct that represents a call of the gRPC api, made with the Go client over an in-memory
+// connection to a harness of its own, and the golden file of its result.
+type ScenarioGRPC struct {
+	// Name is the name of the golden file, without the .golden extension.
+	Name string
+	// Configure changes the configuration of the harness of the scenario, if not nil.
+	Configure func(cfg *config.Config)
+	// Prepare changes the state of the harness of the scenario once it is loaded, if not nil.
+	Prepare func(h *Harness)
+	// Client are the api key and the tenant of the client.
+	Client vehicleclient.Options
+	// Call describes the call in the golden file, such as GetVehicle(1).
+	Call string
+	// Do makes the call and returns its result.
+	Do func(ctx context.Context, c *vehicleclient.Client) (any, error)
+}
+
+// ScenariosGRPC are the scenarios of every method of the gRPC api.
+var ScenariosGRPC = []ScenarioGRPC{
+	// the calls are still served while the process shuts down
+	{Name: "grpc/shutting_down", Prepare: shuttingDown, Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "GetVehicle(1)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.GetVehicle(ctx, 1) }},
+
+	// the calls share the rate limits and the daily quota of the REST api
+	{Name: "grpc/rate_limit/shared_with_rest",
+		Configure: func(cfg *config.Config) { cfg.RateLimit.ReadRPS, cfg.RateLimit.ReadBurst = 0.001, 1 },
+		Prepare: func(h *Harness) {
+			h.Do(Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: header("analyst-key")})
+		},
+		Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "ListVehicles() after GET /api/v1/vehicles",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.ListVehicles(ctx) }},
+	{Name: "grpc/rate_limit/write_limited",
+		Configure: func(cfg *config.Config) { cfg.RateLimit.WriteRPS, cfg.RateLimit.WriteBurst = 0.001, 1 },
+		Client:    vehicleclient.Options{APIKey: "operator-key"}, Call: "UpdateSpeed(1, 210) twice",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			if _, err := c.UpdateSpeed(ctx, 1, 210); err != nil {
+				return nil, err
+			}
+			return c.UpdateSpeed(ctx, 1, 220)
+		}},
+	{Name: "grpc/rate_limit/quota_exceeded",
+		Configure: func(cfg *config.Config) { cfg.RateLimit.QuotaDaily = 1 },
+		Client:    vehicleclient.Options{APIKey: "analyst-key"}, Call: "GetVehicle(1) twice",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			if _, err := c.GetVehicle(ctx, 1); err != nil {
+				return nil, err
+			}
+			return c.GetVehicle(ctx, 1)
+		}},
+
+	// authentication, authorization and tenancy
+	{Name: "grpc/auth/missing_api_key", Call: "ListVehicles()",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.ListVehicles(ctx) }},
+	{Name: "grpc/auth/unknown_api_key", Client: vehicleclient.Options{APIKey: "unknown-key"}, Call: "ListVehicles()",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.ListVehicles(ctx) }},
+	{Name: "grpc/auth/missing_permission", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "AddVehicle(9)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.AddVehicle(ctx, vehicleGRPC(9, "Ford"))
+		}},
+	{Name: "grpc/auth/brands_of_the_user", Client: vehicleclient.Options{APIKey: "operator-key"}, Call: "ListVehicles()",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.ListVehicles(ctx) }},
+	{Name: "grpc/auth/forbidden_brand", Client: vehicleclient.Options{APIKey: "operator-key"}, Call: "GetVehicle(6)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.GetVehicle(ctx, 6) }},
+	{Name: "grpc/tenant/claim", Client: vehicleclient.Options{APIKey: "acme-operator-key"}, Call: "ListVehicles()",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.ListVehicles(ctx) }},
+	{Name: "grpc/tenant/header", Client: vehicleclient.Options{APIKey: "analyst-key", Tenant: "acme"}, Call: "ListVehicles()",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.ListVehicles(ctx) }},
+	{Name: "grpc/tenant/unknown", Client: vehicleclient.Options{APIKey: "analyst-key", Tenant: "initech"}, Call: "ListVehicles()",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.ListVehicles(ctx) }},
+
+	// reads
+	{Name: "grpc/get_vehicle/found", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "GetVehicle(1)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.GetVehicle(ctx, 1) }},
+	{Name: "grpc/get_vehicle/not_found", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "GetVehicle(99)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.GetVehicle(ctx, 99) }},
+	{Name: "grpc/list_vehicles", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "ListVehicles()",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.ListVehicles(ctx) }},
+	{Name: "grpc/color_year/found", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "ListVehiclesByColorAndYear(Red, 2000)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.ListVehiclesByColorAndYear(ctx, "Red", 2000)
+		}},
+	{Name: "grpc/brand_period/found", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "ListVehiclesByBrandAndPeriod(Ford, 2000, 2010)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.ListVehiclesByBrandAndPeriod(ctx, "Ford", 2000, 2010)
+		}},
+	{Name: "grpc/brand_period/single_year", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "ListVehiclesByBrandAndPeriod(Ford, 2010, 2010)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.ListVehiclesByBrandAndPeriod(ctx, "Ford", 2010, 2010)
+		}},
+	{Name: "grpc/brand_period/start_after_end", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "ListVehiclesByBrandAndPeriod(Ford, 2010, 2000)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.ListVehiclesByBrandAndPeriod(ctx, "Ford", 2010, 2000)
+		}},
+	{Name: "grpc/fuel_type/found", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "ListVehiclesByFuelType(diesel)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.ListVehiclesByFuelType(ctx, "diesel")
+		}},
+	{Name: "grpc/fuel_type/not_found", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "ListVehiclesByFuelType(electric)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.ListVehiclesByFuelType(ctx, "electric")
+		}},
+	{Name: "grpc/weight/range", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "ListVehiclesByWeight(100, 200)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			min, max := 100.0, 200.0
+			return c.ListVehiclesByWeight(ctx, &min, &max)
+		}},
+	{Name: "grpc/weight/open_range", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "ListVehiclesByWeight(250, nil)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			min := 250.0
+			return c.ListVehiclesByWeight(ctx, &min, nil)
+		}},
+	{Name: "grpc/weight/min_above_max", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "ListVehiclesByWeight(200, 100)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			min, max := 200.0, 100.0
+			return c.ListVehiclesByWeight(ctx, &min, &max)
+		}},
+	{Name: "grpc/average_speed/found", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "GetSpeedAverageByBrand(Ford)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.GetSpeedAverageByBrand(ctx, "Ford")
+		}},
+	{Name: "grpc/average_speed/not_found", Client: vehicleclient.Options{APIKey: "analyst-key"}, Call: "GetSpeedAverageByBrand(Tesla)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.GetSpeedAverageByBrand(ctx, "Tesla")
+		}},
+
+	// writes
+	{Name: "grpc/add_vehicle/created", Client: vehicleclient.Options{APIKey: "operator-key"}, Call: "AddVehicle(9)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.AddVehicle(ctx, vehicleGRPC(9, "Ford"))
+		}},
+	{Name: "grpc/add_vehicle/exists", Client: vehicleclient.Options{APIKey: "operator-key"}, Call: "AddVehicle(1)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.AddVehicle(ctx, vehicleGRPC(1, "Ford"))
+		}},
+	{Name: "grpc/add_vehicle/invalid", Client: vehicleclient.Options{APIKey: "operator-key"}, Call: "AddVehicle(9) without registration",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			v := vehicleGRPC(9, "Ford")
+			v.Registration = ""
+			return c.AddVehicle(ctx, v)
+		}},
+	{Name: "grpc/add_vehicles/created", Client: vehicleclient.Options{APIKey: "manager-key"}, Call: "AddVehicles(9, 10)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) {
+			return c.AddVehicles(ctx, []*vehiclev1.Vehicle{vehicleGRPC(9, "Ford"), vehicleGRPC(10, "Toyota")})
+		}},
+	{Name: "grpc/update_speed/updated", Client: vehicleclient.Options{APIKey: "operator-key"}, Call: "UpdateSpeed(1, 210)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.UpdateSpeed(ctx, 1, 210) }},
+	{Name: "grpc/update_speed/out_of_range", Client: vehicleclient.Options{APIKey: "operator-key"}, Call: "UpdateSpeed(1, 500)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.UpdateSpeed(ctx, 1, 500) }},
+	{Name: "grpc/delete_vehicle/deleted", Client: vehicleclient.Options{APIKey: "manager-key"}, Call: "DeleteVehicle(8)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.DeleteVehicle(ctx, 8) }},
+	{Name: "grpc/delete_vehicle/missing_permission", Client: vehicleclient.Options{APIKey: "operator-key"}, Call: "DeleteVehicle(1)",
+		Do: func(ctx context.Context, c *vehicleclient.Client) (any, error) { return c.DeleteVehicle(ctx, 1) }},
+}
+
+// vehicleGRPC returns a vehicle with every field, as the body of newVehicle.
+func vehicleGRPC(id int64, brand string) *vehiclev1.Vehicle {
+	return &vehiclev1.Vehicle{
+		Id: id, Brand: brand, Model: "Focus", Registration: fmt.Sprintf("%04d-BBB", id), Year: 2018, Color: "Grey", MaxSpeed: 190,
+		FuelType: "gasoline", Transmission: "manual", Passengers: 5, Height: 147, Width: 182, Weight: 130,
+	}
+}
+
+// Client returns a client of the gRPC api of the harness over an in-memory connection,
+// serving the api on the first call until Close.
+func (h *Harness) Client(opts vehicleclient.Options) (c *vehicleclient.Client, err error) {
+	if h.API.GRPC == nil {
+		err = ErrGRPCDisabled
+		return
+	}
+	if h.lis == nil {
+		h.lis = bufconn.Listen(1 << 20)
+		go h.API.GRPC.Serve(h.lis)
+	}
+	return vehicleclient.Dial("passthrough:///bufconn", opts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return h.lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+}
+
+// Close stops the gRPC api of the harness, if it is served.
+func (h *Harness) Close() {
+	if h.lis != nil {
+		h.API.GRPC.Stop()
+	}
+}
+
+// CheckGRPC runs a gRPC scenario on a harness of its own over a copy of the fixtures in dir,
+// and compares its result to its golden file.
+func CheckGRPC(dir string, sc ScenarioGRPC, opts Options) (err error) {
+	h, err := NewHarness(dir, sc.Configure)
+	if err != nil {
+		return
+	}
+	defer h.Close()
+	if sc.Prepare != nil {
+		sc.Prepare(h)
+	}
+	c, err := h.Client(sc.Client)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	res, e := sc.Do(context.Background(), c)
+	got, err := FormatGRPC(sc, res, e)
+	if err != nil {
+		return
+	}
+	return Compare(filepath.Join(opts.Golden, sc.Name+".golden"), got, opts.Update)
+}
+
+// FormatGRPC returns the golden text of a call and its result: the call, the api key and tenant of the client,
+// and either the error or the result as json, with its vehicles sorted by id.
+func FormatGRPC(sc ScenarioGRPC, res any, e error) (b []byte, err error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "rpc %s\n", sc.Call)
+	if sc.Client.APIKey != "" {
+		fmt.Fprintf(&buf, "api key: %s\n", sc.Client.APIKey)
+	}
+	if sc.Client.Tenant != "" {
+		fmt.Fprintf(&buf, "tenant: %s\n", sc.Client.Tenant)
+	}
+
+	if e != nil {
+		fmt.Fprintf(&buf, "\nerror: %v\n", e)
+		b = buf.Bytes()
+		return
+	}
+	body, err := marshalGRPC(res)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(&buf, "\n%s\n", normalize(body))
+	b = buf.Bytes()
+	return
+}
+
+// marshalGRPC returns the json of a result: the messages as protojson, their slices sorted by id.
+func marshalGRPC(res any) (body string, err error) {
+	mo := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	switch res := res.(type) {
+	case proto.Message:
+		var data []byte
+		data, err = mo.Marshal(res)
+		body = string(data)
+	case []*vehiclev1.Vehicle:
+		sort.Slice(res, func(i, j int) bool { return res[i].GetId() < res[j].GetId() })
+		items := make([]string, 0, len(res))
+		for _, v := range res {
+			var data []byte
+			if data, err = mo.Marshal(v); err != nil {
+				return
+			}
+			items = append(items, string(data))
+		}
+		body = "[" + strings.Join(items, ",") + "]"
+	default:
+		var data []byte
+		data, err = json.Marshal(res)
+		body = string(data)
+	}
+	return
+}
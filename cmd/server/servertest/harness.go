@@ -0,0 +1,131 @@
+// Package servertest is the end-to-end harness of the vehicle api. It boots the full router of package server
+// over a fixture dataset, serves the scenarios of every route through httptest, and compares the responses to
+// golden files, which the -update flag of the tests regenerates. The calls of the gRPC api are made
+// the same way with the Go client over an in-memory connection. The OpenAPI document of the api
+// can be checked the same way, so a route or a type cannot change without regenerating it:
+//
+//	func TestServer(t *testing.T) {
+//		servertest.Run(t, servertest.Options{Golden: "testdata/golden", Update: *update})
+//	}
+//
+//	go test ./cmd/server/servertest -update
+package servertest
+
+import (
+	"app/cmd/server"
+	"app/internal/config"
+	"app/internal/logging"
+	"context"
+	"embed"
+	"io/fs"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fixtures are the data files and the authorization policy the harness boots with.
+//
+//go:embed testdata/fixtures
+var fixtures embed.FS
+
+// Request is an struct that represents a request of a scenario.
+type Request struct {
+	Method string
+	Path   string
+	// Header are the headers of the request, such as the api key.
+	Header map[string]string
+	// Body is the json body of the request.
+	Body string
+}
+
+// Harness is an struct that holds a vehicle api booted over a copy of the fixtures.
+type Harness struct {
+	// API is the wired vehicle api.
+	API *server.Server
+	// Dir is the copy of the fixtures, the data files of the default tenant and of the tenants, and the policy.
+	Dir string
+
+	// lis is the in-memory listener of the gRPC api, nil until a client is requested.
+	lis *bufconn.Listener
+}
+
+// Config returns the configuration of the harness over the fixtures copied to dir:
+// the defaults without watching the files, with the authorization policy and the tenants of the fixtures,
+// and validating the responses against the OpenAPI document of the api.
+func Config(dir string) *config.Config {
+	cfg := config.Default()
+	cfg.Features.ValidateResponses = true
+	cfg.Loader.Path = filepath.Join(dir, "vehicles.json")
+	cfg.Loader.TenantsDir = filepath.Join(dir, "tenants")
+	cfg.Loader.WatchInterval = 0
+	cfg.Auth.PolicyPath = filepath.Join(dir, "policy.json")
+	return cfg
+}
+
+// NewHarness copies the fixtures to dir, boots the vehicle api of their configuration and loads the data files.
+// A non nil configure changes the configuration before the api is wired.
+func NewHarness(dir string, configure func(cfg *config.Config)) (h *Harness, err error) {
+	if err = copyFixtures(dir); err != nil {
+		return
+	}
+	cfg := Config(dir)
+	if configure != nil {
+		configure(cfg)
+	}
+	if err = cfg.Validate(); err != nil {
+		return
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+	api, err := server.New(cfg, logging.Discard())
+	if err != nil {
+		return
+	}
+	if err = api.Load(context.Background()); err != nil {
+		return
+	}
+	h = &Harness{API: api, Dir: dir}
+	return
+}
+
+// Do serves the request with the router of the api and returns the recorded response.
+func (h *Harness) Do(r Request) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(r.Method, r.Path, strings.NewReader(r.Body))
+	if r.Body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range r.Header {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	h.API.Router.ServeHTTP(rec, req)
+	return rec
+}
+
+// copyFixtures copies the fixtures to dir.
+func copyFixtures(dir string) error {
+	root := "testdata/fixtures"
+	return fs.WalkDir(fixtures, root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, strings.TrimPrefix(path, root))
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		data, err := fixtures.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+}
+
+// header returns the headers of a request authenticated with the api key.
+func header(apiKey string) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + apiKey}
+}
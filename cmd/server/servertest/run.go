@@ -0,0 +1,90 @@
+package servertest
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Options is an struct that represents how the scenarios are run.
+type Options struct {
+	// Golden is the directory of the golden files.
+	Golden string
+	// Update writes the golden files instead of comparing the responses to them.
+	Update bool
+	// Scenarios are the scenarios to run, all of them if empty.
+	Scenarios []Scenario
+	// ScenariosGRPC are the gRPC scenarios to run, all of them if empty.
+	ScenariosGRPC []ScenarioGRPC
+	// Spec is the path of the OpenAPI document of the api, checked as one more golden file unless empty.
+	Spec string
+}
+
+// scenarios returns the scenarios to run.
+func (o Options) scenarios() []Scenario {
+	if len(o.Scenarios) == 0 {
+		return Scenarios
+	}
+	return o.Scenarios
+}
+
+// scenariosGRPC returns the gRPC scenarios to run.
+func (o Options) scenariosGRPC() []ScenarioGRPC {
+	if len(o.ScenariosGRPC) == 0 {
+		return ScenariosGRPC
+	}
+	return o.ScenariosGRPC
+}
+
+// Run runs every scenario as a subtest, and the check of the OpenAPI document if set.
+func Run(t *testing.T, opts Options) {
+	if opts.Spec != "" {
+		t.Run("openapi", func(t *testing.T) {
+			if err := CheckSpec(opts.Spec, opts.Update); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	for _, sc := range opts.scenarios() {
+		sc := sc
+		t.Run(sc.Name, func(t *testing.T) {
+			if err := Check(t.TempDir(), sc, opts); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	for _, sc := range opts.scenariosGRPC() {
+		sc := sc
+		t.Run(sc.Name, func(t *testing.T) {
+			if err := CheckGRPC(t.TempDir(), sc, opts); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// Check runs a scenario on a harness of its own over a copy of the fixtures in dir,
+// and compares its response to its golden file.
+func Check(dir string, sc Scenario, opts Options) (err error) {
+	h, err := NewHarness(dir, sc.Configure)
+	if err != nil {
+		return
+	}
+	if sc.Prepare != nil {
+		sc.Prepare(h)
+	}
+	for _, r := range sc.Before {
+		if rec := h.Do(r); rec.Code >= http.StatusBadRequest {
+			err = fmt.Errorf("servertest: %s %s before the scenario: %d %s", r.Method, r.Path, rec.Code, rec.Body)
+			return
+		}
+	}
+	rec := h.Do(sc.Request)
+	if mismatches := h.API.Mismatches(); len(mismatches) > 0 {
+		err = fmt.Errorf("%w:\n%s", ErrSpecMismatch, strings.Join(mismatches, "\n"))
+		return
+	}
+	return Compare(filepath.Join(opts.Golden, sc.Name+".golden"), Format(sc.Request, rec, dir), opts.Update)
+}
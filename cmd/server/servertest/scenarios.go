@@ -0,0 +1,388 @@
+package servertest
+
+import (
+	"app/internal/config"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Scenario is an struct that represents a request served by a harness of its own, and the golden file of its response.
+type Scenario struct {
+	// Name is the name of the golden file, without the .golden extension.
+	Name string
+	// Configure changes the configuration of the harness of the scenario, if not nil.
+	Configure func(cfg *config.Config)
+	// Prepare changes the state of the harness of the scenario once it is loaded, if not nil.
+	Prepare func(h *Harness)
+	// Before are the requests served before the request of the scenario, which must succeed.
+	Before []Request
+	// Request is the request whose response is compared to the golden file.
+	Request Request
+}
+
+// newVehicle is the body of a vehicle that is not in the fixtures.
+var newVehicle = vehicle(9, "Ford", "Focus", "0009-BBB")
+
+// vehicle returns the body of a vehicle with every field.
+func vehicle(id int, brand, model, registration string) string {
+	return fmt.Sprintf(`{"id":%d,"brand":%q,"model":%q,"registration":%q,"year":2018,"color":"Grey","max_speed":190,"fuel_type":"gasoline","transmission":"manual","passengers":5,"height":147,"width":182,"weight":130}`,
+		id, brand, model, registration)
+}
+
+// encoding returns the headers of a request of the analyst accepting the content encodings.
+func encoding(accept string) map[string]string {
+	return map[string]string{"Authorization": "Bearer analyst-key", "Accept-Encoding": accept}
+}
+
+// conditional returns the headers of a request of the analyst with the conditional headers, as name and value pairs.
+func conditional(pairs ...string) map[string]string {
+	h := header("analyst-key")
+	for i := 0; i+1 < len(pairs); i += 2 {
+		h[pairs[i]] = pairs[i+1]
+	}
+	return h
+}
+
+// responseCache enables the response cache.
+func responseCache(cfg *config.Config) {
+	cfg.Features.ResponseCache = true
+}
+
+// shuttingDown marks the process of the harness as shutting down, as on a termination signal.
+func shuttingDown(h *Harness) {
+	h.API.Health.SetShuttingDown()
+}
+
+// graphQL returns the body of a GraphQL request of the query.
+func graphQL(query string) string {
+	body, _ := json.Marshal(map[string]string{"query": query})
+	return string(body)
+}
+
+// Scenarios are the scenarios of every route of the api.
+var Scenarios = []Scenario{
+	// probes
+	{Name: "probes/healthz", Request: Request{Method: http.MethodGet, Path: "/healthz"}},
+	{Name: "probes/readyz", Request: Request{Method: http.MethodGet, Path: "/readyz"}},
+	{Name: "probes/shutting_down/readyz", Prepare: shuttingDown, Request: Request{Method: http.MethodGet, Path: "/readyz"}},
+	{Name: "probes/shutting_down/api", Prepare: shuttingDown, Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: header("analyst-key")}},
+	{Name: "probes/docs", Request: Request{Method: http.MethodGet, Path: "/docs"}},
+	{Name: "probes/docs_unknown_file", Request: Request{Method: http.MethodGet, Path: "/docs/swagger-ui.js"}},
+	{Name: "probes/unknown_route", Request: Request{Method: http.MethodGet, Path: "/api/v1/unknown", Header: header("analyst-key")}},
+
+	// authentication, authorization and tenancy
+	{Name: "auth/missing_api_key", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles"}},
+	{Name: "auth/unknown_api_key", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: header("unknown-key")}},
+	{Name: "auth/missing_permission", Request: Request{Method: http.MethodPost, Path: "/api/v1/vehicles", Header: header("analyst-key"), Body: newVehicle}},
+	{Name: "auth/brands_of_the_user", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: header("operator-key")}},
+	{Name: "tenant/claim", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: header("acme-operator-key")}},
+	{Name: "tenant/header", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: map[string]string{"Authorization": "Bearer analyst-key", "X-Tenant-ID": "acme"}}},
+	{Name: "tenant/unknown", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: map[string]string{"Authorization": "Bearer analyst-key", "X-Tenant-ID": "initech"}}},
+
+	// GET /vehicles
+	{Name: "vehicles/get_all", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: header("analyst-key")}},
+
+	// GET /vehicles/color/:color/year/:year
+	{Name: "vehicles/color_year/found", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/color/Red/year/2000", Header: header("analyst-key")}},
+	{Name: "vehicles/color_year/not_found", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/color/Purple/year/2000", Header: header("analyst-key")}},
+	{Name: "vehicles/color_year/invalid_year", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/color/Red/year/abc", Header: header("analyst-key")}},
+
+	// GET /vehicles/brand/:brand/between/:start_year/:end_year
+	{Name: "vehicles/brand_period/found", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/brand/Ford/between/2000/2010", Header: header("analyst-key")}},
+	{Name: "vehicles/brand_period/not_found", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/brand/Ford/between/1950/1960", Header: header("analyst-key")}},
+	{Name: "vehicles/brand_period/invalid_year", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/brand/Ford/between/abc/2010", Header: header("analyst-key")}},
+	{Name: "vehicles/brand_period/invalid_years", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/brand/Ford/between/abc/20.5", Header: header("analyst-key")}},
+	{Name: "vehicles/brand_period/start_after_end", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/brand/Ford/between/2010/2000", Header: header("analyst-key")}},
+	{Name: "vehicles/brand_period/forbidden_brand", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/brand/Toyota/between/2000/2020", Header: header("operator-key")}},
+
+	// GET /vehicles/average_speed/brand/:brand
+	{Name: "vehicles/average_speed/found", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/average_speed/brand/Ford", Header: header("analyst-key")}},
+	{Name: "vehicles/average_speed/not_found", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/average_speed/brand/Tesla", Header: header("analyst-key")}},
+
+	// GET /vehicles/fuel_type/:type
+	{Name: "vehicles/fuel_type/found", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/fuel_type/diesel", Header: header("analyst-key")}},
+	{Name: "vehicles/fuel_type/not_found", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/fuel_type/electric", Header: header("analyst-key")}},
+
+	// GET /vehicles/weight
+	{Name: "vehicles/weight/found", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/weight?weight_min=100&weight_max=200", Header: header("analyst-key")}},
+	{Name: "vehicles/weight/fractional_bounds", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/weight?weight_min=1.6&weight_max=99", Header: header("analyst-key")}},
+	{Name: "vehicles/weight/open_max", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/weight?weight_min=250", Header: header("analyst-key")}},
+	{Name: "vehicles/weight/open_min", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/weight?weight_max=100", Header: header("analyst-key")}},
+	{Name: "vehicles/weight/invalid_number", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/weight?weight_min=heavy&weight_max=NaN", Header: header("analyst-key")}},
+	{Name: "vehicles/weight/negative_min", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/weight?weight_min=-1&weight_max=100", Header: header("analyst-key")}},
+	{Name: "vehicles/weight/min_after_max", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/weight?weight_min=200&weight_max=100", Header: header("analyst-key")}},
+	{Name: "vehicles/weight/not_found", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/weight?weight_min=400&weight_max=500", Header: header("analyst-key")}},
+
+	// POST /vehicles
+	{Name: "vehicles/add/created", Request: Request{Method: http.MethodPost, Path: "/api/v1/vehicles", Header: header("manager-key"), Body: newVehicle}},
+	{Name: "vehicles/add/existing_id", Request: Request{Method: http.MethodPost, Path: "/api/v1/vehicles", Header: header("manager-key"),
+		Body: vehicle(1, "Ford", "Focus", "0009-BBB")}},
+	{Name: "vehicles/add/missing_fields", Request: Request{Method: http.MethodPost, Path: "/api/v1/vehicles", Header: header("manager-key"),
+		Body: `{"id":9,"brand":"","model":"Focus","registration":"0009-BBB","year":2018}`}},
+	{Name: "vehicles/add/malformed", Request: Request{Method: http.MethodPost, Path: "/api/v1/vehicles", Header: header("manager-key"), Body: `{"id":"nine"}`}},
+	{Name: "vehicles/add/forbidden_brand", Request: Request{Method: http.MethodPost, Path: "/api/v1/vehicles", Header: header("operator-key"),
+		Body: vehicle(9, "Toyota", "Yaris", "0009-BBB")}},
+	{
+		Name: "vehicles/add/idempotent_replay",
+		Before: []Request{
+			{Method: http.MethodPost, Path: "/api/v1/vehicles", Header: map[string]string{"Authorization": "Bearer manager-key", "Idempotency-Key": "add-9"}, Body: newVehicle},
+		},
+		Request: Request{Method: http.MethodPost, Path: "/api/v1/vehicles", Header: map[string]string{"Authorization": "Bearer manager-key", "Idempotency-Key": "add-9"}, Body: newVehicle},
+	},
+	{
+		Name:      "vehicles/add/body_too_large",
+		Configure: func(cfg *config.Config) { cfg.Server.MaxBodyBytes = 64 },
+		Request:   Request{Method: http.MethodPost, Path: "/api/v1/vehicles", Header: header("manager-key"), Body: newVehicle},
+	},
+
+	// POST /vehicles/batch
+	{Name: "vehicles/batch/created", Request: Request{Method: http.MethodPost, Path: "/api/v1/vehicles/batch", Header: header("manager-key"),
+		Body: "[" + vehicle(9, "Ford", "Focus", "0009-BBB") + "," + vehicle(10, "BMW", "M3", "0010-BBB") + "]"}},
+	{Name: "vehicles/batch/existing_id", Request: Request{Method: http.MethodPost, Path: "/api/v1/vehicles/batch", Header: header("manager-key"),
+		Body: "[" + vehicle(9, "Ford", "Focus", "0009-BBB") + "," + vehicle(2, "BMW", "M3", "0010-BBB") + "]"}},
+	{Name: "vehicles/batch/invalid_vehicle", Request: Request{Method: http.MethodPost, Path: "/api/v1/vehicles/batch", Header: header("manager-key"),
+		Body: "[" + vehicle(9, "Ford", "Focus", "0009-BBB") + "," + strings.Replace(vehicle(10, "BMW", "M3", "0010-BBB"), `"max_speed":190`, `"max_speed":500`, 1) + "]"}},
+	{Name: "vehicles/batch/malformed", Request: Request{Method: http.MethodPost, Path: "/api/v1/vehicles/batch", Header: header("manager-key"), Body: newVehicle}},
+
+	// PUT /vehicles/:id/update_speed
+	{Name: "vehicles/update_speed/updated", Request: Request{Method: http.MethodPut, Path: "/api/v1/vehicles/1/update_speed", Header: header("manager-key"), Body: `{"max_speed":210}`}},
+	{Name: "vehicles/update_speed/out_of_range", Request: Request{Method: http.MethodPut, Path: "/api/v1/vehicles/1/update_speed", Header: header("manager-key"), Body: `{"max_speed":500}`}},
+	{Name: "vehicles/update_speed/not_found", Request: Request{Method: http.MethodPut, Path: "/api/v1/vehicles/99/update_speed", Header: header("manager-key"), Body: `{"max_speed":210}`}},
+	{Name: "vehicles/update_speed/malformed", Request: Request{Method: http.MethodPut, Path: "/api/v1/vehicles/1/update_speed", Header: header("manager-key"), Body: `{"max_speed":"fast"}`}},
+	{Name: "vehicles/update_speed/invalid_id", Request: Request{Method: http.MethodPut, Path: "/api/v1/vehicles/one/update_speed", Header: header("manager-key"), Body: `{"max_speed":210}`}},
+	{Name: "vehicles/update_speed/forbidden_brand", Request: Request{Method: http.MethodPut, Path: "/api/v1/vehicles/6/update_speed", Header: header("operator-key"), Body: `{"max_speed":210}`}},
+
+	// DELETE /vehicles/:id
+	{Name: "vehicles/delete/deleted", Request: Request{Method: http.MethodDelete, Path: "/api/v1/vehicles/8", Header: header("manager-key")}},
+	{
+		Name:    "vehicles/delete/gone",
+		Before:  []Request{{Method: http.MethodDelete, Path: "/api/v1/vehicles/8", Header: header("manager-key")}},
+		Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/fuel_type/diesel", Header: header("analyst-key")},
+	},
+	{Name: "vehicles/delete/not_found", Request: Request{Method: http.MethodDelete, Path: "/api/v1/vehicles/99", Header: header("manager-key")}},
+	{Name: "vehicles/delete/invalid_id", Request: Request{Method: http.MethodDelete, Path: "/api/v1/vehicles/one", Header: header("manager-key")}},
+	{Name: "vehicles/delete/missing_permission", Request: Request{Method: http.MethodDelete, Path: "/api/v1/vehicles/1", Header: header("operator-key")}},
+
+	// /graphql
+	{Name: "graphql/query", Request: Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("operator-key"),
+		Body: graphQL(`{ vehicles(first: 2) { totalCount nodes { id brand model maxSpeed } pageInfo { hasNextPage endCursor } } vehicle(id: 1) { id registration } }`)}},
+	{Name: "graphql/next_page", Request: Request{Method: http.MethodGet, Header: header("analyst-key"),
+		Path: "/api/v1/graphql?query=" + url.QueryEscape(`{ vehicles(first: 3, after: "dmVoaWNsZToz", filter: {fuelType: "gasoline"}) { nodes { id } pageInfo { hasNextPage } } }`)}},
+	{Name: "graphql/stats", Request: Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("analyst-key"),
+		Body: graphQL(`{ stats(filter: {yearMin: 2000}) { count averageMaxSpeed averageWeight minYear maxYear byBrand { key count averageMaxSpeed } } averageSpeedByBrand(brand: "Ford") }`)}},
+	{Name: "graphql/forbidden_brand", Request: Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("operator-key"),
+		Body: graphQL(`{ vehicle(id: 6) { id brand } }`)}},
+	{Name: "graphql/add_vehicle", Request: Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("manager-key"),
+		Body: graphQL(`mutation { addVehicle(input: {id: 9, brand: "Ford", model: "Focus", registration: "0009-BBB", year: 2018, color: "Grey", maxSpeed: 190, fuelType: "gasoline", transmission: "manual", passengers: 5, height: 147, width: 182, weight: 130}) { id brand model } }`)}},
+	{Name: "graphql/update_speed_missing_permission", Request: Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("analyst-key"),
+		Body: graphQL(`mutation { updateSpeed(id: 1, maxSpeed: 210) { id maxSpeed } }`)}},
+	{
+		Name:    "graphql/delete_vehicle",
+		Before:  []Request{{Method: http.MethodPut, Path: "/api/v1/vehicles/8/update_speed", Header: header("manager-key"), Body: `{"max_speed":210}`}},
+		Request: Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("manager-key"), Body: graphQL(`mutation { deleteVehicle(id: 8) { id maxSpeed } }`)},
+	},
+	{
+		Name:      "graphql/mutation_write_limit",
+		Configure: func(cfg *config.Config) { cfg.RateLimit.WriteRPS, cfg.RateLimit.WriteBurst = 0.001, 1 },
+		Before: []Request{
+			{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("analyst-key"), Body: graphQL(`{ vehicle(id: 1) { id } }`)},
+			{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("manager-key"), Body: graphQL(`mutation { deleteVehicle(id: 8) { id } }`)},
+		},
+		Request: Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("manager-key"), Body: graphQL(`mutation { deleteVehicle(id: 7) { id } }`)},
+	},
+	{Name: "graphql/mutation_over_get", Request: Request{Method: http.MethodGet, Header: header("manager-key"),
+		Path: "/api/v1/graphql?query=" + url.QueryEscape(`mutation { deleteVehicle(id: 8) { id } }`)}},
+	{Name: "graphql/syntax_error", Request: Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("analyst-key"), Body: graphQL(`{ vehicles( }`)}},
+	{
+		Name:      "graphql/too_deep",
+		Configure: func(cfg *config.Config) { cfg.GraphQL.MaxDepth = 2 },
+		Request:   Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("analyst-key"), Body: graphQL(`{ vehicles { pageInfo { hasNextPage } } }`)},
+	},
+	{
+		Name:      "graphql/introspection_too_deep",
+		Configure: func(cfg *config.Config) { cfg.GraphQL.MaxDepth = 2 },
+		Request:   Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("analyst-key"), Body: graphQL(`{ __schema { types { name } } }`)},
+	},
+	{
+		Name:      "graphql/body_too_large",
+		Configure: func(cfg *config.Config) { cfg.Server.MaxBodyBytes = 16 },
+		Request:   Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("analyst-key"), Body: graphQL(`{ vehicle(id: 1) { id } }`)},
+	},
+	{Name: "graphql/too_complex", Request: Request{Method: http.MethodPost, Path: "/api/v1/graphql", Header: header("analyst-key"),
+		Body: graphQL(`{ vehicles(first: 100) { nodes { id brand model registration year color maxSpeed fuelType transmission passengers height } } }`)}},
+
+	// api v2
+	{Name: "v2/index", Request: Request{Method: http.MethodGet, Path: "/api/v2"}},
+	{Name: "v2/auth/missing_api_key", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles"}},
+	{Name: "v2/auth/missing_permission", Request: Request{Method: http.MethodPost, Path: "/api/v2/vehicles", Header: header("analyst-key"), Body: newVehicle}},
+	{Name: "v2/tenant/header", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles", Header: map[string]string{"Authorization": "Bearer analyst-key", "X-Tenant-ID": "acme"}}},
+	{Name: "v2/tenant/unknown", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles", Header: map[string]string{"Authorization": "Bearer analyst-key", "X-Tenant-ID": "initech"}}},
+	{Name: "v2/list/first_page", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles?limit=3", Header: header("manager-key")}},
+	{Name: "v2/list/last_page", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles?limit=3&offset=6", Header: header("analyst-key")}},
+	{Name: "v2/list/filters", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles?brand=ford&year_min=2000&year_max=2009&weight_max=160", Header: header("analyst-key")}},
+	{Name: "v2/list/year", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles?color=Red&year=2000", Header: header("analyst-key")}},
+	{Name: "v2/list/empty", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles?fuel_type=electric", Header: header("analyst-key")}},
+	{Name: "v2/list/brands_of_the_user", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles?fuel_type=diesel", Header: header("operator-key")}},
+	{Name: "v2/list/invalid_params", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles?limit=0&offset=x", Header: header("analyst-key")}},
+	{Name: "v2/list/invalid_range", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles?year_min=2010&year_max=2000", Header: header("analyst-key")}},
+	{Name: "v2/get/found", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/1", Header: header("operator-key")}},
+	{Name: "v2/get/not_found", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/99", Header: header("analyst-key")}},
+	{Name: "v2/get/forbidden_brand", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/6", Header: header("operator-key")}},
+	{Name: "v2/create/created", Request: Request{Method: http.MethodPost, Path: "/api/v2/vehicles", Header: header("manager-key"), Body: newVehicle}},
+	{
+		Name:      "v2/create/body_too_large",
+		Configure: func(cfg *config.Config) { cfg.Server.MaxBodyBytes = 64 },
+		Request:   Request{Method: http.MethodPost, Path: "/api/v2/vehicles", Header: header("manager-key"), Body: newVehicle},
+	},
+	{Name: "v2/create/existing_id", Request: Request{Method: http.MethodPost, Path: "/api/v2/vehicles", Header: header("manager-key"),
+		Body: vehicle(1, "Ford", "Focus", "0009-BBB")}},
+	{Name: "v2/create/invalid", Request: Request{Method: http.MethodPost, Path: "/api/v2/vehicles", Header: header("manager-key"),
+		Body: `{"id":9,"brand":"","model":"Focus","registration":"0009-BBB","year":2018}`}},
+	{Name: "v2/create/batch", Request: Request{Method: http.MethodPost, Path: "/api/v2/vehicles/batch", Header: header("manager-key"),
+		Body: "[" + vehicle(9, "Ford", "Focus", "0009-BBB") + "," + vehicle(10, "BMW", "M3", "0010-BBB") + "]"}},
+	{Name: "v2/patch/updated", Request: Request{Method: http.MethodPatch, Path: "/api/v2/vehicles/1", Header: header("operator-key"), Body: `{"max_speed":210}`}},
+	{Name: "v2/patch/out_of_range", Request: Request{Method: http.MethodPatch, Path: "/api/v2/vehicles/1", Header: header("manager-key"), Body: `{"max_speed":500}`}},
+	{Name: "v2/delete/deleted", Request: Request{Method: http.MethodDelete, Path: "/api/v2/vehicles/8", Header: header("manager-key")}},
+	{
+		Name:    "v2/delete/gone",
+		Before:  []Request{{Method: http.MethodDelete, Path: "/api/v2/vehicles/8", Header: header("manager-key")}},
+		Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/8", Header: header("manager-key")},
+	},
+	{Name: "v2/average_speed/found", Request: Request{Method: http.MethodGet, Path: "/api/v2/brands/Ford/average_speed", Header: header("analyst-key")}},
+	{Name: "v2/average_speed/not_found", Request: Request{Method: http.MethodGet, Path: "/api/v2/brands/Tesla/average_speed", Header: header("analyst-key")}},
+	{
+		Name:    "v2/v1_changes_are_shared",
+		Before:  []Request{{Method: http.MethodPut, Path: "/api/v1/vehicles/2/update_speed", Header: header("manager-key"), Body: `{"max_speed":175}`}},
+		Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/2", Header: header("analyst-key")},
+	},
+
+	// compression
+	{Name: "compression/gzip", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: encoding("gzip")}},
+	{Name: "compression/br", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: encoding("br")}},
+	{Name: "compression/zstd", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles", Header: encoding("zstd")}},
+	{Name: "compression/weights", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: encoding("gzip;q=0.5, br;q=0.8, zstd;q=0")}},
+	{Name: "compression/preference", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: encoding("gzip, br, *")}},
+	{Name: "compression/identity", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: encoding("identity")}},
+	{Name: "compression/small", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/average_speed/brand/Ford", Header: encoding("gzip")}},
+	{Name: "compression/error", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/99", Header: encoding("gzip")}},
+	{
+		Name:      "compression/disabled",
+		Configure: func(cfg *config.Config) { cfg.Features.Compression = false },
+		Request:   Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: encoding("gzip")},
+	},
+
+	// http caching
+	{Name: "caching/modified_since", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: conditional("If-Modified-Since", "Sat, 01 Jan 2000 00:00:00 GMT")}},
+	{Name: "caching/not_modified_since", Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: conditional("If-Modified-Since", "Fri, 01 Jan 2100 00:00:00 GMT")}},
+	{Name: "caching/none_match", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/1", Header: conditional("If-None-Match", `W/"0"`)}},
+	{Name: "caching/none_match_any", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/1", Header: conditional("If-None-Match", "*")}},
+	{
+		Name:    "caching/none_match_over_modified_since",
+		Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/1", Header: conditional("If-None-Match", `W/"0"`, "If-Modified-Since", "Fri, 01 Jan 2100 00:00:00 GMT")},
+	},
+	{Name: "caching/error", Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/99", Header: header("analyst-key")}},
+	{
+		Name:      "caching/max_age",
+		Configure: func(cfg *config.Config) { cfg.Cache.MaxAge = time.Minute },
+		Request:   Request{Method: http.MethodGet, Path: "/api/v2/brands/Ford/average_speed", Header: header("analyst-key")},
+	},
+	{
+		Name:      "caching/stored/miss",
+		Configure: responseCache,
+		Request:   Request{Method: http.MethodGet, Path: "/api/v2/vehicles?brand=ford", Header: header("analyst-key")},
+	},
+	{
+		Name:      "caching/stored/hit",
+		Configure: responseCache,
+		Before:    []Request{{Method: http.MethodGet, Path: "/api/v2/vehicles?brand=ford", Header: header("analyst-key")}},
+		Request:   Request{Method: http.MethodGet, Path: "/api/v2/vehicles?brand=ford", Header: header("analyst-key")},
+	},
+	{
+		Name:      "caching/stored/hit_compressed",
+		Configure: responseCache,
+		Before:    []Request{{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: header("analyst-key")}},
+		Request:   Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: encoding("gzip")},
+	},
+	{
+		Name:      "caching/stored/scoped_by_user",
+		Configure: responseCache,
+		Before:    []Request{{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: header("manager-key")}},
+		Request:   Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: header("operator-key")},
+	},
+	{
+		Name:      "caching/stored/scoped_by_tenant",
+		Configure: responseCache,
+		Before:    []Request{{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: header("analyst-key")}},
+		Request:   Request{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: map[string]string{"Authorization": "Bearer analyst-key", "X-Tenant-ID": "acme"}},
+	},
+	{
+		Name:      "caching/stored/kept_by_other_tenant",
+		Configure: responseCache,
+		Before: []Request{
+			{Method: http.MethodGet, Path: "/api/v2/vehicles/1", Header: header("manager-key")},
+			{Method: http.MethodDelete, Path: "/api/v2/vehicles/2", Header: map[string]string{"Authorization": "Bearer manager-key", "X-Tenant-ID": "acme"}},
+		},
+		Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/1", Header: header("manager-key")},
+	},
+	{
+		Name:      "caching/stored/invalidated_by_add",
+		Configure: responseCache,
+		Before: []Request{
+			{Method: http.MethodGet, Path: "/api/v2/vehicles?brand=ford", Header: header("manager-key")},
+			{Method: http.MethodPost, Path: "/api/v1/vehicles", Header: header("manager-key"), Body: newVehicle},
+		},
+		Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles?brand=ford", Header: header("manager-key")},
+	},
+	{
+		Name:      "caching/stored/invalidated_by_update",
+		Configure: responseCache,
+		Before: []Request{
+			{Method: http.MethodGet, Path: "/api/v1/vehicles/average_speed/brand/Ford", Header: header("manager-key")},
+			{Method: http.MethodPatch, Path: "/api/v2/vehicles/1", Header: header("manager-key"), Body: `{"max_speed":110}`},
+		},
+		Request: Request{Method: http.MethodGet, Path: "/api/v1/vehicles/average_speed/brand/Ford", Header: header("manager-key")},
+	},
+	{
+		Name:      "caching/stored/invalidated_by_delete",
+		Configure: responseCache,
+		Before: []Request{
+			{Method: http.MethodGet, Path: "/api/v2/vehicles/8", Header: header("manager-key")},
+			{Method: http.MethodDelete, Path: "/api/v2/vehicles/8", Header: header("manager-key")},
+		},
+		Request: Request{Method: http.MethodGet, Path: "/api/v2/vehicles/8", Header: header("manager-key")},
+	},
+
+	// admin
+	{
+		Name:      "admin/no_authentication",
+		Configure: func(cfg *config.Config) { cfg.Auth.PolicyPath = "" },
+		Request:   Request{Method: http.MethodGet, Path: "/api/v1/admin/quotas"},
+	},
+	{Name: "admin/missing_permission", Request: Request{Method: http.MethodGet, Path: "/api/v1/admin/quotas", Header: header("manager-key")}},
+	{
+		Name:    "admin/quotas",
+		Before:  []Request{{Method: http.MethodGet, Path: "/api/v1/vehicles", Header: header("analyst-key")}},
+		Request: Request{Method: http.MethodGet, Path: "/api/v1/admin/quotas", Header: header("admin-key")},
+	},
+	{Name: "admin/reload_status", Request: Request{Method: http.MethodGet, Path: "/api/v1/admin/reload", Header: header("admin-key")}},
+	{Name: "admin/reload", Request: Request{Method: http.MethodPost, Path: "/api/v1/admin/reload", Header: header("admin-key")}},
+	{
+		Name: "admin/reload_empty_tenant",
+		Configure: func(cfg *config.Config) {
+			os.WriteFile(filepath.Join(cfg.Loader.TenantsDir, "acme.json"), []byte("[]"), 0o644)
+		},
+		Request: Request{Method: http.MethodPost, Path: "/api/v1/admin/reload", Header: header("admin-key")},
+	},
+	{Name: "admin/snapshot_disabled", Request: Request{Method: http.MethodPost, Path: "/api/v1/admin/snapshot", Header: header("admin-key")}},
+	{
+		Name:      "admin/snapshot",
+		Configure: func(cfg *config.Config) { cfg.Snapshot.Dir = filepath.Join(filepath.Dir(cfg.Loader.Path), "snapshots") },
+		Request:   Request{Method: http.MethodPost, Path: "/api/v1/admin/snapshot", Header: header("admin-key")},
+	},
+}
@@ -0,0 +1,27 @@
+package servertest
+
+import (
+	"app/cmd/server"
+	"app/internal/config"
+	"app/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Spec is the OpenAPI document of the api committed to the repository, relative to the root of the module.
+const Spec = "docs/openapi/openapi.json"
+
+// CheckSpec compares the OpenAPI document of the api wired with the default configuration to the file at path,
+// which it writes instead when updating. It fails when a route or a type changed without regenerating the file.
+func CheckSpec(path string, update bool) (err error) {
+	gin.SetMode(gin.ReleaseMode)
+	api, err := server.New(config.Default(), logging.Discard())
+	if err != nil {
+		return
+	}
+	spec, err := api.Spec.JSON()
+	if err != nil {
+		return
+	}
+	return Compare(path, spec, update)
+}
@@ -0,0 +1,71 @@
+// Command snapshot asks a running vehicle api to write its vehicles to disk, or reports the last snapshot.
+//
+//	go run ./cmd/snapshot -addr http://localhost:8080 -api-key <key> [-status]
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", envOr("VEHICLE_API_ADDR", "http://localhost:8080"), "base url of the vehicle api (env VEHICLE_API_ADDR)")
+	apiKey := flag.String("api-key", os.Getenv("VEHICLE_API_KEY"), "api key of a user with the vehicles:admin permission (env VEHICLE_API_KEY)")
+	status := flag.Bool("status", false, "report the last snapshot instead of taking one")
+	timeout := flag.Duration("timeout", time.Minute, "maximum time to wait for the snapshot")
+	flag.Parse()
+
+	// request
+	method := http.MethodPost
+	if *status {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequest(method, *addr+"/api/v1/admin/snapshot", nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if *apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+*apiKey)
+	}
+
+	// process
+	res, err := (&http.Client{Timeout: *timeout}).Do(req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer res.Body.Close()
+	var body struct {
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+		Error   bool            `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", res.Status, err)
+		os.Exit(1)
+	}
+
+	// response
+	var out bytes.Buffer
+	if len(body.Data) > 0 && json.Indent(&out, body.Data, "", "  ") == nil {
+		fmt.Println(out.String())
+	}
+	if res.StatusCode != http.StatusOK {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", res.Status, body.Message)
+		os.Exit(1)
+	}
+}
+
+// envOr returns the value of the environment variable, or def if it is not set.
+func envOr(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return def
+}
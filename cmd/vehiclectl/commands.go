@@ -0,0 +1,391 @@
+package main
+
+import (
+	"app/internal/domain"
+	"app/internal/vehicle/loader"
+	"app/internal/vehicle/saver"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// command is an struct that represents a command of the tool.
+type command struct {
+	// args and help describe the command in the usage.
+	args string
+	help string
+	run  func(ctx context.Context, c *cli, name string, args []string) error
+}
+
+// commandNames are the commands in the order of the usage.
+var commandNames = []string{"list", "get", "filter", "add", "update", "delete", "validate", "convert", "stats"}
+
+// commands are the commands of the tool by name.
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"list":     {args: "", help: "list every vehicle", run: runList},
+		"get":      {args: "<id>", help: "show a vehicle", run: runGet},
+		"filter":   {args: "<criteria>", help: "list the vehicles of -color and -year, -brand, -from and -to, -fuel, or -min-weight and/or -max-weight", run: runFilter},
+		"add":      {args: "[-f file]", help: "add the vehicle or the array of vehicles of a json file, or of the standard input", run: runAdd},
+		"update":   {args: "<id> -max-speed N", help: "update the max speed of a vehicle", run: runUpdate},
+		"delete":   {args: "<id>", help: "delete a vehicle", run: runDelete},
+		"validate": {args: "[-mode strict|lenient]", help: "check the data file and report every problem", run: runValidate},
+		"convert":  {args: "-out file [-out-format F]", help: "write the vehicles to a file of another format, gzip compressed if it ends in .gz", run: runConvert},
+		"stats":    {args: "[-brand B]", help: "compute statistics of the vehicles", run: runStats},
+	}
+}
+
+// parse parses the flags and checks the number of positional arguments of a command.
+func parse(c *cli, name string, fs *flag.FlagSet, args []string, positional ...string) (values []string, err error) {
+	fs.SetOutput(c.errOut)
+	fs.Usage = func() {
+		fmt.Fprintf(c.errOut, "Usage: vehiclectl [flags] %s %s\n", name, commands[name].args)
+		fs.PrintDefaults()
+	}
+	// the positional arguments may come before the flags
+	var rest []string
+	for len(args) > 0 {
+		if err = fs.Parse(args); err != nil {
+			return
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		rest = append(rest, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(rest) != len(positional) {
+		fs.Usage()
+		err = errUsage
+		return
+	}
+	values = rest
+	return
+}
+
+// parseId parses the id of a vehicle.
+func parseId(s string) (id int, err error) {
+	id, err = strconv.Atoi(s)
+	if err != nil || id <= 0 {
+		err = fmt.Errorf("invalid id %q", s)
+	}
+	return
+}
+
+func runList(ctx context.Context, c *cli, name string, args []string) (err error) {
+	if _, err = parse(c, name, flag.NewFlagSet(name, flag.ContinueOnError), args); err != nil {
+		return
+	}
+	sv, err := c.service()
+	if err != nil {
+		return
+	}
+	vehicles, err := sv.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	return c.printVehicles(vehicles)
+}
+
+func runGet(ctx context.Context, c *cli, name string, args []string) (err error) {
+	values, err := parse(c, name, flag.NewFlagSet(name, flag.ContinueOnError), args, "id")
+	if err != nil {
+		return
+	}
+	id, err := parseId(values[0])
+	if err != nil {
+		return
+	}
+	sv, err := c.service()
+	if err != nil {
+		return
+	}
+	vehicle, err := sv.GetById(ctx, id)
+	if err != nil {
+		return
+	}
+	return c.printVehicles([]*domain.Vehicle{vehicle})
+}
+
+func runFilter(ctx context.Context, c *cli, name string, args []string) (err error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	color := fs.String("color", "", "color of the vehicles, with -year")
+	year := fs.Int("year", 0, "fabrication year of the vehicles, with -color")
+	brand := fs.String("brand", "", "brand of the vehicles, with -from and -to")
+	from := fs.Int("from", 0, "first fabrication year, with -brand")
+	to := fs.Int("to", 0, "fabrication year up to which, not included, with -brand")
+	fuel := fs.String("fuel", "", "fuel type of the vehicles")
+	minWeight := fs.Float64("min-weight", math.Inf(-1), "minimum weight, none if not given")
+	maxWeight := fs.Float64("max-weight", math.Inf(1), "maximum weight, none if not given")
+	if _, err = parse(c, name, fs, args); err != nil {
+		return
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	sv, err := c.service()
+	if err != nil {
+		return
+	}
+	var vehicles []*domain.Vehicle
+	switch {
+	case len(set) == 2 && set["color"] && set["year"]:
+		vehicles, err = sv.GetByColorAndYear(ctx, *color, *year)
+	case len(set) == 3 && set["brand"] && set["from"] && set["to"]:
+		vehicles, err = sv.GetByBrandAndPeriod(ctx, *brand, *from, *to)
+	case len(set) == 1 && set["fuel"]:
+		vehicles, err = sv.GetByFuelType(ctx, *fuel)
+	case len(set) == 2 && set["min-weight"] && set["max-weight"],
+		len(set) == 1 && (set["min-weight"] || set["max-weight"]):
+		vehicles, err = sv.GetByWeight(ctx, *minWeight, *maxWeight)
+	default:
+		fs.Usage()
+		err = errUsage
+	}
+	if err != nil {
+		return
+	}
+	return c.printVehicles(vehicles)
+}
+
+func runAdd(ctx context.Context, c *cli, name string, args []string) (err error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	file := fs.String("f", "-", "json file with a vehicle or an array of vehicles, - for the standard input")
+	if _, err = parse(c, name, fs, args); err != nil {
+		return
+	}
+
+	// request
+	var r io.Reader = c.in
+	if *file != "-" {
+		var f *os.File
+		if f, err = os.Open(*file); err != nil {
+			return
+		}
+		defer f.Close()
+		r = f
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return
+	}
+	var in []*loader.VehicleJSON
+	if err = json.Unmarshal(data, &in); err != nil {
+		var one loader.VehicleJSON
+		if e := json.Unmarshal(data, &one); e != nil {
+			err = fmt.Errorf("expected a json vehicle or array of vehicles: %v", err)
+			return
+		}
+		in = []*loader.VehicleJSON{&one}
+	}
+
+	// the same validation as the data files
+	db := make(map[int]*domain.VehicleAttributes, len(in))
+	vehicles := make([]*domain.Vehicle, 0, len(in))
+	for _, vehicleJSON := range in {
+		vehicle := toVehicle(vehicleJSON)
+		db[vehicle.Id] = &vehicle.Attributes
+		vehicles = append(vehicles, vehicle)
+	}
+	if len(db) != len(in) {
+		err = errors.New("the vehicles to add repeat an id")
+		return
+	}
+	if err = loader.Validate(db); err != nil {
+		return
+	}
+
+	// process
+	sv, err := c.serviceWrite()
+	if err != nil {
+		return
+	}
+	if len(vehicles) == 1 {
+		var vehicle *domain.Vehicle
+		if vehicle, err = sv.AddVehicle(ctx, vehicles[0]); err != nil {
+			return
+		}
+		vehicles = []*domain.Vehicle{vehicle}
+	} else if vehicles, err = sv.AddVehicles(ctx, vehicles); err != nil {
+		return
+	}
+	if err = c.save(ctx); err != nil {
+		return
+	}
+	return c.printVehicles(vehicles)
+}
+
+func runUpdate(ctx context.Context, c *cli, name string, args []string) (err error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	maxSpeed := fs.Int("max-speed", -1, "new max speed of the vehicle")
+	values, err := parse(c, name, fs, args, "id")
+	if err != nil {
+		return
+	}
+	id, err := parseId(values[0])
+	if err != nil {
+		return
+	}
+	if *maxSpeed < 0 {
+		fs.Usage()
+		err = errUsage
+		return
+	}
+
+	sv, err := c.serviceWrite()
+	if err != nil {
+		return
+	}
+	vehicle, err := sv.UpdateSpeed(ctx, &domain.Vehicle{Id: id, Attributes: domain.VehicleAttributes{MaxSpeed: *maxSpeed}})
+	if err != nil {
+		return
+	}
+	if err = c.save(ctx); err != nil {
+		return
+	}
+	return c.printVehicles([]*domain.Vehicle{vehicle})
+}
+
+func runDelete(ctx context.Context, c *cli, name string, args []string) (err error) {
+	values, err := parse(c, name, flag.NewFlagSet(name, flag.ContinueOnError), args, "id")
+	if err != nil {
+		return
+	}
+	id, err := parseId(values[0])
+	if err != nil {
+		return
+	}
+	sv, err := c.serviceWrite()
+	if err != nil {
+		return
+	}
+	vehicle, err := sv.DeleteVehicle(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = c.save(ctx); err != nil {
+		return
+	}
+	return c.printVehicles([]*domain.Vehicle{vehicle})
+}
+
+func runValidate(ctx context.Context, c *cli, name string, args []string) (err error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	mode := fs.String("mode", string(loader.ModeStrict), "checks of the records: strict reports every problem, lenient logs them and checks the rest")
+	if _, err = parse(c, name, fs, args); err != nil {
+		return
+	}
+	if c.file == "" {
+		err = errors.New("validate works on a data file, -file is required")
+		return
+	}
+
+	opts, err := c.loaderOptions()
+	if err != nil {
+		return
+	}
+	// -> the problems are reported whatever the mode, the ones a lenient load skips as well
+	var report loader.Report
+	opts.Mode, opts.Report, opts.Logger = loader.Mode(*mode), &report, nil
+	ld, err := loader.NewLoaderVehicle(c.file, opts)
+	if err != nil {
+		return
+	}
+	v, err := ld.Load()
+	if len(report.Problems) > 0 {
+		if e := c.printReport(&report); e != nil {
+			return e
+		}
+		err = fmt.Errorf("%s: %d problems in %d records", c.file, len(report.Problems), report.Records)
+		return
+	}
+	if err != nil {
+		return
+	}
+	if err = loader.Validate(v); err != nil {
+		return
+	}
+	fmt.Fprintf(c.errOut, "%s: %d vehicles, no problems\n", c.file, len(v))
+	return
+}
+
+func runConvert(ctx context.Context, c *cli, name string, args []string) (err error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	out := fs.String("out", "", "file to write")
+	outFormat := fs.String("out-format", "", "format of the file to write: json, csv or ndjson, empty picks it from the extension")
+	if _, err = parse(c, name, fs, args); err != nil {
+		return
+	}
+	if *out == "" {
+		fs.Usage()
+		err = errUsage
+		return
+	}
+
+	sv, err := c.service()
+	if err != nil {
+		return
+	}
+	vehicles, err := sv.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	db := make(map[int]*domain.VehicleAttributes, len(vehicles))
+	for _, vehicle := range vehicles {
+		attributes := vehicle.Attributes
+		db[vehicle.Id] = &attributes
+	}
+	opts, err := c.loaderOptions()
+	if err != nil {
+		return
+	}
+	sa, err := saver.NewSaverVehicle(*out, saver.Options{Format: *outFormat, Delimiter: opts.Delimiter, Columns: opts.Columns})
+	if err != nil {
+		return
+	}
+	if err = sa.Save(db); err != nil {
+		return
+	}
+	fmt.Fprintf(c.errOut, "%s: %d vehicles written\n", *out, len(db))
+	return
+}
+
+func runStats(ctx context.Context, c *cli, name string, args []string) (err error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	brand := fs.String("brand", "", "only the vehicles of the brand")
+	if _, err = parse(c, name, fs, args); err != nil {
+		return
+	}
+	sv, err := c.service()
+	if err != nil {
+		return
+	}
+	vehicles, err := sv.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	if *brand != "" {
+		filtered := vehicles[:0]
+		for _, vehicle := range vehicles {
+			if vehicle.Attributes.Brand == *brand {
+				filtered = append(filtered, vehicle)
+			}
+		}
+		vehicles = filtered
+	}
+	return c.printStats(computeStats(vehicles))
+}
+
+// sortVehicles sorts the vehicles by id.
+func sortVehicles(vehicles []*domain.Vehicle) {
+	sort.Slice(vehicles, func(i, j int) bool { return vehicles[i].Id < vehicles[j].Id })
+}
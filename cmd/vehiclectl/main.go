@@ -0,0 +1,210 @@
+// Command vehiclectl manages the vehicle datasets, directly on a data file or against a running vehicle api.
+//
+//	vehiclectl [flags] <command> [command flags] [args]
+//
+// Run vehiclectl -h for the flags and the commands.
+package main
+
+import (
+	"app/internal/domain"
+	"app/internal/logging"
+	"app/internal/vehicle/loader"
+	"app/internal/vehicle/repository"
+	"app/internal/vehicle/saver"
+	"app/internal/vehicle/service"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// errUsage is returned when a command is misused, after its usage is printed.
+var errUsage = errors.New("usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command of the arguments and returns the exit code: 0 on success, 1 on failure and 2 on misuse.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	c := &cli{in: stdin, out: stdout, errOut: stderr}
+
+	fs := flag.NewFlagSet("vehiclectl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&c.file, "file", os.Getenv("FILE_PATH_VEHICLES_JSON"), "data file to work on (env FILE_PATH_VEHICLES_JSON)")
+	fs.StringVar(&c.server, "server", os.Getenv("VEHICLE_API_ADDR"), "base url of a running vehicle api to work against instead of a file (env VEHICLE_API_ADDR)")
+	fs.StringVar(&c.apiKey, "api-key", os.Getenv("VEHICLE_API_KEY"), "api key sent to the server (env VEHICLE_API_KEY)")
+	fs.StringVar(&c.tenant, "tenant", "", "tenant sent to the server")
+	fs.StringVar(&c.tenantHeader, "tenant-header", "X-Tenant-ID", "header carrying the tenant")
+	fs.StringVar(&c.output, "o", "table", "output: table, json or csv")
+	fs.StringVar(&c.format, "format", "", "format of the data file: json, csv, yaml or ndjson, empty picks it from the extension")
+	fs.StringVar(&c.mode, "mode", string(loader.ModePermissive), "how strictly the data file is checked: permissive, strict or lenient. add, update and delete always check it in strict mode")
+	fs.StringVar(&c.delimiter, "csv-delimiter", ",", "field delimiter of the csv files")
+	fs.StringVar(&c.columns, "csv-columns", "", "comma separated field=column pairs mapping the csv headers")
+	timeout := fs.Duration("timeout", 30*time.Second, "maximum time of the command")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: vehiclectl [flags] <command> [command flags] [args]")
+		fmt.Fprintln(stderr, "\nCommands:")
+		for _, name := range commandNames {
+			fmt.Fprintf(stderr, "  %-28s %s\n", name+" "+commands[name].args, commands[name].help)
+		}
+		fmt.Fprintln(stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "vehiclectl: unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+	if c.output != "table" && c.output != "json" && c.output != "csv" {
+		fmt.Fprintf(stderr, "vehiclectl: unknown output %q\n", c.output)
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if err := cmd.run(ctx, c, fs.Arg(0), fs.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintln(stderr, "vehiclectl:", err)
+		return 1
+	}
+	return 0
+}
+
+// cli is an struct that represents the options and the dataset of an invocation.
+type cli struct {
+	file, server, apiKey, tenant, tenantHeader string
+	output, format, mode, delimiter, columns   string
+
+	in          io.Reader
+	out, errOut io.Writer
+
+	// sv is the service of the dataset, once opened.
+	sv service.ServiceVehicle
+	// sa writes the data file back, once opened for a change.
+	sa saver.SaverVehicle
+}
+
+// loaderOptions returns the options of the loaders of the data files.
+func (c *cli) loaderOptions() (opts loader.Options, err error) {
+	columns, err := loader.ParseColumns(strings.Split(c.columns, ","))
+	if c.columns == "" {
+		columns, err = nil, nil
+	}
+	if err != nil {
+		return
+	}
+	delimiter := []rune(c.delimiter)
+	if len(delimiter) != 1 {
+		err = fmt.Errorf("the csv delimiter %q must be a single character", c.delimiter)
+		return
+	}
+	opts = loader.Options{
+		Format:    c.format,
+		Delimiter: delimiter[0],
+		Columns:   columns,
+		Mode:      loader.Mode(c.mode),
+		Logger:    slog.New(slog.NewTextHandler(c.errOut, nil)),
+	}
+	return
+}
+
+// service returns the service of the dataset: the data file, or the server if one is given.
+func (c *cli) service() (sv service.ServiceVehicle, err error) {
+	return c.open(false)
+}
+
+// serviceWrite returns the service of the dataset for a command that changes it. As a data file is written back
+// whole after the change, it is checked before anything is changed: its format must be writable, and it must load
+// in strict mode with every one of its records, so no record dropped by the load is deleted from the file.
+func (c *cli) serviceWrite() (sv service.ServiceVehicle, err error) {
+	return c.open(true)
+}
+
+// open returns the service of the dataset, checking a data file for a change if write is set.
+func (c *cli) open(write bool) (sv service.ServiceVehicle, err error) {
+	if c.sv != nil {
+		sv = c.sv
+		return
+	}
+
+	switch {
+	case c.server != "":
+		header := http.Header{}
+		if c.apiKey != "" {
+			header.Set("Authorization", "Bearer "+c.apiKey)
+		}
+		if c.tenant != "" {
+			header.Set(c.tenantHeader, c.tenant)
+		}
+		c.sv = service.NewServiceVehicleHTTP(c.server, http.DefaultClient, header)
+	case c.file != "":
+		var opts loader.Options
+		if opts, err = c.loaderOptions(); err != nil {
+			return
+		}
+		var report loader.Report
+		if write {
+			if c.sa, err = saver.NewSaverVehicle(c.file, saver.Options{Format: c.format, Delimiter: opts.Delimiter, Columns: opts.Columns}); err != nil {
+				return
+			}
+			opts.Mode, opts.Report = loader.ModeStrict, &report
+		}
+		var ld loader.LoaderVehicle
+		if ld, err = loader.NewLoaderVehicle(c.file, opts); err != nil {
+			return
+		}
+		var db map[int]*domain.VehicleAttributes
+		if db, err = ld.Load(); err != nil {
+			return
+		}
+		if write && report.Loaded != report.Records {
+			err = fmt.Errorf("%s: %d of %d records loaded, writing it back would delete the rest", c.file, report.Loaded, report.Records)
+			return
+		}
+		rp := repository.NewRepositoryVehicleInMemory(db, logging.Discard())
+		c.sv = service.NewServiceVehicleDefault(rp, logging.Discard())
+	default:
+		err = errors.New("either -file or -server is required")
+		return
+	}
+	sv = c.sv
+	return
+}
+
+// save writes the vehicles back to the data file after a change, once opened with serviceWrite.
+// The server persists its own changes.
+func (c *cli) save(ctx context.Context) (err error) {
+	if c.server != "" {
+		return
+	}
+	vehicles, err := c.sv.GetAll(ctx)
+	if err != nil && !errors.Is(err, service.ErrServiceVehicleNotFound) {
+		return
+	}
+	db := make(map[int]*domain.VehicleAttributes, len(vehicles))
+	for _, vehicle := range vehicles {
+		attributes := vehicle.Attributes
+		db[vehicle.Id] = &attributes
+	}
+	return c.sa.Save(db)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"app/internal/domain"
+	"app/internal/vehicle/loader"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+func toVehicleJSON(id int, a *domain.VehicleAttributes) *loader.VehicleJSON {
+	return &loader.VehicleJSON{
+		ID:           id,
+		Brand:        a.Brand,
+		Model:        a.Model,
+		Registration: a.Registration,
+		Year:         a.Year,
+		Color:        a.Color,
+		MaxSpeed:     a.MaxSpeed,
+		FuelType:     a.FuelType,
+		Transmission: a.Transmission,
+		Passengers:   a.Passengers,
+		Height:       a.Height,
+		Width:        a.Width,
+		Weight:       a.Weight,
+	}
+}
+
+func toVehicle(v *loader.VehicleJSON) *domain.Vehicle {
+	return &domain.Vehicle{
+		Id: v.ID,
+		Attributes: domain.VehicleAttributes{
+			Brand:        v.Brand,
+			Model:        v.Model,
+			Registration: v.Registration,
+			Year:         v.Year,
+			Color:        v.Color,
+			MaxSpeed:     v.MaxSpeed,
+			FuelType:     v.FuelType,
+			Transmission: v.Transmission,
+			Passengers:   v.Passengers,
+			Height:       v.Height,
+			Width:        v.Width,
+			Weight:       v.Weight,
+		},
+	}
+}
+
+// formatFloat returns the shortest representation of a float.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// printRows prints a header and its rows as a table or as csv.
+func (c *cli) printRows(header []string, rows [][]string) (err error) {
+	if c.output == "csv" {
+		w := csv.NewWriter(c.out)
+		w.Write(header)
+		w.WriteAll(rows)
+		return w.Error()
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(header, "\t")))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// printJSON prints a value as indented json.
+func (c *cli) printJSON(v any) error {
+	enc := json.NewEncoder(c.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printVehicles prints the vehicles sorted by id, the json output being a data file of the json loader.
+func (c *cli) printVehicles(vehicles []*domain.Vehicle) error {
+	sortVehicles(vehicles)
+	if c.output == "json" {
+		out := make([]*loader.VehicleJSON, 0, len(vehicles))
+		for _, vehicle := range vehicles {
+			out = append(out, toVehicleJSON(vehicle.Id, &vehicle.Attributes))
+		}
+		return c.printJSON(out)
+	}
+
+	rows := make([][]string, 0, len(vehicles))
+	for _, v := range vehicles {
+		a := v.Attributes
+		rows = append(rows, []string{
+			strconv.Itoa(v.Id), a.Brand, a.Model, a.Registration, strconv.Itoa(a.Year), a.Color, strconv.Itoa(a.MaxSpeed),
+			a.FuelType, a.Transmission, strconv.Itoa(a.Passengers), formatFloat(a.Height), formatFloat(a.Width), formatFloat(a.Weight),
+		})
+	}
+	return c.printRows(loader.Fields, rows)
+}
+
+// printReport prints the problems of a data file.
+func (c *cli) printReport(report *loader.Report) error {
+	if c.output == "json" {
+		return c.printJSON(report)
+	}
+
+	rows := make([][]string, 0, len(report.Problems))
+	for _, p := range report.Problems {
+		rows = append(rows, []string{strconv.Itoa(p.Record), strconv.Itoa(p.Line), strconv.Itoa(p.Column), p.Message})
+	}
+	return c.printRows([]string{"record", "line", "column", "problem"}, rows)
+}
+
+// printStats prints the statistics of the vehicles.
+func (c *cli) printStats(st *stats) error {
+	if c.output == "json" {
+		return c.printJSON(st)
+	}
+	return c.printRows([]string{"metric", "name", "value"}, st.rows())
+}
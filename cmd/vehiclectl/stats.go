@@ -0,0 +1,124 @@
+package main
+
+import (
+	"app/internal/domain"
+	"sort"
+	"strconv"
+)
+
+// stats is an struct that represents the statistics of a set of vehicles.
+type stats struct {
+	Vehicles int     `json:"vehicles"`
+	MaxSpeed summary `json:"max_speed"`
+	Year     summary `json:"year"`
+	Weight   summary `json:"weight"`
+	// Brands and FuelTypes are the vehicles of every brand and fuel type, the most common first.
+	Brands    []count `json:"brands"`
+	FuelTypes []count `json:"fuel_types"`
+}
+
+// summary is an struct that represents the range and the average of a value.
+type summary struct {
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Average float64 `json:"average"`
+}
+
+// count is an struct that represents the number of vehicles of a group and their average max speed.
+type count struct {
+	Name         string  `json:"name"`
+	Vehicles     int     `json:"vehicles"`
+	AverageSpeed float64 `json:"average_speed"`
+}
+
+// computeStats returns the statistics of the vehicles.
+func computeStats(vehicles []*domain.Vehicle) (st *stats) {
+	st = &stats{Vehicles: len(vehicles), Brands: []count{}, FuelTypes: []count{}}
+	if len(vehicles) == 0 {
+		return
+	}
+
+	var speed, year, weight summarizer
+	brands, fuelTypes := make(map[string]*count), make(map[string]*count)
+	for _, vehicle := range vehicles {
+		a := vehicle.Attributes
+		speed.add(float64(a.MaxSpeed))
+		year.add(float64(a.Year))
+		weight.add(a.Weight)
+		group(brands, a.Brand, a.MaxSpeed)
+		group(fuelTypes, a.FuelType, a.MaxSpeed)
+	}
+	st.MaxSpeed, st.Year, st.Weight = speed.summary(), year.summary(), weight.summary()
+	st.Brands, st.FuelTypes = sorted(brands), sorted(fuelTypes)
+	return
+}
+
+// summarizer is an struct that represents a summary being computed.
+type summarizer struct {
+	n             int
+	min, max, sum float64
+}
+
+func (s *summarizer) add(v float64) {
+	if s.n == 0 || v < s.min {
+		s.min = v
+	}
+	if s.n == 0 || v > s.max {
+		s.max = v
+	}
+	s.n++
+	s.sum += v
+}
+
+func (s *summarizer) summary() summary {
+	return summary{Min: s.min, Max: s.max, Average: s.sum / float64(s.n)}
+}
+
+// group adds a vehicle to the count of its group, keeping the sum of the speeds as the average until sorted.
+func group(groups map[string]*count, name string, speed int) {
+	g, ok := groups[name]
+	if !ok {
+		g = &count{Name: name}
+		groups[name] = g
+	}
+	g.Vehicles++
+	g.AverageSpeed += float64(speed)
+}
+
+// sorted returns the groups with their average speed, the most common first.
+func sorted(groups map[string]*count) (counts []count) {
+	counts = make([]count, 0, len(groups))
+	for _, g := range groups {
+		g.AverageSpeed /= float64(g.Vehicles)
+		counts = append(counts, *g)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Vehicles != counts[j].Vehicles {
+			return counts[i].Vehicles > counts[j].Vehicles
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	return
+}
+
+// rows returns the statistics as metric, name and value rows.
+func (st *stats) rows() (rows [][]string) {
+	rows = append(rows, []string{"vehicles", "", strconv.Itoa(st.Vehicles)})
+	for _, s := range []struct {
+		metric string
+		summary
+	}{{"max_speed", st.MaxSpeed}, {"year", st.Year}, {"weight", st.Weight}} {
+		rows = append(rows,
+			[]string{s.metric, "min", formatFloat(s.Min)},
+			[]string{s.metric, "max", formatFloat(s.Max)},
+			[]string{s.metric, "average", strconv.FormatFloat(s.Average, 'f', 2, 64)},
+		)
+	}
+	for _, g := range st.Brands {
+		rows = append(rows, []string{"brand", g.Name, strconv.Itoa(g.Vehicles)})
+	}
+	for _, g := range st.FuelTypes {
+		rows = append(rows, []string{"fuel_type", g.Name, strconv.Itoa(g.Vehicles)})
+	}
+	return
+}
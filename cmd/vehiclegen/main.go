@@ -0,0 +1,166 @@
+// Command vehiclegen writes synthetic fleets for load and scale tests, in any of the formats the loaders read.
+// The same flags and seed write the same vehicles.
+//
+//	go run ./cmd/vehiclegen -n 100000 -seed 7 -out docs/db/json/vehicles_100k.json.gz
+//	go run ./cmd/vehiclegen -n 1000 -anomalies duplicate_id=0.01,invalid_max_speed=0.02 -format ndjson
+package main
+
+import (
+	"app/internal/vehicle/generator"
+	"app/internal/vehicle/loader"
+	"app/internal/vehicle/saver"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run writes the fleet of the arguments and returns the exit code: 0 on success, 1 on failure and 2 on misuse.
+func run(args []string, stdout, stderr io.Writer) int {
+	cfg := generator.Default()
+
+	fs := flag.NewFlagSet("vehiclegen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.IntVar(&cfg.Count, "n", cfg.Count, "number of vehicles")
+	fs.Int64Var(&cfg.Seed, "seed", cfg.Seed, "seed of the random vehicles")
+	fs.IntVar(&cfg.StartId, "start-id", cfg.StartId, "id of the first vehicle")
+	out := fs.String("out", "-", "file to write, - for the standard output; a .gz extension compresses it")
+	format := fs.String("format", "", "format: json, csv or ndjson, empty picks it from the extension of -out, json for the standard output")
+	delimiter := fs.String("csv-delimiter", ",", "field delimiter of the csv files")
+	columns := fs.String("csv-columns", "", "comma separated field=column pairs mapping the csv headers")
+	brands := fs.String("brands", "", "comma separated brand=weight pairs, the whole catalog with equal weights if empty")
+	fuels := fs.String("fuels", "", "comma separated fuel_type=weight pairs, "+strings.Join(generator.FuelTypes, ", ")+" with equal weights if empty")
+	year := fs.String("year", "normal:2000:9:1957:2013", "distribution of the years: uniform:min:max or normal:mean:stddev:min:max")
+	weight := fs.String("weight", "uniform:1:300", "distribution of the weights: uniform:min:max or normal:mean:stddev:min:max")
+	anomalies := fs.String("anomalies", "", "comma separated anomaly=rate pairs of "+anomalyNames())
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	// configuration
+	if err := parseConfig(cfg, *brands, *fuels, *year, *weight, *anomalies); err != nil {
+		fmt.Fprintln(stderr, "vehiclegen:", err)
+		return 2
+	}
+	g, err := generator.NewGenerator(*cfg)
+	if err != nil {
+		fmt.Fprintln(stderr, "vehiclegen:", err)
+		return 2
+	}
+	opts, err := encoderOptions(*format, *out, *delimiter, *columns)
+	if err != nil {
+		fmt.Fprintln(stderr, "vehiclegen:", err)
+		return 2
+	}
+
+	// write
+	st, err := write(g, *out, stdout, opts)
+	if err != nil {
+		fmt.Fprintln(stderr, "vehiclegen:", err)
+		return 1
+	}
+	fmt.Fprintf(stderr, "vehiclegen: %d vehicles written to %s, seed %d, anomalies: %s\n", st.Vehicles, *out, cfg.Seed, st)
+	return 0
+}
+
+// parseConfig sets the weights, distributions and anomaly rates of the flags.
+func parseConfig(cfg *generator.Config, brands, fuels, year, weight, anomalies string) (err error) {
+	if cfg.Brands, err = generator.ParseWeights(brands); err != nil {
+		return
+	}
+	if cfg.FuelTypes, err = generator.ParseWeights(fuels); err != nil {
+		return
+	}
+	if cfg.Year, err = generator.ParseDistribution(year); err != nil {
+		return
+	}
+	if cfg.Weight, err = generator.ParseDistribution(weight); err != nil {
+		return
+	}
+	cfg.Rates, err = generator.ParseRates(anomalies)
+	return
+}
+
+// encoderOptions returns the options of the encoder of the output.
+func encoderOptions(format, out, delimiter, columns string) (opts saver.Options, err error) {
+	opts.Format = format
+	if opts.Format == "" {
+		opts.Format = loader.FormatJSON
+		if out != "-" {
+			if opts.Format, err = loader.FormatOf(out); err != nil {
+				return
+			}
+		}
+	}
+	d := []rune(delimiter)
+	if len(d) != 1 {
+		err = fmt.Errorf("the csv delimiter %q must be a single character", delimiter)
+		return
+	}
+	opts.Delimiter = d[0]
+	if columns != "" {
+		opts.Columns, err = loader.ParseColumns(strings.Split(columns, ","))
+	}
+	return
+}
+
+// write writes the vehicles of the generator to the output, compressed if its extension is .gz.
+// The vehicles are streamed, so the size of the fleet is only bounded by the disk.
+func write(g *generator.Generator, out string, stdout io.Writer, opts saver.Options) (st generator.Stats, err error) {
+	w := stdout
+	if out != "-" {
+		var f *os.File
+		if f, err = os.Create(out); err != nil {
+			return
+		}
+		defer func() {
+			if e := f.Close(); err == nil {
+				err = e
+			}
+		}()
+		w = f
+	}
+
+	bw := bufio.NewWriter(w)
+	w = bw
+	var gz *gzip.Writer
+	if strings.HasSuffix(out, ".gz") {
+		gz = gzip.NewWriter(bw)
+		w = gz
+	}
+
+	enc, err := saver.NewEncoder(w, opts.Format, opts)
+	if err != nil {
+		return
+	}
+	if st, err = g.Write(enc); err != nil {
+		return
+	}
+	if gz != nil {
+		if err = gz.Close(); err != nil {
+			return
+		}
+	}
+	err = bw.Flush()
+	return
+}
+
+// anomalyNames returns the names of the anomalies.
+func anomalyNames() string {
+	names := make([]string, len(generator.Anomalies))
+	for i, a := range generator.Anomalies {
+		names[i] = string(a)
+	}
+	return strings.Join(names, ", ")
+}
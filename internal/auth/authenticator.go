@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// Permission is a permission over the vehicle resources.
+type Permission string
+
+const (
+	// PermissionVehiclesRead allows to query vehicles.
+	PermissionVehiclesRead Permission = "vehicles:read"
+	// PermissionVehiclesWrite allows to create and update vehicles.
+	PermissionVehiclesWrite Permission = "vehicles:write"
+	// PermissionVehiclesDelete allows to delete vehicles.
+	PermissionVehiclesDelete Permission = "vehicles:delete"
+	// PermissionVehiclesAdmin grants every other permission.
+	PermissionVehiclesAdmin Permission = "vehicles:admin"
+)
+
+// Principal is an struct that represents an authenticated user.
+type Principal struct {
+	// Name is the name of the user.
+	Name string
+	// Roles are the roles assigned to the user.
+	Roles []string
+	// Permissions are the permissions granted by the roles of the user.
+	Permissions []Permission
+	// Brands restricts the user to vehicles of these brands. Empty means no restriction.
+	Brands []string
+	// Tenant binds the user to the fleet of a tenant. Empty means the user is not bound.
+	Tenant string
+}
+
+// HasPermission returns true if the principal was granted the permission.
+func (p *Principal) HasPermission(perm Permission) bool {
+	for _, granted := range p.Permissions {
+		if granted == perm || granted == PermissionVehiclesAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// HasBrand returns true if the principal is allowed to access vehicles of the brand.
+// A nil principal is not restricted, as it means authorization is disabled.
+func (p *Principal) HasBrand(brand string) bool {
+	if p == nil || len(p.Brands) == 0 {
+		return true
+	}
+	for _, allowed := range p.Brands {
+		if allowed == brand {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator is the interface that wraps the basic methods for an authenticator.
+type Authenticator interface {
+	// Authenticate returns the principal that owns the token.
+	Authenticate(token string) (p *Principal, err error)
+}
+
+var (
+	// ErrAuthUnauthenticated is returned when the token does not belong to any user.
+	ErrAuthUnauthenticated = errors.New("auth: unauthenticated")
+
+	// ErrAuthPolicyInvalid is returned when the policy is malformed.
+	ErrAuthPolicyInvalid = errors.New("auth: invalid policy")
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries the principal.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// PrincipalFromContext returns the principal carried by ctx, or nil if there is none.
+func PrincipalFromContext(ctx context.Context) (p *Principal) {
+	p, _ = ctx.Value(contextKey{}).(*Principal)
+	return
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// PolicyJSON is an struct that represents a policy file.
+type PolicyJSON struct {
+	// Roles maps every role to the permissions it grants.
+	Roles map[string][]Permission `json:"roles"`
+	// Users are the users allowed to call the api.
+	Users []UserJSON `json:"users"`
+}
+
+// UserJSON is an struct that represents a user of a policy file.
+type UserJSON struct {
+	Name   string   `json:"name"`
+	APIKey string   `json:"api_key"`
+	Roles  []string `json:"roles"`
+	Brands []string `json:"brands"`
+	Tenant string   `json:"tenant"`
+}
+
+// LoadPolicyJSON reads a policy file.
+func LoadPolicyJSON(path string) (p *PolicyJSON, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrAuthPolicyInvalid, err)
+		return
+	}
+	defer f.Close()
+
+	p = &PolicyJSON{}
+	err = json.NewDecoder(f).Decode(p)
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrAuthPolicyInvalid, err)
+		return
+	}
+	return
+}
+
+// NewAuthenticatorPolicy returns a new instance of an authenticator backed by a policy.
+func NewAuthenticatorPolicy(policy *PolicyJSON) (a *AuthenticatorPolicy, err error) {
+	a = &AuthenticatorPolicy{principals: make(map[string]*Principal)}
+	for _, user := range policy.Users {
+		if user.APIKey == "" {
+			err = fmt.Errorf("%w. user %q has no api key", ErrAuthPolicyInvalid, user.Name)
+			return
+		}
+		if _, ok := a.principals[user.APIKey]; ok {
+			err = fmt.Errorf("%w. user %q reuses an api key", ErrAuthPolicyInvalid, user.Name)
+			return
+		}
+
+		principal := &Principal{Name: user.Name, Roles: user.Roles, Brands: user.Brands, Tenant: user.Tenant}
+		for _, role := range user.Roles {
+			perms, ok := policy.Roles[role]
+			if !ok {
+				err = fmt.Errorf("%w. user %q has unknown role %q", ErrAuthPolicyInvalid, user.Name, role)
+				return
+			}
+			principal.Permissions = append(principal.Permissions, perms...)
+		}
+		a.principals[user.APIKey] = principal
+	}
+	return
+}
+
+// AuthenticatorPolicy is an struct that implements the Authenticator interface.
+type AuthenticatorPolicy struct {
+	// principals maps every api key to its principal.
+	principals map[string]*Principal
+}
+
+// Authenticate returns the principal that owns the token.
+func (a *AuthenticatorPolicy) Authenticate(token string) (p *Principal, err error) {
+	p, ok := a.principals[token]
+	if !ok {
+		err = ErrAuthUnauthenticated
+		return
+	}
+	return
+}
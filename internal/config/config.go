@@ -0,0 +1,238 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrConfigInvalid is returned when the configuration can not be loaded or is not valid.
+	ErrConfigInvalid = errors.New("config: invalid configuration")
+)
+
+// Config is an struct that represents the configuration of the vehicle api.
+type Config struct {
+	// Server is the configuration of the http server.
+	Server Server `yaml:"server" toml:"server"`
+	// Timeouts are the deadlines of the vehicle routes.
+	Timeouts Timeouts `yaml:"timeouts" toml:"timeouts"`
+	// Repository is the configuration of the vehicle repository.
+	Repository Repository `yaml:"repository" toml:"repository"`
+	// Loader is the source of the vehicles loaded at startup.
+	Loader Loader `yaml:"loader" toml:"loader"`
+	// Snapshot is where and how often the vehicles are written back to disk.
+	Snapshot Snapshot `yaml:"snapshot" toml:"snapshot"`
+	// Auth is the configuration of the authorization.
+	Auth Auth `yaml:"auth" toml:"auth"`
+	// Tenant is the configuration of the tenant resolution.
+	Tenant Tenant `yaml:"tenant" toml:"tenant"`
+	// RateLimit is the configuration of the rate limits and quotas.
+	RateLimit RateLimit `yaml:"rate_limit" toml:"rate_limit"`
+	// Idempotency is the configuration of the idempotency keys.
+	Idempotency Idempotency `yaml:"idempotency" toml:"idempotency"`
+	// GraphQL is the configuration of the GraphQL endpoint.
+	GraphQL GraphQL `yaml:"graphql" toml:"graphql"`
+	// Pagination is the configuration of the pages of the collections of the api v2.
+	Pagination Pagination `yaml:"pagination" toml:"pagination"`
+	// GRPC is the configuration of the gRPC server.
+	GRPC GRPC `yaml:"grpc" toml:"grpc"`
+	// Compression is the configuration of the compression of the responses.
+	Compression Compression `yaml:"compression" toml:"compression"`
+	// Cache is the configuration of the http caching of the read routes.
+	Cache Cache `yaml:"cache" toml:"cache"`
+	// Log is the configuration of the logger.
+	Log Log `yaml:"log" toml:"log"`
+	// Tracing is the configuration of the tracing.
+	Tracing Tracing `yaml:"tracing" toml:"tracing"`
+	// Features are the toggles of the optional features.
+	Features Features `yaml:"features" toml:"features"`
+}
+
+// Server is an struct that represents the configuration of the http server.
+type Server struct {
+	Addr              string        `yaml:"addr" toml:"addr"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" toml:"read_header_timeout"`
+	// ShutdownDelay is the time the process keeps serving once it is not ready, before draining the connections.
+	ShutdownDelay time.Duration `yaml:"shutdown_delay" toml:"shutdown_delay"`
+	// ShutdownTimeout is the maximum time to drain the connections.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+	// MaxBodyBytes is the maximum size of the body of a request, answered with 413 beyond it. Zero does not limit it.
+	MaxBodyBytes int `yaml:"max_body_bytes" toml:"max_body_bytes"`
+}
+
+// Timeouts is an struct that represents the deadlines of the vehicle routes. Zero disables a deadline.
+type Timeouts struct {
+	Read  time.Duration `yaml:"read" toml:"read"`
+	Write time.Duration `yaml:"write" toml:"write"`
+	Batch time.Duration `yaml:"batch" toml:"batch"`
+}
+
+// Repository is an struct that represents the configuration of the vehicle repository.
+type Repository struct {
+	// Backend is the storage of the vehicles: memory.
+	Backend string `yaml:"backend" toml:"backend"`
+}
+
+// Loader is an struct that represents the source of the vehicles loaded at startup.
+type Loader struct {
+	// Path is the data file of the default tenant.
+	Path string `yaml:"path" toml:"path"`
+	// TenantsDir is the directory holding a <tenant>.<extension> data file per tenant.
+	TenantsDir string `yaml:"tenants_dir" toml:"tenants_dir"`
+	// Format is the format of the data files: json, csv, yaml or ndjson. Empty picks it from their extension.
+	Format string `yaml:"format" toml:"format"`
+	// Mode is how strictly the records are checked: permissive, strict or lenient.
+	Mode string `yaml:"mode" toml:"mode"`
+	// CSVDelimiter is the field delimiter of the csv files.
+	CSVDelimiter string `yaml:"csv_delimiter" toml:"csv_delimiter"`
+	// CSVColumns maps the fields of the vehicles to the headers of the csv columns, as field=column pairs.
+	CSVColumns []string `yaml:"csv_columns" toml:"csv_columns"`
+	// WatchInterval is how often the data files are checked for changes to reload them. Zero disables it.
+	WatchInterval time.Duration `yaml:"watch_interval" toml:"watch_interval"`
+	// ReloadPolicy is what happens to the changes made through the api on a reload: discard or merge.
+	ReloadPolicy string `yaml:"reload_policy" toml:"reload_policy"`
+}
+
+// Snapshot is an struct that represents where and how often the vehicles are written back to disk.
+type Snapshot struct {
+	// Dir is the directory a <tenant>.<format> file is written to for every tenant. Empty disables the snapshots.
+	Dir string `yaml:"dir" toml:"dir"`
+	// Format is the format of the files: json, csv or ndjson.
+	Format string `yaml:"format" toml:"format"`
+	// Gzip compresses the files.
+	Gzip bool `yaml:"gzip" toml:"gzip"`
+	// Interval is how often the vehicles are written if they changed, and on shutdown. Zero disables the autosave.
+	Interval time.Duration `yaml:"interval" toml:"interval"`
+}
+
+// Auth is an struct that represents the configuration of the authorization.
+type Auth struct {
+	// PolicyPath is the policy file. Empty disables the authorization.
+	PolicyPath string `yaml:"policy_path" toml:"policy_path"`
+}
+
+// Tenant is an struct that represents the configuration of the tenant resolution.
+type Tenant struct {
+	Header string `yaml:"header" toml:"header"`
+	Domain string `yaml:"domain" toml:"domain"`
+}
+
+// RateLimit is an struct that represents the configuration of the rate limits and quotas.
+type RateLimit struct {
+	// Key is what the requests are limited by: api_key, ip or tenant.
+	Key        string  `yaml:"key" toml:"key"`
+	ReadRPS    float64 `yaml:"read_rps" toml:"read_rps"`
+	ReadBurst  int     `yaml:"read_burst" toml:"read_burst"`
+	WriteRPS   float64 `yaml:"write_rps" toml:"write_rps"`
+	WriteBurst int     `yaml:"write_burst" toml:"write_burst"`
+	// QuotaDaily is the maximum number of requests of a key per day. Zero only counts them.
+	QuotaDaily int `yaml:"quota_daily" toml:"quota_daily"`
+}
+
+// Idempotency is an struct that represents the configuration of the idempotency keys.
+type Idempotency struct {
+	// Window is the time a response is replayed for retries with the same key.
+	Window time.Duration `yaml:"window" toml:"window"`
+}
+
+// GraphQL is an struct that represents the configuration of the GraphQL endpoint.
+type GraphQL struct {
+	// MaxDepth is the maximum nesting of the fields of an operation. Zero disables the limit.
+	MaxDepth int `yaml:"max_depth" toml:"max_depth"`
+	// MaxComplexity is the maximum number of fields an operation may resolve. Zero disables the limit.
+	MaxComplexity   int `yaml:"max_complexity" toml:"max_complexity"`
+	DefaultPageSize int `yaml:"default_page_size" toml:"default_page_size"`
+	MaxPageSize     int `yaml:"max_page_size" toml:"max_page_size"`
+	// SubscriptionBuffer is the number of changes a subscription buffers before it misses them.
+	SubscriptionBuffer int `yaml:"subscription_buffer" toml:"subscription_buffer"`
+}
+
+// Pagination is an struct that represents the configuration of the pages of the collections of the api v2.
+type Pagination struct {
+	// DefaultLimit is the page size when the request does not set one.
+	DefaultLimit int `yaml:"default_limit" toml:"default_limit"`
+	MaxLimit     int `yaml:"max_limit" toml:"max_limit"`
+}
+
+// GRPC is an struct that represents the configuration of the gRPC server.
+type GRPC struct {
+	// Addr is the address the gRPC server listens on. The address of the http server shares its port between both.
+	Addr string `yaml:"addr" toml:"addr"`
+}
+
+// Compression is an struct that represents the configuration of the compression of the responses.
+type Compression struct {
+	// Encodings are the content encodings offered, in order of preference: zstd, br and gzip.
+	Encodings []string `yaml:"encodings" toml:"encodings"`
+	// Level is the compression level: fastest, default or best.
+	Level string `yaml:"level" toml:"level"`
+	// MinSize is the size in bytes from which a response is compressed.
+	MinSize int `yaml:"min_size" toml:"min_size"`
+}
+
+// Cache is an struct that represents the configuration of the http caching of the read routes.
+type Cache struct {
+	// MaxAge is the time the clients may reuse a response without revalidating it. Zero makes them revalidate it every time.
+	MaxAge time.Duration `yaml:"max_age" toml:"max_age"`
+	// Size is the maximum number of responses of the response cache.
+	Size int `yaml:"size" toml:"size"`
+}
+
+// Log is an struct that represents the configuration of the logger.
+type Log struct {
+	Format string   `yaml:"format" toml:"format"`
+	Level  string   `yaml:"level" toml:"level"`
+	Redact []string `yaml:"redact" toml:"redact"`
+}
+
+// Tracing is an struct that represents the configuration of the tracing.
+type Tracing struct {
+	// Exporter is where the spans are exported: stdout, file, or empty to only propagate the trace context.
+	Exporter string `yaml:"exporter" toml:"exporter"`
+	File     string `yaml:"file" toml:"file"`
+}
+
+// Features is an struct that represents the toggles of the optional features.
+type Features struct {
+	Metrics     bool `yaml:"metrics" toml:"metrics"`
+	RateLimit   bool `yaml:"rate_limit" toml:"rate_limit"`
+	Idempotency bool `yaml:"idempotency" toml:"idempotency"`
+	// GraphQL serves the GraphQL endpoint.
+	GraphQL bool `yaml:"graphql" toml:"graphql"`
+	// GRPC serves the gRPC api.
+	GRPC bool `yaml:"grpc" toml:"grpc"`
+	// Compression compresses the responses in the encoding negotiated with the clients.
+	Compression bool `yaml:"compression" toml:"compression"`
+	// ResponseCache stores the responses of the read routes in process until the vehicles change.
+	ResponseCache bool `yaml:"response_cache" toml:"response_cache"`
+	// ValidateRequests rejects the requests that do not match the OpenAPI document before the handlers run.
+	ValidateRequests bool `yaml:"validate_requests" toml:"validate_requests"`
+	// ValidateResponses reports the responses that do not match the OpenAPI document, meant for the tests.
+	ValidateResponses bool `yaml:"validate_responses" toml:"validate_responses"`
+}
+
+// Default returns the default configuration.
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Addr:              "localhost:8080",
+			ReadHeaderTimeout: 10 * time.Second,
+			ShutdownTimeout:   15 * time.Second,
+			MaxBodyBytes:      1 << 20,
+		},
+		Timeouts:    Timeouts{Read: 5 * time.Second, Write: 5 * time.Second, Batch: 30 * time.Second},
+		Repository:  Repository{Backend: "memory"},
+		Loader:      Loader{Mode: "permissive", CSVDelimiter: ",", WatchInterval: 5 * time.Second, ReloadPolicy: "discard"},
+		Snapshot:    Snapshot{Format: "json"},
+		Tenant:      Tenant{Header: "X-Tenant-ID"},
+		RateLimit:   RateLimit{Key: "api_key"},
+		Idempotency: Idempotency{Window: 24 * time.Hour},
+		GraphQL:     GraphQL{MaxDepth: 8, MaxComplexity: 1000, DefaultPageSize: 20, MaxPageSize: 100, SubscriptionBuffer: 64},
+		Pagination:  Pagination{DefaultLimit: 20, MaxLimit: 100},
+		GRPC:        GRPC{Addr: "localhost:9090"},
+		Compression: Compression{Encodings: []string{"zstd", "br", "gzip"}, Level: "default", MinSize: 1024},
+		Cache:       Cache{Size: 1000},
+		Log:         Log{Format: "text", Level: "info"},
+		Features:    Features{Metrics: true, RateLimit: true, Idempotency: true, GraphQL: true, GRPC: true, Compression: true, ValidateRequests: true},
+	}
+}
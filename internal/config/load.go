@@ -0,0 +1,227 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvFile is the environment variable holding the path of the configuration file.
+const EnvFile = "CONFIG_FILE"
+
+// binding is an struct that binds a field of the configuration to its environment variable and flag.
+type binding struct {
+	flag  string
+	env   string
+	usage string
+	// ptr points to the field: *string, *int, *float64, *bool, *time.Duration or *[]string.
+	ptr any
+}
+
+// bindings returns the bindings of every field of the configuration.
+func (c *Config) bindings() []binding {
+	return []binding{
+		{"server.addr", "SERVER_ADDR", "address the server listens on", &c.Server.Addr},
+		{"server.read-header-timeout", "SERVER_READ_HEADER_TIMEOUT", "maximum time to read the request headers", &c.Server.ReadHeaderTimeout},
+		{"server.shutdown-delay", "SHUTDOWN_DELAY", "time serving while not ready before draining the connections", &c.Server.ShutdownDelay},
+		{"server.shutdown-timeout", "SHUTDOWN_TIMEOUT", "maximum time to drain the connections", &c.Server.ShutdownTimeout},
+		{"server.max-body-bytes", "SERVER_MAX_BODY_BYTES", "maximum size of the request bodies, 0 does not limit it", &c.Server.MaxBodyBytes},
+		{"timeouts.read", "TIMEOUT_READ", "deadline of the read routes", &c.Timeouts.Read},
+		{"timeouts.write", "TIMEOUT_WRITE", "deadline of the write routes", &c.Timeouts.Write},
+		{"timeouts.batch", "TIMEOUT_BATCH", "deadline of the batch route", &c.Timeouts.Batch},
+		{"repository.backend", "REPOSITORY_BACKEND", "storage of the vehicles: memory", &c.Repository.Backend},
+		{"loader.path", "FILE_PATH_VEHICLES_JSON", "data file of the default tenant", &c.Loader.Path},
+		{"loader.tenants-dir", "DIR_PATH_TENANTS_VEHICLES_JSON", "directory with a data file per tenant", &c.Loader.TenantsDir},
+		{"loader.format", "LOADER_FORMAT", "format of the data files: json, csv, yaml or ndjson, empty picks it from the extension", &c.Loader.Format},
+		{"loader.mode", "LOADER_MODE", "how strictly the records are checked: permissive, strict or lenient", &c.Loader.Mode},
+		{"loader.csv-delimiter", "LOADER_CSV_DELIMITER", "field delimiter of the csv files", &c.Loader.CSVDelimiter},
+		{"loader.csv-columns", "LOADER_CSV_COLUMNS", "comma separated field=column pairs mapping the csv headers", &c.Loader.CSVColumns},
+		{"loader.watch-interval", "LOADER_WATCH_INTERVAL", "how often the data files are checked for changes, zero disables it", &c.Loader.WatchInterval},
+		{"loader.reload-policy", "LOADER_RELOAD_POLICY", "changes made through the api on a reload: discard or merge", &c.Loader.ReloadPolicy},
+		{"snapshot.dir", "SNAPSHOT_DIR", "directory the snapshots are written to, empty disables them", &c.Snapshot.Dir},
+		{"snapshot.format", "SNAPSHOT_FORMAT", "format of the snapshots: json, csv or ndjson", &c.Snapshot.Format},
+		{"snapshot.gzip", "SNAPSHOT_GZIP", "compress the snapshots", &c.Snapshot.Gzip},
+		{"snapshot.interval", "SNAPSHOT_INTERVAL", "how often the changed vehicles are written, and on shutdown; zero disables it", &c.Snapshot.Interval},
+		{"auth.policy-path", "FILE_PATH_AUTH_POLICY", "authorization policy file, empty disables authorization", &c.Auth.PolicyPath},
+		{"tenant.header", "TENANT_HEADER", "header carrying the tenant", &c.Tenant.Header},
+		{"tenant.domain", "TENANT_DOMAIN", "parent domain of the tenant subdomains", &c.Tenant.Domain},
+		{"rate-limit.key", "RATE_LIMIT_KEY", "key the requests are limited by: api_key, ip or tenant", &c.RateLimit.Key},
+		{"rate-limit.read-rps", "RATE_LIMIT_READ_RPS", "requests per second of the read routes, zero disables the limit", &c.RateLimit.ReadRPS},
+		{"rate-limit.read-burst", "RATE_LIMIT_READ_BURST", "burst of the read routes", &c.RateLimit.ReadBurst},
+		{"rate-limit.write-rps", "RATE_LIMIT_WRITE_RPS", "requests per second of the write routes, zero disables the limit", &c.RateLimit.WriteRPS},
+		{"rate-limit.write-burst", "RATE_LIMIT_WRITE_BURST", "burst of the write routes", &c.RateLimit.WriteBurst},
+		{"rate-limit.quota-daily", "QUOTA_DAILY_REQUESTS", "requests per key and day, zero only counts them", &c.RateLimit.QuotaDaily},
+		{"idempotency.window", "IDEMPOTENCY_WINDOW", "time a response is replayed for the same idempotency key", &c.Idempotency.Window},
+		{"graphql.max-depth", "GRAPHQL_MAX_DEPTH", "maximum nesting of the fields of a GraphQL operation, zero disables the limit", &c.GraphQL.MaxDepth},
+		{"graphql.max-complexity", "GRAPHQL_MAX_COMPLEXITY", "maximum number of fields a GraphQL operation may resolve, zero disables the limit", &c.GraphQL.MaxComplexity},
+		{"graphql.default-page-size", "GRAPHQL_DEFAULT_PAGE_SIZE", "vehicles of a GraphQL page when first is not set", &c.GraphQL.DefaultPageSize},
+		{"graphql.max-page-size", "GRAPHQL_MAX_PAGE_SIZE", "maximum vehicles of a GraphQL page", &c.GraphQL.MaxPageSize},
+		{"graphql.subscription-buffer", "GRAPHQL_SUBSCRIPTION_BUFFER", "changes a GraphQL subscription buffers before it misses them", &c.GraphQL.SubscriptionBuffer},
+		{"pagination.default-limit", "PAGINATION_DEFAULT_LIMIT", "page size of the collections of the api v2 when the request does not set one", &c.Pagination.DefaultLimit},
+		{"pagination.max-limit", "PAGINATION_MAX_LIMIT", "maximum page size of the collections of the api v2", &c.Pagination.MaxLimit},
+		{"grpc.addr", "GRPC_ADDR", "address the gRPC server listens on, the one of the server shares its port", &c.GRPC.Addr},
+		{"compression.encodings", "COMPRESSION_ENCODINGS", "comma separated content encodings offered, in order of preference: zstd, br and gzip", &c.Compression.Encodings},
+		{"compression.level", "COMPRESSION_LEVEL", "compression level: fastest, default or best", &c.Compression.Level},
+		{"compression.min-size", "COMPRESSION_MIN_SIZE", "size in bytes from which a response is compressed", &c.Compression.MinSize},
+		{"cache.max-age", "CACHE_MAX_AGE", "time the clients may reuse a response without revalidating it, zero makes them revalidate it", &c.Cache.MaxAge},
+		{"cache.size", "CACHE_SIZE", "maximum number of responses of the response cache", &c.Cache.Size},
+		{"log.format", "LOG_FORMAT", "log format: json or text", &c.Log.Format},
+		{"log.level", "LOG_LEVEL", "log level: debug, info, warn or error", &c.Log.Level},
+		{"log.redact", "LOG_REDACT", "comma separated log attributes to redact", &c.Log.Redact},
+		{"tracing.exporter", "TRACING_EXPORTER", "span exporter: stdout, file, or empty", &c.Tracing.Exporter},
+		{"tracing.file", "TRACING_FILE", "file the spans are written to by the file exporter", &c.Tracing.File},
+		{"features.metrics", "FEATURE_METRICS", "serve the /metrics endpoint", &c.Features.Metrics},
+		{"features.rate-limit", "FEATURE_RATE_LIMIT", "enforce the rate limits and quotas", &c.Features.RateLimit},
+		{"features.idempotency", "FEATURE_IDEMPOTENCY", "honour the Idempotency-Key header", &c.Features.Idempotency},
+		{"features.graphql", "FEATURE_GRAPHQL", "serve the /api/v1/graphql endpoint", &c.Features.GraphQL},
+		{"features.grpc", "FEATURE_GRPC", "serve the gRPC api", &c.Features.GRPC},
+		{"features.compression", "FEATURE_COMPRESSION", "compress the responses in the encoding negotiated with Accept-Encoding", &c.Features.Compression},
+		{"features.response-cache", "FEATURE_RESPONSE_CACHE", "store the responses of the read routes until the vehicles change", &c.Features.ResponseCache},
+		{"features.validate-requests", "FEATURE_VALIDATE_REQUESTS", "reject the requests that do not match the OpenAPI document", &c.Features.ValidateRequests},
+		{"features.validate-responses", "FEATURE_VALIDATE_RESPONSES", "log the responses that do not match the OpenAPI document", &c.Features.ValidateResponses},
+	}
+}
+
+// Load returns the configuration layered from the defaults, the configuration file, the environment and the flags,
+// each layer overriding the previous one, and validates it.
+// The configuration file is given by the -config flag or the CONFIG_FILE environment variable.
+func Load(args []string, lookupEnv func(key string) (string, bool)) (c *Config, err error) {
+	c = Default()
+
+	// file
+	path, _ := lookupEnv(EnvFile)
+	if p, ok := configFlag(args); ok {
+		path = p
+	}
+	if path != "" {
+		if err = c.loadFile(path); err != nil {
+			return
+		}
+	}
+
+	// environment
+	for _, b := range c.bindings() {
+		value, ok := lookupEnv(b.env)
+		if !ok {
+			continue
+		}
+		if err = set(b.ptr, value); err != nil {
+			err = fmt.Errorf("%w. environment variable %s: %v", ErrConfigInvalid, b.env, err)
+			return
+		}
+	}
+
+	// flags
+	fs := flag.NewFlagSet("vehicle-api", flag.ContinueOnError)
+	fs.String("config", path, "configuration file (.yaml, .yml or .toml)")
+	for _, b := range c.bindings() {
+		ptr := b.ptr
+		fs.Func(b.flag, fmt.Sprintf("%s (env %s, default %v)", b.usage, b.env, value(ptr)), func(s string) error { return set(ptr, s) })
+	}
+	if err = fs.Parse(args); err != nil {
+		err = fmt.Errorf("%w. %w", ErrConfigInvalid, err)
+		return
+	}
+
+	err = c.Validate()
+	return
+}
+
+// configFlag returns the value of the -config flag of the arguments.
+func configFlag(args []string) (path string, ok bool) {
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "config" {
+			continue
+		}
+		if hasValue {
+			return value, true
+		}
+		if i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+	return
+}
+
+// loadFile overrides the configuration with the fields of a yaml or toml file.
+func (c *Config) loadFile(path string) (err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrConfigInvalid, err)
+		return
+	}
+
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(c)
+	case ".toml":
+		var md toml.MetaData
+		md, err = toml.Decode(string(data), c)
+		if err == nil && len(md.Undecoded()) > 0 {
+			err = fmt.Errorf("unknown field %s", md.Undecoded()[0])
+		}
+	default:
+		err = fmt.Errorf("unsupported extension %q", ext)
+	}
+	if err != nil {
+		err = fmt.Errorf("%w. file %s: %v", ErrConfigInvalid, path, err)
+		return
+	}
+	return
+}
+
+// set parses s into the field ptr points to.
+func set(ptr any, s string) (err error) {
+	switch p := ptr.(type) {
+	case *string:
+		*p = s
+	case *int:
+		*p, err = strconv.Atoi(s)
+	case *float64:
+		*p, err = strconv.ParseFloat(s, 64)
+	case *bool:
+		*p, err = strconv.ParseBool(s)
+	case *time.Duration:
+		*p, err = time.ParseDuration(s)
+	case *[]string:
+		*p = nil
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*p = append(*p, item)
+			}
+		}
+	default:
+		err = fmt.Errorf("unsupported type %T", ptr)
+	}
+	return
+}
+
+// value returns the value of the field ptr points to.
+func value(ptr any) any {
+	switch p := ptr.(type) {
+	case *string:
+		return *p
+	case *int:
+		return *p
+	case *float64:
+		return *p
+	case *bool:
+		return *p
+	case *time.Duration:
+		return *p
+	case *[]string:
+		return strings.Join(*p, ",")
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validate returns an error describing every invalid field of the configuration.
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(field string, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+	exists := func(field, path string) {
+		if _, err := os.Stat(path); err != nil {
+			invalid(field, "%v", err)
+		}
+	}
+	oneOf := func(field, value string, allowed ...string) {
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		invalid(field, "%q is not one of %q", value, allowed)
+	}
+	nonNegative := func(field string, value float64) {
+		if value < 0 {
+			invalid(field, "must not be negative")
+		}
+	}
+
+	// server
+	if c.Server.Addr == "" {
+		invalid("server.addr", "is required")
+	}
+	nonNegative("server.read_header_timeout", c.Server.ReadHeaderTimeout.Seconds())
+	nonNegative("server.shutdown_delay", c.Server.ShutdownDelay.Seconds())
+	if c.Server.ShutdownTimeout <= 0 {
+		invalid("server.shutdown_timeout", "must be positive")
+	}
+	nonNegative("server.max_body_bytes", float64(c.Server.MaxBodyBytes))
+	nonNegative("timeouts.read", c.Timeouts.Read.Seconds())
+	nonNegative("timeouts.write", c.Timeouts.Write.Seconds())
+	nonNegative("timeouts.batch", c.Timeouts.Batch.Seconds())
+
+	// data
+	oneOf("repository.backend", c.Repository.Backend, "memory")
+	if c.Loader.Path == "" && c.Loader.TenantsDir == "" {
+		invalid("loader", "either loader.path (FILE_PATH_VEHICLES_JSON) or loader.tenants_dir (DIR_PATH_TENANTS_VEHICLES_JSON) is required")
+	}
+	if c.Loader.Path != "" {
+		exists("loader.path", c.Loader.Path)
+	}
+	if c.Loader.TenantsDir != "" {
+		exists("loader.tenants_dir", c.Loader.TenantsDir)
+	}
+	if c.Loader.Format != "" {
+		oneOf("loader.format", c.Loader.Format, "json", "csv", "yaml", "ndjson")
+	}
+	oneOf("loader.mode", c.Loader.Mode, "permissive", "strict", "lenient")
+	if utf8.RuneCountInString(c.Loader.CSVDelimiter) != 1 {
+		invalid("loader.csv_delimiter", "%q must be a single character", c.Loader.CSVDelimiter)
+	}
+	for _, pair := range c.Loader.CSVColumns {
+		if field, column, ok := strings.Cut(pair, "="); !ok || field == "" || column == "" {
+			invalid("loader.csv_columns", "%q is not a field=column pair", pair)
+		}
+	}
+	nonNegative("loader.watch_interval", c.Loader.WatchInterval.Seconds())
+	oneOf("loader.reload_policy", c.Loader.ReloadPolicy, "discard", "merge")
+
+	oneOf("snapshot.format", c.Snapshot.Format, "json", "csv", "ndjson")
+	nonNegative("snapshot.interval", c.Snapshot.Interval.Seconds())
+	if c.Snapshot.Interval > 0 && c.Snapshot.Dir == "" {
+		invalid("snapshot.dir", "is required by the autosave (snapshot.interval)")
+	}
+
+	// access
+	if c.Auth.PolicyPath != "" {
+		exists("auth.policy_path", c.Auth.PolicyPath)
+	}
+	if c.Tenant.Header == "" {
+		invalid("tenant.header", "is required")
+	}
+	oneOf("rate_limit.key", c.RateLimit.Key, "api_key", "ip", "tenant")
+	nonNegative("rate_limit.read_rps", c.RateLimit.ReadRPS)
+	nonNegative("rate_limit.read_burst", float64(c.RateLimit.ReadBurst))
+	nonNegative("rate_limit.write_rps", c.RateLimit.WriteRPS)
+	nonNegative("rate_limit.write_burst", float64(c.RateLimit.WriteBurst))
+	nonNegative("rate_limit.quota_daily", float64(c.RateLimit.QuotaDaily))
+	if c.Idempotency.Window <= 0 {
+		invalid("idempotency.window", "must be positive")
+	}
+	nonNegative("graphql.max_depth", float64(c.GraphQL.MaxDepth))
+	nonNegative("graphql.max_complexity", float64(c.GraphQL.MaxComplexity))
+	if c.GraphQL.MaxPageSize <= 0 {
+		invalid("graphql.max_page_size", "must be positive")
+	}
+	if c.GraphQL.DefaultPageSize <= 0 || c.GraphQL.DefaultPageSize > c.GraphQL.MaxPageSize {
+		invalid("graphql.default_page_size", "must be positive and at most graphql.max_page_size")
+	}
+	if c.GraphQL.SubscriptionBuffer <= 0 {
+		invalid("graphql.subscription_buffer", "must be positive")
+	}
+	if c.Pagination.MaxLimit <= 0 {
+		invalid("pagination.max_limit", "must be positive")
+	}
+	if c.Pagination.DefaultLimit <= 0 || c.Pagination.DefaultLimit > c.Pagination.MaxLimit {
+		invalid("pagination.default_limit", "must be positive and at most pagination.max_limit")
+	}
+	if c.Features.GRPC && c.GRPC.Addr == "" {
+		invalid("grpc.addr", "is required by the gRPC api")
+	}
+
+	// responses
+	if c.Features.Compression && len(c.Compression.Encodings) == 0 {
+		invalid("compression.encodings", "is required by the compression")
+	}
+	for _, encoding := range c.Compression.Encodings {
+		oneOf("compression.encodings", encoding, "zstd", "br", "gzip")
+	}
+	oneOf("compression.level", c.Compression.Level, "fastest", "default", "best")
+	nonNegative("compression.min_size", float64(c.Compression.MinSize))
+	nonNegative("cache.max_age", c.Cache.MaxAge.Seconds())
+	if c.Features.ResponseCache && c.Cache.Size <= 0 {
+		invalid("cache.size", "must be positive for the response cache")
+	}
+
+	// observability
+	oneOf("log.format", c.Log.Format, "json", "text")
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		invalid("log.level", "%q is not one of debug, info, warn or error", c.Log.Level)
+	}
+	oneOf("tracing.exporter", c.Tracing.Exporter, "", "stdout", "file")
+	if c.Tracing.Exporter == "file" && c.Tracing.File == "" {
+		invalid("tracing.file", "is required by the file exporter")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w:\n%w", ErrConfigInvalid, errors.Join(errs...))
+	}
+	return nil
+}
@@ -1,5 +1,8 @@
 package domain
 
+// MaxSpeedLimit is the highest max speed a vehicle may have, the lowest being zero.
+const MaxSpeedLimit = 400
+
 // VehicleAttributes is an struct that represents the attributes of a vehicle.
 type VehicleAttributes struct {
 	// Brand is the brand of the vehicle.
@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"app/internal/vehicle/service"
+	"errors"
+)
+
+// Code is the code of an error, in the extensions of the GraphQL errors.
+type Code string
+
+const (
+	CodeBadUserInput    Code = "BAD_USER_INPUT"
+	CodeForbidden       Code = "FORBIDDEN"
+	CodeNotFound        Code = "NOT_FOUND"
+	CodeConflict        Code = "CONFLICT"
+	CodeTimeout         Code = "TIMEOUT"
+	CodeCanceled        Code = "CANCELED"
+	CodeInternal        Code = "INTERNAL"
+	CodeQueryTooDeep    Code = "QUERY_TOO_DEEP"
+	CodeQueryTooComplex Code = "QUERY_TOO_COMPLEX"
+)
+
+// Error is an struct that represents an error of a field, with its code.
+type Error struct {
+	Code    Code
+	Message string
+}
+
+// Error returns the message of the error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions returns the code of the error, written in the extensions of the GraphQL error.
+func (e *Error) Extensions() map[string]any {
+	return map[string]any{"code": e.Code}
+}
+
+// serviceError returns the error of a field for an error of the service.
+// The unexpected errors are not disclosed.
+func serviceError(err error) *Error {
+	switch {
+	case errors.Is(err, service.ErrServiceVehicleNotFound), errors.Is(err, service.ErrServiceVehicleNotFoundWithValue):
+		return &Error{Code: CodeNotFound, Message: "vehicle not found"}
+	case errors.Is(err, service.ErrServiceTenantNotFound):
+		return &Error{Code: CodeNotFound, Message: "tenant not found"}
+	case errors.Is(err, service.ErrServiceVehicleExist):
+		return &Error{Code: CodeConflict, Message: "vehicle id already exists"}
+	case errors.Is(err, service.ErrServiceImposibleMaxSpeed):
+		return &Error{Code: CodeBadUserInput, Message: "max speed malformed or out of range"}
+	case errors.Is(err, service.ErrServiceInvalidParam):
+		return &Error{Code: CodeBadUserInput, Message: "invalid parameters"}
+	case errors.Is(err, service.ErrServiceVehicleTimeout):
+		return &Error{Code: CodeTimeout, Message: "operation timed out"}
+	case errors.Is(err, service.ErrServiceVehicleCanceled):
+		return &Error{Code: CodeCanceled, Message: "operation canceled"}
+	default:
+		return &Error{Code: CodeInternal, Message: "internal error"}
+	}
+}
@@ -0,0 +1,154 @@
+// Package graph serves the vehicle services of every tenant over GraphQL: queries with filters, pagination and
+// aggregations, mutations to add, update and delete vehicles, and subscriptions to the changes of the vehicles.
+//
+// The tenant and the principal of a request are taken from its context, as set by the middlewares of the api,
+// and the principal is held to the same permissions and brands as on the REST routes.
+// The depth and the complexity of the operations are limited before they are executed.
+package graph
+
+import (
+	"app/internal/vehicle/events"
+	"app/internal/vehicle/service"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+var (
+	// ErrGraphSchema is returned when the schema cannot be built.
+	ErrGraphSchema = errors.New("graph: invalid schema")
+)
+
+// Limits is an struct that represents the limits of the operations.
+type Limits struct {
+	// MaxDepth is the maximum nesting of the fields of an operation.
+	MaxDepth int
+	// MaxComplexity is the maximum number of fields an operation may resolve,
+	// the fields of a page counted once per vehicle of the page.
+	MaxComplexity int
+	// DefaultPageSize and MaxPageSize are the default and maximum number of vehicles of a page.
+	DefaultPageSize int
+	MaxPageSize     int
+}
+
+// Request is an struct that represents a GraphQL request.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Operation is an struct that represents a request parsed and validated against the schema.
+type Operation struct {
+	// Type is the type of the operation: query, mutation or subscription.
+	Type string
+	req  Request
+	doc  *ast.Document
+}
+
+// NewSchema returns the schema of the vehicle services of st, subscribed to the changes of the bus.
+func NewSchema(st service.ServiceVehicleTenants, bus *events.Bus, limits Limits, lg *slog.Logger) (s *Schema, err error) {
+	r := &resolver{st: st, bus: bus, limits: limits, lg: lg}
+	schema, err := graphql.NewSchema(r.schemaConfig())
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrGraphSchema, err)
+		return
+	}
+	s = &Schema{schema: schema, limits: limits}
+	return
+}
+
+// Schema is an struct that represents the executable GraphQL schema of the vehicles.
+type Schema struct {
+	schema graphql.Schema
+	limits Limits
+}
+
+// Parse parses and validates the request, and checks its operation is within the limits.
+// Otherwise it returns the result with the errors to respond.
+func (s *Schema) Parse(r Request) (op *Operation, res *graphql.Result) {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(r.Query), Name: "GraphQL request"})})
+	if err != nil {
+		res = &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+		return
+	}
+	if vr := graphql.ValidateDocument(&s.schema, doc, nil); !vr.IsValid {
+		res = &graphql.Result{Errors: vr.Errors}
+		return
+	}
+	definition := operation(doc, r.OperationName)
+	if definition == nil {
+		res = &graphql.Result{Errors: gqlerrors.FormatErrors(fmt.Errorf("unknown operation %q", r.OperationName))}
+		return
+	}
+	if e := s.check(doc, definition, r.Variables); e != nil {
+		res = &graphql.Result{Errors: []gqlerrors.FormattedError{{Message: e.Message, Extensions: e.Extensions()}}}
+		return
+	}
+	op = &Operation{Type: definition.Operation, req: r, doc: doc}
+	return
+}
+
+// OperationType returns the type of the operation of the request, query, mutation or subscription,
+// or empty if the request does not parse. It neither validates the request nor checks its limits.
+func OperationType(r Request) (t string) {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(r.Query), Name: "GraphQL request"})})
+	if err != nil {
+		return
+	}
+	if definition := operation(doc, r.OperationName); definition != nil {
+		t = definition.Operation
+	}
+	return
+}
+
+// Execute executes a query or a mutation.
+func (s *Schema) Execute(ctx context.Context, op *Operation) *graphql.Result {
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           op.doc,
+		OperationName: op.req.OperationName,
+		Args:          op.req.Variables,
+		Context:       ctx,
+	})
+}
+
+// Subscribe executes a subscription, returning a result per change until the context is done,
+// when the channel is closed.
+func (s *Schema) Subscribe(ctx context.Context, op *Operation) <-chan *graphql.Result {
+	return graphql.ExecuteSubscription(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           op.doc,
+		OperationName: op.req.OperationName,
+		Args:          op.req.Variables,
+		Context:       ctx,
+	})
+}
+
+// operation returns the operation of the document with the name, or its only operation if the name is empty.
+func operation(doc *ast.Document, name string) (op *ast.OperationDefinition) {
+	for _, definition := range doc.Definitions {
+		d, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if op != nil {
+				return nil
+			}
+			op = d
+			continue
+		}
+		if d.Name != nil && d.Name.Value == name {
+			return d
+		}
+	}
+	return
+}
@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// paged are the fields that return a page of vehicles, whose fields are resolved once per vehicle.
+var paged = map[string]bool{"vehicles": true}
+
+// check returns an error if the operation is deeper or more complex than the limits.
+// The introspection fields count as any other, so a deeply nested introspection query is rejected too.
+func (s *Schema) check(doc *ast.Document, op *ast.OperationDefinition, variables map[string]any) (err *Error) {
+	m := measure{limits: s.limits, variables: variables, fragments: make(map[string]*ast.FragmentDefinition)}
+	for _, definition := range doc.Definitions {
+		if f, ok := definition.(*ast.FragmentDefinition); ok {
+			m.fragments[f.Name.Value] = f
+		}
+	}
+	depth, complexity := m.selections(op.SelectionSet)
+	switch {
+	case s.limits.MaxDepth > 0 && depth > s.limits.MaxDepth:
+		err = &Error{Code: CodeQueryTooDeep, Message: fmt.Sprintf("query depth %d exceeds the limit of %d", depth, s.limits.MaxDepth)}
+	case s.limits.MaxComplexity > 0 && complexity > s.limits.MaxComplexity:
+		err = &Error{Code: CodeQueryTooComplex, Message: fmt.Sprintf("query complexity %d exceeds the limit of %d", complexity, s.limits.MaxComplexity)}
+	}
+	return
+}
+
+// measure is an struct that measures the depth and the complexity of the selections of an operation.
+type measure struct {
+	limits    Limits
+	variables map[string]any
+	// fragments are the fragments of the document, by name.
+	fragments map[string]*ast.FragmentDefinition
+}
+
+// selections returns the depth and the complexity of a selection set.
+// The fragments are measured as if their fields were selected in place,
+// and the documents with cycles of fragments never get here, as the validation rejects them.
+func (m *measure) selections(set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return
+	}
+	for _, selection := range set.Selections {
+		var d, c int
+		switch sel := selection.(type) {
+		case *ast.Field:
+			d, c = m.selections(sel.SelectionSet)
+			d, c = d+1, 1+c*m.multiplier(sel)
+		case *ast.InlineFragment:
+			d, c = m.selections(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			if f, ok := m.fragments[sel.Name.Value]; ok {
+				d, c = m.selections(f.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return
+}
+
+// multiplier returns the number of times the selections of a field are resolved: the size of the page
+// of the paged fields, once for any other field.
+func (m *measure) multiplier(field *ast.Field) int {
+	if !paged[field.Name.Value] {
+		return 1
+	}
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil {
+				return max(n, 1)
+			}
+		case *ast.Variable:
+			switch n := m.variables[v.Name.Value].(type) {
+			case float64:
+				return max(int(n), 1)
+			case int:
+				return max(n, 1)
+			}
+		}
+	}
+	return m.limits.DefaultPageSize
+}
@@ -0,0 +1,473 @@
+package graph
+
+import (
+	"app/internal/auth"
+	"app/internal/domain"
+	"app/internal/tenant"
+	"app/internal/vehicle/events"
+	"app/internal/vehicle/service"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// resolver is an struct that resolves the fields of the schema with the vehicle services of every tenant.
+type resolver struct {
+	st service.ServiceVehicleTenants
+	// bus delivers the changes of the vehicles to the subscriptions.
+	bus    *events.Bus
+	limits Limits
+	lg     *slog.Logger
+}
+
+// service returns the vehicle service of the tenant of the request,
+// if its principal has the permission.
+func (r *resolver) service(ctx context.Context, perm auth.Permission) (sv service.ServiceVehicle, err error) {
+	if principal := auth.PrincipalFromContext(ctx); principal != nil && !principal.HasPermission(perm) {
+		err = &Error{Code: CodeForbidden, Message: "missing permission " + string(perm)}
+		return
+	}
+	sv, err = r.st.Tenant(tenant.FromContext(ctx))
+	if err != nil {
+		err = r.fail(ctx, "Tenant", err)
+		return
+	}
+	return
+}
+
+// fail returns the error of a field for an error of the service, logged as an error when unexpected.
+func (r *resolver) fail(ctx context.Context, op string, err error) *Error {
+	e := serviceError(err)
+	if e.Code == CodeInternal {
+		r.lg.ErrorContext(ctx, "graphql operation failed", "operation", op, "error", err)
+	}
+	return e
+}
+
+// brand returns an error if the principal of the request is not allowed to access the vehicles of the brand.
+func brand(ctx context.Context, b string) error {
+	if !auth.PrincipalFromContext(ctx).HasBrand(b) {
+		return &Error{Code: CodeForbidden, Message: "no access to the vehicles of the brand " + b}
+	}
+	return nil
+}
+
+// authorize returns an error if the principal of the request is not allowed to access the stored vehicle with the id.
+func (r *resolver) authorize(ctx context.Context, sv service.ServiceVehicle, op string, id int) error {
+	principal := auth.PrincipalFromContext(ctx)
+	if principal == nil || len(principal.Brands) == 0 {
+		return nil
+	}
+	v, err := sv.GetById(ctx, id)
+	if err != nil {
+		return r.fail(ctx, op, err)
+	}
+	return brand(ctx, v.Attributes.Brand)
+}
+
+// all returns the vehicles the principal of the request is allowed to access that match the filter, sorted by id.
+func (r *resolver) all(ctx context.Context, sv service.ServiceVehicle, f filter) (v []*domain.Vehicle, err error) {
+	vehicles, err := sv.GetAll(ctx)
+	if err != nil {
+		if !errors.Is(err, service.ErrServiceVehicleNotFound) {
+			err = r.fail(ctx, "GetAll", err)
+			return
+		}
+		err = nil
+	}
+	principal := auth.PrincipalFromContext(ctx)
+	for _, vehicle := range vehicles {
+		if principal.HasBrand(vehicle.Attributes.Brand) && f.match(vehicle) {
+			v = append(v, vehicle)
+		}
+	}
+	sort.Slice(v, func(i, j int) bool { return v[i].Id < v[j].Id })
+	return
+}
+
+// vehicle resolves the vehicle with the id.
+func (r *resolver) vehicle(p graphql.ResolveParams) (any, error) {
+	sv, err := r.service(p.Context, auth.PermissionVehiclesRead)
+	if err != nil {
+		return nil, err
+	}
+	v, err := sv.GetById(p.Context, p.Args["id"].(int))
+	if err != nil {
+		if errors.Is(err, service.ErrServiceVehicleNotFound) {
+			return nil, nil
+		}
+		return nil, r.fail(p.Context, "GetById", err)
+	}
+	if err = brand(p.Context, v.Attributes.Brand); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// vehicles resolves a page of the vehicles that match the filter.
+func (r *resolver) vehicles(p graphql.ResolveParams) (any, error) {
+	first, _ := p.Args["first"].(int)
+	if first < 0 || first > r.limits.MaxPageSize {
+		return nil, &Error{Code: CodeBadUserInput, Message: fmt.Sprintf("first must be between 0 and %d", r.limits.MaxPageSize)}
+	}
+	f, err := parseFilter(p.Args["filter"])
+	if err != nil {
+		return nil, err
+	}
+	after := 0
+	if cursor, ok := p.Args["after"].(string); ok {
+		if after, err = decodeCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	sv, err := r.service(p.Context, auth.PermissionVehiclesRead)
+	if err != nil {
+		return nil, err
+	}
+	vehicles, err := r.all(p.Context, sv, f)
+	if err != nil {
+		return nil, err
+	}
+
+	start := sort.Search(len(vehicles), func(i int) bool { return vehicles[i].Id > after })
+	end := min(start+first, len(vehicles))
+	nodes := vehicles[start:end]
+	var endCursor any
+	if len(nodes) > 0 {
+		endCursor = encodeCursor(nodes[len(nodes)-1].Id)
+	}
+	return map[string]any{
+		"totalCount": len(vehicles),
+		"nodes":      nodes,
+		"pageInfo":   map[string]any{"hasNextPage": end < len(vehicles), "endCursor": endCursor},
+	}, nil
+}
+
+// stats resolves the aggregations of the vehicles that match the filter.
+func (r *resolver) stats(p graphql.ResolveParams) (any, error) {
+	f, err := parseFilter(p.Args["filter"])
+	if err != nil {
+		return nil, err
+	}
+	sv, err := r.service(p.Context, auth.PermissionVehiclesRead)
+	if err != nil {
+		return nil, err
+	}
+	vehicles, err := r.all(p.Context, sv, f)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := map[string]any{
+		"count":      len(vehicles),
+		"byBrand":    groupBy(vehicles, func(v *domain.Vehicle) string { return v.Attributes.Brand }),
+		"byFuelType": groupBy(vehicles, func(v *domain.Vehicle) string { return v.Attributes.FuelType }),
+	}
+	if len(vehicles) > 0 {
+		g := newGroup("")
+		minYear, maxYear := vehicles[0].Attributes.Year, vehicles[0].Attributes.Year
+		for _, v := range vehicles {
+			g.add(v)
+			minYear, maxYear = min(minYear, v.Attributes.Year), max(maxYear, v.Attributes.Year)
+		}
+		stats["averageMaxSpeed"] = g.averageMaxSpeed()
+		stats["averageWeight"] = g.averageWeight()
+		stats["minYear"], stats["maxYear"] = minYear, maxYear
+	}
+	return stats, nil
+}
+
+// averageSpeedByBrand resolves the average max speed of the vehicles of the brand.
+func (r *resolver) averageSpeedByBrand(p graphql.ResolveParams) (any, error) {
+	b := p.Args["brand"].(string)
+	if err := brand(p.Context, b); err != nil {
+		return nil, err
+	}
+	sv, err := r.service(p.Context, auth.PermissionVehiclesRead)
+	if err != nil {
+		return nil, err
+	}
+	average, err := sv.GetSpeedAverageByBrand(p.Context, b)
+	if err != nil {
+		if errors.Is(err, service.ErrServiceVehicleNotFound) || errors.Is(err, service.ErrServiceVehicleNotFoundWithValue) {
+			return nil, nil
+		}
+		return nil, r.fail(p.Context, "GetSpeedAverageByBrand", err)
+	}
+	return average, nil
+}
+
+// addVehicle resolves the vehicle added.
+func (r *resolver) addVehicle(p graphql.ResolveParams) (any, error) {
+	vehicle, err := parseVehicle(p.Args["input"])
+	if err != nil {
+		return nil, err
+	}
+	if err = brand(p.Context, vehicle.Attributes.Brand); err != nil {
+		return nil, err
+	}
+	sv, err := r.service(p.Context, auth.PermissionVehiclesWrite)
+	if err != nil {
+		return nil, err
+	}
+	v, err := sv.AddVehicle(p.Context, vehicle)
+	if err != nil {
+		return nil, r.fail(p.Context, "AddVehicle", err)
+	}
+	return v, nil
+}
+
+// addVehicles resolves the vehicles added.
+func (r *resolver) addVehicles(p graphql.ResolveParams) (any, error) {
+	inputs, _ := p.Args["input"].([]any)
+	vehicles := make([]*domain.Vehicle, 0, len(inputs))
+	for i, input := range inputs {
+		vehicle, err := parseVehicle(input)
+		if err != nil {
+			return nil, &Error{Code: CodeBadUserInput, Message: fmt.Sprintf("input %d: %v", i, err)}
+		}
+		if err = brand(p.Context, vehicle.Attributes.Brand); err != nil {
+			return nil, err
+		}
+		vehicles = append(vehicles, vehicle)
+	}
+	sv, err := r.service(p.Context, auth.PermissionVehiclesWrite)
+	if err != nil {
+		return nil, err
+	}
+	v, err := sv.AddVehicles(p.Context, vehicles)
+	if err != nil {
+		return nil, r.fail(p.Context, "AddVehicles", err)
+	}
+	return v, nil
+}
+
+// updateSpeed resolves the vehicle whose max speed was updated.
+func (r *resolver) updateSpeed(p graphql.ResolveParams) (any, error) {
+	id, speed := p.Args["id"].(int), p.Args["maxSpeed"].(int)
+	if speed < 0 || speed > domain.MaxSpeedLimit {
+		return nil, &Error{Code: CodeBadUserInput, Message: fmt.Sprintf("maxSpeed must be between 0 and %d", domain.MaxSpeedLimit)}
+	}
+	sv, err := r.service(p.Context, auth.PermissionVehiclesWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.authorize(p.Context, sv, "UpdateSpeed", id); err != nil {
+		return nil, err
+	}
+	v, err := sv.UpdateSpeed(p.Context, &domain.Vehicle{Id: id, Attributes: domain.VehicleAttributes{MaxSpeed: speed}})
+	if err != nil {
+		return nil, r.fail(p.Context, "UpdateSpeed", err)
+	}
+	return v, nil
+}
+
+// deleteVehicle resolves the vehicle deleted.
+func (r *resolver) deleteVehicle(p graphql.ResolveParams) (any, error) {
+	id := p.Args["id"].(int)
+	sv, err := r.service(p.Context, auth.PermissionVehiclesDelete)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.authorize(p.Context, sv, "DeleteVehicle", id); err != nil {
+		return nil, err
+	}
+	v, err := sv.DeleteVehicle(p.Context, id)
+	if err != nil {
+		return nil, r.fail(p.Context, "DeleteVehicle", err)
+	}
+	return v, nil
+}
+
+// vehicleChanged subscribes to the changes of the vehicles of the tenant of the request of the kinds,
+// of the brands its principal is allowed to access.
+func (r *resolver) vehicleChanged(p graphql.ResolveParams) (any, error) {
+	if _, err := r.service(p.Context, auth.PermissionVehiclesRead); err != nil {
+		return nil, err
+	}
+	kinds := make(map[events.Kind]bool)
+	if list, ok := p.Args["kinds"].([]any); ok {
+		for _, k := range list {
+			kinds[k.(events.Kind)] = true
+		}
+	}
+
+	principal := auth.PrincipalFromContext(p.Context)
+	sub := r.bus.Subscribe(p.Context, tenant.FromContext(p.Context))
+	changes := make(chan any)
+	go func() {
+		defer close(changes)
+		for e := range sub {
+			if len(kinds) > 0 && !kinds[e.Kind] || !principal.HasBrand(e.Vehicle.Attributes.Brand) {
+				continue
+			}
+			select {
+			case changes <- e:
+			case <-p.Context.Done():
+				return
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// filter is an struct that represents the conditions of a VehicleFilter.
+// The nil conditions are not checked.
+type filter struct {
+	brand, model, color, fuelType, transmission *string
+	yearMin, yearMax, maxSpeedMin, maxSpeedMax  *int
+	weightMin, weightMax                        *float64
+}
+
+// parseFilter returns the filter of a VehicleFilter argument.
+func parseFilter(arg any) (f filter, err error) {
+	m, _ := arg.(map[string]any)
+	f = filter{
+		brand: value[string](m, "brand"), model: value[string](m, "model"), color: value[string](m, "color"),
+		fuelType: value[string](m, "fuelType"), transmission: value[string](m, "transmission"),
+		yearMin: value[int](m, "yearMin"), yearMax: value[int](m, "yearMax"),
+		maxSpeedMin: value[int](m, "maxSpeedMin"), maxSpeedMax: value[int](m, "maxSpeedMax"),
+		weightMin: value[float64](m, "weightMin"), weightMax: value[float64](m, "weightMax"),
+	}
+	switch {
+	case f.yearMin != nil && f.yearMax != nil && *f.yearMin > *f.yearMax,
+		f.maxSpeedMin != nil && f.maxSpeedMax != nil && *f.maxSpeedMin > *f.maxSpeedMax,
+		f.weightMin != nil && f.weightMax != nil && *f.weightMin > *f.weightMax:
+		err = &Error{Code: CodeBadUserInput, Message: "the minimum of a range of the filter is greater than its maximum"}
+	}
+	return
+}
+
+// match returns true if the vehicle meets every condition of the filter. The strings are compared case insensitively.
+func (f filter) match(v *domain.Vehicle) bool {
+	a := v.Attributes
+	return equal(f.brand, a.Brand) && equal(f.model, a.Model) && equal(f.color, a.Color) &&
+		equal(f.fuelType, a.FuelType) && equal(f.transmission, a.Transmission) &&
+		within(f.yearMin, f.yearMax, a.Year) && within(f.maxSpeedMin, f.maxSpeedMax, a.MaxSpeed) &&
+		within(f.weightMin, f.weightMax, a.Weight)
+}
+
+// value returns the value of the key of an argument, or nil if it is not set.
+func value[T any](m map[string]any, key string) *T {
+	if v, ok := m[key].(T); ok {
+		return &v
+	}
+	return nil
+}
+
+// equal returns true if want is nil or equal to got.
+func equal(want *string, got string) bool {
+	return want == nil || strings.EqualFold(*want, got)
+}
+
+// within returns true if got is within the bounds that are set.
+func within[T int | float64](lo, hi *T, got T) bool {
+	return (lo == nil || got >= *lo) && (hi == nil || got <= *hi)
+}
+
+// parseVehicle returns the vehicle of a VehicleInput argument.
+func parseVehicle(arg any) (v *domain.Vehicle, err error) {
+	m, _ := arg.(map[string]any)
+	v = &domain.Vehicle{
+		Id: *value[int](m, "id"),
+		Attributes: domain.VehicleAttributes{
+			Brand:        *value[string](m, "brand"),
+			Model:        *value[string](m, "model"),
+			Registration: *value[string](m, "registration"),
+			Year:         *value[int](m, "year"),
+			Color:        *value[string](m, "color"),
+			MaxSpeed:     *value[int](m, "maxSpeed"),
+			FuelType:     *value[string](m, "fuelType"),
+			Transmission: *value[string](m, "transmission"),
+			Passengers:   *value[int](m, "passengers"),
+			Height:       *value[float64](m, "height"),
+			Width:        *value[float64](m, "width"),
+			Weight:       *value[float64](m, "weight"),
+		},
+	}
+	switch {
+	case v.Id < 1:
+		err = &Error{Code: CodeBadUserInput, Message: "id must be positive"}
+	case v.Attributes.Brand == "", v.Attributes.Registration == "":
+		err = &Error{Code: CodeBadUserInput, Message: "brand and registration must not be empty"}
+	case v.Attributes.MaxSpeed < 0 || v.Attributes.MaxSpeed > domain.MaxSpeedLimit:
+		err = &Error{Code: CodeBadUserInput, Message: fmt.Sprintf("maxSpeed must be between 0 and %d", domain.MaxSpeedLimit)}
+	}
+	return
+}
+
+// encodeCursor returns the opaque cursor of the vehicle with the id.
+func encodeCursor(id int) string {
+	return base64.StdEncoding.EncodeToString([]byte("vehicle:" + strconv.Itoa(id)))
+}
+
+// decodeCursor returns the id of the vehicle of a cursor.
+func decodeCursor(cursor string) (id int, err error) {
+	b, err := base64.StdEncoding.DecodeString(cursor)
+	if err == nil {
+		id, err = strconv.Atoi(strings.TrimPrefix(string(b), "vehicle:"))
+	}
+	if err != nil || !strings.HasPrefix(string(b), "vehicle:") {
+		err = &Error{Code: CodeBadUserInput, Message: "invalid cursor"}
+		return
+	}
+	return
+}
+
+// group is an struct that represents the aggregations of a group of vehicles.
+type group struct {
+	key    string
+	count  int
+	speed  float64
+	weight float64
+}
+
+// newGroup returns a new group with the key.
+func newGroup(key string) *group {
+	return &group{key: key}
+}
+
+// add adds the vehicle to the group.
+func (g *group) add(v *domain.Vehicle) {
+	g.count++
+	g.speed += float64(v.Attributes.MaxSpeed)
+	g.weight += v.Attributes.Weight
+}
+
+func (g *group) averageMaxSpeed() float64 { return g.speed / float64(g.count) }
+
+func (g *group) averageWeight() float64 { return g.weight / float64(g.count) }
+
+// groupBy returns the VehicleGroups of the vehicles by the key, sorted by key.
+func groupBy(vehicles []*domain.Vehicle, key func(v *domain.Vehicle) string) []map[string]any {
+	groups := make(map[string]*group)
+	for _, v := range vehicles {
+		k := key(v)
+		if groups[k] == nil {
+			groups[k] = newGroup(k)
+		}
+		groups[k].add(v)
+	}
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := make([]map[string]any, 0, len(keys))
+	for _, k := range keys {
+		g := groups[k]
+		list = append(list, map[string]any{
+			"key": g.key, "count": g.count, "averageMaxSpeed": g.averageMaxSpeed(), "averageWeight": g.averageWeight(),
+		})
+	}
+	return list
+}
@@ -0,0 +1,181 @@
+package graph
+
+import (
+	"app/internal/domain"
+	"app/internal/vehicle/events"
+
+	"github.com/graphql-go/graphql"
+)
+
+// schemaConfig returns the types of the schema, resolved by r.
+func (r *resolver) schemaConfig() graphql.SchemaConfig {
+	nonNull := graphql.NewNonNull
+
+	// vehicles
+	vehicle := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Vehicle",
+		Description: "A vehicle of the fleet of a tenant.",
+		Fields: graphql.Fields{
+			"id":           vehicleField(graphql.Int, func(v *domain.Vehicle) any { return v.Id }),
+			"brand":        vehicleField(graphql.String, func(v *domain.Vehicle) any { return v.Attributes.Brand }),
+			"model":        vehicleField(graphql.String, func(v *domain.Vehicle) any { return v.Attributes.Model }),
+			"registration": vehicleField(graphql.String, func(v *domain.Vehicle) any { return v.Attributes.Registration }),
+			"year":         vehicleField(graphql.Int, func(v *domain.Vehicle) any { return v.Attributes.Year }),
+			"color":        vehicleField(graphql.String, func(v *domain.Vehicle) any { return v.Attributes.Color }),
+			"maxSpeed":     vehicleField(graphql.Int, func(v *domain.Vehicle) any { return v.Attributes.MaxSpeed }),
+			"fuelType":     vehicleField(graphql.String, func(v *domain.Vehicle) any { return v.Attributes.FuelType }),
+			"transmission": vehicleField(graphql.String, func(v *domain.Vehicle) any { return v.Attributes.Transmission }),
+			"passengers":   vehicleField(graphql.Int, func(v *domain.Vehicle) any { return v.Attributes.Passengers }),
+			"height":       vehicleField(graphql.Float, func(v *domain.Vehicle) any { return v.Attributes.Height }),
+			"width":        vehicleField(graphql.Float, func(v *domain.Vehicle) any { return v.Attributes.Width }),
+			"weight":       vehicleField(graphql.Float, func(v *domain.Vehicle) any { return v.Attributes.Weight }),
+		},
+	})
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "VehicleFilter",
+		Description: "Conditions the vehicles must all meet. The ranges include their bounds.",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"brand":        {Type: graphql.String},
+			"model":        {Type: graphql.String},
+			"color":        {Type: graphql.String},
+			"fuelType":     {Type: graphql.String},
+			"transmission": {Type: graphql.String},
+			"yearMin":      {Type: graphql.Int},
+			"yearMax":      {Type: graphql.Int},
+			"maxSpeedMin":  {Type: graphql.Int},
+			"maxSpeedMax":  {Type: graphql.Int},
+			"weightMin":    {Type: graphql.Float},
+			"weightMax":    {Type: graphql.Float},
+		},
+	})
+	input := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "VehicleInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":           {Type: nonNull(graphql.Int)},
+			"brand":        {Type: nonNull(graphql.String)},
+			"model":        {Type: nonNull(graphql.String)},
+			"registration": {Type: nonNull(graphql.String)},
+			"year":         {Type: nonNull(graphql.Int)},
+			"color":        {Type: nonNull(graphql.String)},
+			"maxSpeed":     {Type: nonNull(graphql.Int)},
+			"fuelType":     {Type: nonNull(graphql.String)},
+			"transmission": {Type: nonNull(graphql.String)},
+			"passengers":   {Type: nonNull(graphql.Int)},
+			"height":       {Type: nonNull(graphql.Float)},
+			"width":        {Type: nonNull(graphql.Float)},
+			"weight":       {Type: nonNull(graphql.Float)},
+		},
+	})
+
+	// pages and aggregations
+	pageInfo := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": {Type: nonNull(graphql.Boolean)},
+			"endCursor":   {Type: graphql.String, Description: "Cursor of the last vehicle of the page, to pass as after for the next page."},
+		},
+	})
+	connection := graphql.NewObject(graphql.ObjectConfig{
+		Name: "VehicleConnection",
+		Fields: graphql.Fields{
+			"totalCount": {Type: nonNull(graphql.Int), Description: "Number of vehicles that match the filter, on every page."},
+			"nodes":      {Type: nonNull(graphql.NewList(nonNull(vehicle)))},
+			"pageInfo":   {Type: nonNull(pageInfo)},
+		},
+	})
+	group := graphql.NewObject(graphql.ObjectConfig{
+		Name: "VehicleGroup",
+		Fields: graphql.Fields{
+			"key":             {Type: nonNull(graphql.String)},
+			"count":           {Type: nonNull(graphql.Int)},
+			"averageMaxSpeed": {Type: nonNull(graphql.Float)},
+			"averageWeight":   {Type: nonNull(graphql.Float)},
+		},
+	})
+	stats := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "VehicleStats",
+		Description: "Aggregations of the vehicles that match a filter. The averages are null when there are none.",
+		Fields: graphql.Fields{
+			"count":           {Type: nonNull(graphql.Int)},
+			"averageMaxSpeed": {Type: graphql.Float},
+			"averageWeight":   {Type: graphql.Float},
+			"minYear":         {Type: graphql.Int},
+			"maxYear":         {Type: graphql.Int},
+			"byBrand":         {Type: nonNull(graphql.NewList(nonNull(group)))},
+			"byFuelType":      {Type: nonNull(graphql.NewList(nonNull(group)))},
+		},
+	})
+
+	// changes
+	kind := graphql.NewEnum(graphql.EnumConfig{
+		Name: "ChangeKind",
+		Values: graphql.EnumValueConfigMap{
+			"CREATED": {Value: events.KindCreated},
+			"UPDATED": {Value: events.KindUpdated},
+			"DELETED": {Value: events.KindDeleted},
+		},
+	})
+	change := graphql.NewObject(graphql.ObjectConfig{
+		Name: "VehicleChange",
+		Fields: graphql.Fields{
+			"kind": {Type: nonNull(kind), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(events.Event).Kind, nil
+			}},
+			"vehicle": {Type: nonNull(vehicle), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(events.Event).Vehicle, nil
+			}},
+		},
+	})
+
+	id := &graphql.ArgumentConfig{Type: nonNull(graphql.Int)}
+	return graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"vehicle": {Type: vehicle, Description: "The vehicle with the id, null if there is none.",
+					Args: graphql.FieldConfigArgument{"id": id}, Resolve: r.vehicle},
+				"vehicles": {Type: nonNull(connection), Description: "A page of the vehicles that match the filter, sorted by id.",
+					Args: graphql.FieldConfigArgument{
+						"filter": {Type: filter},
+						"first":  {Type: graphql.Int, DefaultValue: r.limits.DefaultPageSize},
+						"after":  {Type: graphql.String},
+					}, Resolve: r.vehicles},
+				"stats": {Type: nonNull(stats), Description: "Aggregations of the vehicles that match the filter.",
+					Args: graphql.FieldConfigArgument{"filter": {Type: filter}}, Resolve: r.stats},
+				"averageSpeedByBrand": {Type: graphql.Float, Description: "Average max speed of the vehicles of the brand, null if there are none.",
+					Args: graphql.FieldConfigArgument{"brand": {Type: nonNull(graphql.String)}}, Resolve: r.averageSpeedByBrand},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"addVehicle": {Type: nonNull(vehicle),
+					Args: graphql.FieldConfigArgument{"input": {Type: nonNull(input)}}, Resolve: r.addVehicle},
+				"addVehicles": {Type: nonNull(graphql.NewList(nonNull(vehicle))), Description: "Adds the vehicles, all or none.",
+					Args: graphql.FieldConfigArgument{"input": {Type: nonNull(graphql.NewList(nonNull(input)))}}, Resolve: r.addVehicles},
+				"updateSpeed": {Type: nonNull(vehicle),
+					Args: graphql.FieldConfigArgument{"id": id, "maxSpeed": {Type: nonNull(graphql.Int)}}, Resolve: r.updateSpeed},
+				"deleteVehicle": {Type: nonNull(vehicle), Description: "Deletes the vehicle and returns it as it was.",
+					Args: graphql.FieldConfigArgument{"id": id}, Resolve: r.deleteVehicle},
+			},
+		}),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"vehicleChanged": {Type: nonNull(change), Description: "The changes of the vehicles of the tenant, of any kind unless kinds is set.",
+					Args:      graphql.FieldConfigArgument{"kinds": {Type: graphql.NewList(nonNull(kind))}},
+					Subscribe: r.vehicleChanged,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return p.Source, nil
+					}},
+			},
+		}),
+	}
+}
+
+// vehicleField returns a non null field of a vehicle.
+func vehicleField(t graphql.Output, get func(v *domain.Vehicle) any) *graphql.Field {
+	return &graphql.Field{Type: graphql.NewNonNull(t), Resolve: func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(*domain.Vehicle)), nil
+	}}
+}
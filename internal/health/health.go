@@ -0,0 +1,47 @@
+package health
+
+import "sync/atomic"
+
+// NewHealth returns a new instance of the health state of the process, not ready yet.
+func NewHealth() *Health {
+	return &Health{}
+}
+
+// Health is an struct that represents the health state of the process.
+type Health struct {
+	// ready is set once the vehicles are loaded.
+	ready atomic.Bool
+	// shuttingDown is set once the process starts draining its connections.
+	shuttingDown atomic.Bool
+}
+
+// SetReady marks the process as ready to serve requests.
+func (h *Health) SetReady() {
+	h.ready.Store(true)
+}
+
+// SetShuttingDown marks the process as shutting down, so it stops being ready.
+// The requests are still served while the connections drain, only the readiness changes.
+func (h *Health) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Loaded returns true if the process can serve requests, once the vehicles are loaded, or the reason why it can not.
+func (h *Health) Loaded() (ok bool, reason string) {
+	if !h.ready.Load() {
+		reason = "loading vehicles"
+		return
+	}
+	ok = true
+	return
+}
+
+// Ready returns true if the process should receive new requests, or the reason why it should not:
+// the vehicles are not loaded yet, or the process is shutting down.
+func (h *Health) Ready() (ok bool, reason string) {
+	if h.shuttingDown.Load() {
+		reason = "shutting down"
+		return
+	}
+	return h.Loaded()
+}
@@ -0,0 +1,36 @@
+// Package httpcache caches the responses of the read routes for as long as the data they were computed from
+// does not change. The data of every tenant is versioned by its source, and a response is only served again
+// at its version.
+package httpcache
+
+import (
+	"net/http"
+	"time"
+)
+
+// Source is the interface that wraps the version of the data of every tenant the responses are computed from.
+type Source interface {
+	// Version returns the number of the last modification of the data of the tenant and its time.
+	// The numbers only grow, and are never the same for two modifications, whatever their tenant
+	Version(tenant string) (n uint64, modified time.Time, err error)
+}
+
+// Response is an struct that represents a cached response.
+type Response struct {
+	// Status is the status code of the response.
+	Status int
+	// Header is the header of the response.
+	Header http.Header
+	// Body is the body of the response.
+	Body []byte
+	// Version is the version of the data the response was computed from.
+	Version uint64
+}
+
+// Cache is the interface that wraps the basic methods for a response cache.
+type Cache interface {
+	// Get returns the response stored for the key, if it was computed from the given version of the data
+	Get(key string, version uint64) (r *Response, ok bool)
+	// Set stores the response for the key
+	Set(key string, r *Response)
+}
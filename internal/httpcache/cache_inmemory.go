@@ -0,0 +1,74 @@
+package httpcache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// NewCacheInMemory returns a new instance of a response cache in memory holding up to size responses.
+// The least recently used response is evicted to make room for a new one.
+func NewCacheInMemory(size int) *CacheInMemory {
+	return &CacheInMemory{size: size, entries: make(map[string]*list.Element), lru: list.New()}
+}
+
+// CacheInMemory is an struct that implements the Cache interface.
+// The data of every tenant is versioned apart, so a change of a tenant leaves the responses of the others in place.
+// The stale responses are replaced by newer ones, or evicted as the least recently used.
+type CacheInMemory struct {
+	// size is the maximum number of responses.
+	size int
+	// entries are the elements of the responses in lru, by key.
+	entries map[string]*list.Element
+	// lru holds the entries from the most to the least recently used.
+	lru *list.List
+	mu  sync.Mutex
+}
+
+// entry is an struct that represents a stored response.
+type entry struct {
+	key      string
+	response *Response
+}
+
+// Get returns the response stored for the key, if it was computed from the given version of the data.
+func (c *CacheInMemory) Get(key string, version uint64) (r *Response, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	r = e.Value.(*entry).response
+	if ok = r.Version == version; !ok {
+		r = nil
+		return
+	}
+	c.lru.MoveToFront(e)
+	return
+}
+
+// Set stores the response for the key. A response computed from a version older than the one stored
+// for the key is already stale, and is not stored.
+func (c *CacheInMemory) Set(key string, r *Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size <= 0 {
+		return
+	}
+	if e, ok := c.entries[key]; ok {
+		if r.Version < e.Value.(*entry).response.Version {
+			return
+		}
+		e.Value.(*entry).response = r
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&entry{key: key, response: r})
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+}
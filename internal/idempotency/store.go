@@ -0,0 +1,35 @@
+package idempotency
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Response is an struct that represents the stored response of an idempotent request.
+type Response struct {
+	// Status is the status code of the response.
+	Status int
+	// Header is the header of the response.
+	Header http.Header
+	// Body is the body of the response.
+	Body []byte
+}
+
+// Store is the interface that wraps the basic methods for an idempotency key store.
+type Store interface {
+	// Begin reserves the key for a request with the given payload fingerprint.
+	// It returns the stored response if a request with the same key and payload already completed.
+	Begin(key string, fingerprint string) (r *Response, err error)
+	// Complete stores the response of the request that reserved the key.
+	Complete(key string, r *Response)
+	// Release frees the key so the request can be retried.
+	Release(key string)
+}
+
+var (
+	// ErrIdempotencyKeyReused is returned when a key is reused with a different payload.
+	ErrIdempotencyKeyReused = errors.New("idempotency: key reused with a different payload")
+
+	// ErrIdempotencyInProgress is returned when a request with the same key is still in progress.
+	ErrIdempotencyInProgress = errors.New("idempotency: request in progress")
+)
@@ -0,0 +1,94 @@
+package idempotency
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// NewStoreInMemory returns a new instance of an idempotency key store in memory.
+// The keys expire once the window has passed since they were reserved.
+func NewStoreInMemory(window time.Duration) *StoreInMemory {
+	return &StoreInMemory{window: window, entries: make(map[string]*list.Element), order: list.New(), now: time.Now}
+}
+
+// StoreInMemory is an struct that implements the Store interface.
+// Every key is kept for the same window, so the keys expire in the order they were reserved.
+type StoreInMemory struct {
+	// window is the time a key is kept.
+	window time.Duration
+	// entries are the elements of the reserved keys in order, by key.
+	entries map[string]*list.Element
+	// order holds the reserved keys from the oldest to the newest.
+	order *list.List
+	// now returns the current time.
+	now func() time.Time
+	mu  sync.Mutex
+}
+
+// entry is an struct that represents a reserved key.
+type entry struct {
+	key         string
+	fingerprint string
+	expires     time.Time
+	// response is nil while the request is in progress.
+	response *Response
+}
+
+// Begin reserves the key for a request with the given payload fingerprint.
+func (s *StoreInMemory) Begin(key string, fingerprint string) (r *Response, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.expire(now)
+
+	el, ok := s.entries[key]
+	if !ok {
+		s.entries[key] = s.order.PushBack(&entry{key: key, fingerprint: fingerprint, expires: now.Add(s.window)})
+		return
+	}
+	e := el.Value.(*entry)
+	switch {
+	case e.fingerprint != fingerprint:
+		err = ErrIdempotencyKeyReused
+	case e.response == nil:
+		err = ErrIdempotencyInProgress
+	default:
+		r = e.response
+	}
+	return
+}
+
+// Complete stores the response of the request that reserved the key.
+func (s *StoreInMemory) Complete(key string, r *Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		el.Value.(*entry).response = r
+	}
+}
+
+// Release frees the key so the request can be retried.
+func (s *StoreInMemory) Release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		s.order.Remove(el)
+		delete(s.entries, key)
+	}
+}
+
+// expire removes the expired keys, from the oldest until one has not expired yet.
+func (s *StoreInMemory) expire(now time.Time) {
+	for el := s.order.Front(); el != nil; el = s.order.Front() {
+		e := el.Value.(*entry)
+		if !now.After(e.expires) {
+			return
+		}
+		s.order.Remove(el)
+		delete(s.entries, e.key)
+	}
+}
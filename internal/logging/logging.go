@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Redacted is the value logged in place of a redacted attribute.
+const Redacted = "[REDACTED]"
+
+var (
+	// ErrLoggingConfig is returned when the logging configuration is invalid.
+	ErrLoggingConfig = errors.New("logging: invalid configuration")
+)
+
+// Config is an struct that represents the configuration of a logger.
+type Config struct {
+	// Format is the output format, either json or text.
+	Format string
+	// Level is the minimum level logged: debug, info, warn or error.
+	Level string
+	// Redact are the attribute keys whose values are redacted, e.g. registration.
+	Redact []string
+}
+
+// New returns a new structured logger that writes to w.
+// Every record logged with a context carrying a request id includes it.
+func New(w io.Writer, cfg Config) (lg *slog.Logger, err error) {
+	var level slog.Level
+	if cfg.Level == "" {
+		cfg.Level = "info"
+	}
+	if err = level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		err = fmt.Errorf("%w. %v", ErrLoggingConfig, err)
+		return
+	}
+
+	redact := make(map[string]bool, len(cfg.Redact))
+	for _, key := range cfg.Redact {
+		redact[strings.TrimSpace(key)] = true
+	}
+	opts := &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if redact[a.Key] {
+				a.Value = slog.StringValue(Redacted)
+			}
+			return a
+		},
+	}
+
+	var h slog.Handler
+	switch cfg.Format {
+	case "json":
+		h = slog.NewJSONHandler(w, opts)
+	case "text", "":
+		h = slog.NewTextHandler(w, opts)
+	default:
+		err = fmt.Errorf("%w. unknown format %q", ErrLoggingConfig, cfg.Format)
+		return
+	}
+
+	lg = slog.New(&handlerContext{Handler: h})
+	return
+}
+
+// Discard returns a logger that writes nothing, for dependencies built without logging.
+func Discard() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+type requestIDKey struct{}
+
+// NewContext returns a copy of ctx that carries the request id.
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request id carried by ctx, or an empty string if there is none.
+func RequestIDFromContext(ctx context.Context) (id string) {
+	id, _ = ctx.Value(requestIDKey{}).(string)
+	return
+}
+
+// handlerContext is an struct that adds the request id of the context to every record.
+type handlerContext struct {
+	slog.Handler
+}
+
+// Handle adds the request id of the context to the record.
+func (h *handlerContext) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a handler with the attributes.
+func (h *handlerContext) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &handlerContext{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a handler with the group.
+func (h *handlerContext) WithGroup(name string) slog.Handler {
+	return &handlerContext{Handler: h.Handler.WithGroup(name)}
+}
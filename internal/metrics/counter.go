@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"sync"
+)
+
+// NewCounterVec registers a new counter partitioned by the label names.
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{name: name, help: help, labelNames: labelNames, values: make(map[string]*counterValue)}
+	r.register(c)
+	return c
+}
+
+// CounterVec is an struct that represents a monotonic counter partitioned by labels.
+type CounterVec struct {
+	name       string
+	help       string
+	labelNames []string
+	values     map[string]*counterValue
+	mu         sync.Mutex
+}
+
+// counterValue is an struct that represents the value of a counter for some label values.
+type counterValue struct {
+	labelValues []string
+	value       float64
+}
+
+// Inc adds one to the counter of the label values.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds delta to the counter of the label values. Negative deltas are ignored.
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 || len(labelValues) != len(c.labelNames) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := key(labelValues)
+	v, ok := c.values[k]
+	if !ok {
+		v = &counterValue{labelValues: append([]string(nil), labelValues...)}
+		c.values[k] = v
+	}
+	v.value += delta
+}
+
+// Value returns the counter of the label values.
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.values[key(labelValues)]; ok {
+		return v.value
+	}
+	return 0
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header(w, c.name, c.help, "counter")
+	for _, k := range sortedKeys(c.values) {
+		v := c.values[k]
+		fmt.Fprintf(w, "%s%s %s\n", c.name, labels(c.labelNames, v.labelValues), value(v.value))
+	}
+}
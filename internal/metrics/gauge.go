@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+)
+
+// Sample is an struct that represents the value of a gauge for some label values.
+type Sample struct {
+	LabelValues []string
+	Value       float64
+}
+
+// Gauge is an struct that represents the name, help and label names of a gauge computed on every scrape.
+type Gauge struct {
+	Name       string
+	Help       string
+	LabelNames []string
+}
+
+// NewGaugeFunc registers new gauges whose samples are computed by fn on every scrape, once for all of them,
+// so the gauges read from the same source cost one read. fn returns the samples of each gauge, in the order of gauges.
+func (r *Registry) NewGaugeFunc(gauges []Gauge, fn func() [][]Sample) {
+	r.register(&gaugeFunc{gauges: gauges, fn: fn})
+}
+
+// gaugeFunc is an struct that represents gauges computed together on every scrape.
+type gaugeFunc struct {
+	gauges []Gauge
+	fn     func() [][]Sample
+}
+
+func (g *gaugeFunc) write(w *bufio.Writer) {
+	samples := g.fn()
+	for i, gauge := range g.gauges {
+		header(w, gauge.Name, gauge.Help, "gauge")
+		if i >= len(samples) {
+			continue
+		}
+		s := samples[i]
+		sort.Slice(s, func(i, j int) bool { return key(s[i].LabelValues) < key(s[j].LabelValues) })
+		for _, sample := range s {
+			if len(sample.LabelValues) != len(gauge.LabelNames) {
+				continue
+			}
+			fmt.Fprintf(w, "%s%s %s\n", gauge.Name, labels(gauge.LabelNames, sample.LabelValues), value(sample.Value))
+		}
+	}
+}
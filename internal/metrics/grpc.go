@@ -0,0 +1,19 @@
+package metrics
+
+// NewGRPC registers the metrics of the gRPC calls.
+func NewGRPC(r *Registry) *GRPC {
+	return &GRPC{
+		Calls: r.NewCounterVec("grpc_calls_total",
+			"Number of gRPC calls per method and code.", "method", "code"),
+		Duration: r.NewHistogramVec("grpc_call_duration_seconds",
+			"Duration of the gRPC calls per method.", DefBuckets, "method"),
+	}
+}
+
+// GRPC is an struct that represents the metrics of the gRPC calls.
+type GRPC struct {
+	// Calls counts the calls per method and code.
+	Calls *CounterVec
+	// Duration observes the latency of the calls per method.
+	Duration *HistogramVec
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// NewHistogramVec registers a new histogram partitioned by the label names.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	h := &HistogramVec{name: name, help: help, buckets: buckets, labelNames: labelNames, values: make(map[string]*histogramValue)}
+	r.register(h)
+	return h
+}
+
+// HistogramVec is an struct that represents a histogram of observations partitioned by labels.
+type HistogramVec struct {
+	name       string
+	help       string
+	buckets    []float64
+	labelNames []string
+	values     map[string]*histogramValue
+	mu         sync.Mutex
+}
+
+// histogramValue is an struct that represents the observations of a histogram for some label values.
+type histogramValue struct {
+	labelValues []string
+	// counts are the observations per bucket, not cumulative.
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Observe adds an observation to the histogram of the label values.
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	if len(labelValues) != len(h.labelNames) {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	k := key(labelValues)
+	hv, ok := h.values[k]
+	if !ok {
+		hv = &histogramValue{labelValues: append([]string(nil), labelValues...), counts: make([]uint64, len(h.buckets))}
+		h.values[k] = hv
+	}
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		hv.counts[i]++
+	}
+	hv.count++
+	hv.sum += v
+}
+
+// Count returns the number of observations of the label values.
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if hv, ok := h.values[key(labelValues)]; ok {
+		return hv.count
+	}
+	return 0
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	header(w, h.name, h.help, "histogram")
+	names := append(append([]string(nil), h.labelNames...), "le")
+	for _, k := range sortedKeys(h.values) {
+		hv := h.values[k]
+		values := append(append([]string(nil), hv.labelValues...), "")
+
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += hv.counts[i]
+			values[len(values)-1] = value(bound)
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labels(names, values), cumulative)
+		}
+		values[len(values)-1] = value(math.Inf(1))
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labels(names, values), hv.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, labels(h.labelNames, hv.labelValues), value(hv.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, labels(h.labelNames, hv.labelValues), hv.count)
+	}
+}
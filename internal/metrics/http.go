@@ -0,0 +1,26 @@
+package metrics
+
+// KeyErrorKind is the key of the request context value holding the kind of the error a handler responded with.
+const KeyErrorKind = "metrics.error_kind"
+
+// NewHTTP registers the metrics of the http requests.
+func NewHTTP(r *Registry) *HTTP {
+	return &HTTP{
+		Requests: r.NewCounterVec("http_requests_total",
+			"Number of http requests per route and status.", "method", "route", "status"),
+		Duration: r.NewHistogramVec("http_request_duration_seconds",
+			"Duration of the http requests per route.", DefBuckets, "method", "route"),
+		Errors: r.NewCounterVec("vehicle_errors_total",
+			"Number of vehicle errors responded per kind.", "kind"),
+	}
+}
+
+// HTTP is an struct that represents the metrics of the http requests.
+type HTTP struct {
+	// Requests counts the requests per method, route and status.
+	Requests *CounterVec
+	// Duration observes the latency of the requests per method and route.
+	Duration *HistogramVec
+	// Errors counts the errors responded per kind.
+	Errors *CounterVec
+}
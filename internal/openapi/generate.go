@@ -0,0 +1,211 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Route is an struct that represents the documentation of a route of the api.
+type Route struct {
+	// Method and Path are the route as registered in the router, with its parameters as :name.
+	Method string
+	Path   string
+	// OperationID is the unique name of the operation, used by the clients generated from the document.
+	OperationID string
+	Summary     string
+	Tags        []string
+	// Params are the path, query and header parameters. Every parameter of the path must be documented.
+	Params []Param
+	// Body is a value of the type of the request body, nil if the route takes none.
+	Body any
+	// Responses are a value of the type of the body of each status code: a go value encoded as json,
+	// a Content for any other media type, Contents for several media types, or nil for an empty body.
+	Responses map[int]any
+	// Auth is set when the route requires an api key.
+	Auth       bool
+	Deprecated bool
+}
+
+// Param is an struct that represents a parameter of a route.
+type Param struct {
+	Name        string
+	In          string
+	Description string
+	Required    bool
+	// Type is a value of the type of the parameter.
+	Type any
+	// Constraints are the constraints of the value, written as the openapi tag of a field: "minimum=1".
+	Constraints string
+}
+
+// Content is an struct that represents a body that is not json.
+type Content struct {
+	// Type is the media type of the body.
+	Type   string
+	Schema *Schema
+}
+
+// Contents are the bodies of a status code served in several media types, negotiated with the client.
+type Contents []any
+
+// Endpoint is an struct that represents a route registered in the router.
+type Endpoint struct {
+	Method string
+	Path   string
+}
+
+// Generate returns the document of the registered endpoints, described by the routes.
+// Every endpoint must be documented, and the routes that are not registered are left out of the document,
+// so it follows the features enabled in the router.
+func Generate(info Info, endpoints []Endpoint, routes []Route) (d *Document, err error) {
+	documented := make(map[Endpoint]Route, len(routes))
+	for _, r := range routes {
+		documented[Endpoint{Method: r.Method, Path: r.Path}] = r
+	}
+
+	d = &Document{OpenAPI: Version, Info: info, Paths: make(map[string]*PathItem)}
+	sc := newSchemas()
+	secured := false
+	ids := make(map[string]bool)
+	for _, e := range endpoints {
+		r, ok := documented[e]
+		if !ok {
+			err = fmt.Errorf("%w: %s %s", ErrOpenAPIRoute, e.Method, e.Path)
+			return
+		}
+		if ids[r.OperationID] {
+			err = fmt.Errorf("%w: operation id %s of %s %s already used", ErrOpenAPIRoute, r.OperationID, e.Method, e.Path)
+			return
+		}
+		ids[r.OperationID] = true
+
+		var path string
+		var op *Operation
+		path, op, err = operation(r, sc)
+		if err != nil {
+			return
+		}
+		item, ok := d.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			d.Paths[path] = item
+		}
+		(*item)[strings.ToLower(r.Method)] = op
+		secured = secured || r.Auth
+	}
+
+	if err = errors.Join(sc.errs...); err != nil {
+		return
+	}
+	d.Components.Schemas = sc.resolve()
+	if secured {
+		d.Components.SecuritySchemes = map[string]*SecurityScheme{
+			"bearer": {Type: "http", Scheme: "bearer"},
+			"apiKey": {Type: "apiKey", Name: "X-API-Key", In: "header"},
+		}
+	}
+	return
+}
+
+// operation returns the path of a route in the syntax of the document, and its operation.
+func operation(r Route, sc *schemas) (path string, op *Operation, err error) {
+	op = &Operation{
+		OperationID: r.OperationID,
+		Summary:     r.Summary,
+		Tags:        r.Tags,
+		Deprecated:  r.Deprecated,
+		Responses:   make(map[string]*Response, len(r.Responses)),
+	}
+
+	// -> the parameters of the path must be documented
+	params := make(map[string]bool)
+	for _, p := range r.Params {
+		params[p.In+":"+p.Name] = true
+	}
+	for _, s := range strings.Split(r.Path, "/") {
+		if name, ok := pathParam(s); ok && !params["path:"+name] {
+			err = fmt.Errorf("%w: parameter %s of %s %s", ErrOpenAPIRoute, name, r.Method, r.Path)
+			return
+		}
+	}
+	path = Path(r.Path)
+
+	for _, p := range r.Params {
+		schema := sc.of(p.Type)
+		if err = constrain(schema, p.Constraints); err != nil {
+			err = fmt.Errorf("%w of parameter %s of %s %s", err, p.Name, r.Method, r.Path)
+			return
+		}
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:        p.Name,
+			In:          p.In,
+			Description: p.Description,
+			Required:    p.Required || p.In == "path",
+			Schema:      schema,
+		})
+	}
+	if r.Body != nil {
+		op.RequestBody = &RequestBody{Required: true, Content: content(r.Body, sc)}
+	}
+
+	for code, body := range r.Responses {
+		res := &Response{Description: http.StatusText(code)}
+		if body != nil {
+			res.Content = content(body, sc)
+		}
+		op.Responses[strconv.Itoa(code)] = res
+	}
+
+	if r.Auth {
+		op.Security = []map[string][]string{{"bearer": {}}, {"apiKey": {}}}
+	}
+	return
+}
+
+// content returns the media types of a body: json unless it is a Content, or each of Contents.
+func content(body any, sc *schemas) map[string]*MediaType {
+	switch b := body.(type) {
+	case Content:
+		return map[string]*MediaType{b.Type: {Schema: b.Schema}}
+	case Contents:
+		m := make(map[string]*MediaType, len(b))
+		for _, c := range b {
+			for t, mt := range content(c, sc) {
+				m[t] = mt
+			}
+		}
+		return m
+	}
+	return map[string]*MediaType{"application/json": {Schema: sc.of(body)}}
+}
+
+// Path returns a path of the router, with its parameters as :name, in the syntax of the document: {name}.
+func Path(route string) string {
+	segments := strings.Split(route, "/")
+	for i, s := range segments {
+		if name, ok := pathParam(s); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// pathParam returns the name of the parameter of a segment of a path of the router, if it is one.
+func pathParam(segment string) (name string, ok bool) {
+	if !strings.HasPrefix(segment, ":") && !strings.HasPrefix(segment, "*") {
+		return
+	}
+	return segment[1:], true
+}
+
+// Operation returns the operation of a route of the router, or nil if it is not documented.
+func (d *Document) Operation(method, route string) *Operation {
+	item, ok := d.Paths[Path(route)]
+	if !ok {
+		return nil
+	}
+	return (*item)[strings.ToLower(method)]
+}
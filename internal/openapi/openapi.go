@@ -0,0 +1,128 @@
+// Package openapi generates the OpenAPI 3.1 document of the api from its route registrations and Go types.
+// Only the subset of the specification the api uses is modelled.
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// Version is the version of the OpenAPI specification of the documents.
+const Version = "3.1.0"
+
+var (
+	// ErrOpenAPIRoute is returned when a registered route is not documented, or not as registered.
+	ErrOpenAPIRoute = errors.New("openapi: undocumented route")
+	// ErrOpenAPIConstraint is returned when the constraints of a field or a parameter are malformed.
+	ErrOpenAPIConstraint = errors.New("openapi: malformed constraint")
+)
+
+// Document is an struct that represents an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info is an struct that represents the metadata of the api.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem are the operations of a path, keyed by lower case http method.
+type PathItem map[string]*Operation
+
+// Operation is an struct that represents an operation of a path.
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter is an struct that represents a path, query or header parameter of an operation.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is an struct that represents the body of the requests of an operation.
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is an struct that represents a response of an operation.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType is an struct that represents the schema of a content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components is an struct that represents the reusable schemas and the security schemes.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is an struct that represents how the requests are authenticated.
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+	Name   string `json:"name,omitempty"`
+	In     string `json:"in,omitempty"`
+}
+
+// Schema is an struct that represents a JSON Schema of the 2020-12 draft, as used by OpenAPI 3.1.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+
+	// typ is the go type of a reference, resolved to the name of its component once every schema is generated.
+	typ reflect.Type
+}
+
+// Types are the json types a schema allows, written as a single string when there is only one.
+type Types []string
+
+// MarshalJSON writes a single type as a string, and several as an array.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// JSON returns the document indented, as served and committed.
+func (d *Document) JSON() (data []byte, err error) {
+	data, err = json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+	return
+}
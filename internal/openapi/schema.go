@@ -0,0 +1,255 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// schemas generates the schemas of go values, following the rules of encoding/json.
+// Named structs are generated once as components, and referenced from every other schema.
+type schemas struct {
+	// components are the schemas of the named structs, by go type.
+	components map[reflect.Type]*Schema
+	// refs are the references to the components, resolved once every schema is generated.
+	refs []*Schema
+	// errs are the malformed constraints of the fields.
+	errs []error
+}
+
+// newSchemas returns an empty generator of schemas.
+func newSchemas() *schemas {
+	return &schemas{components: make(map[reflect.Type]*Schema)}
+}
+
+// of returns the schema of a value. The dynamic types of its interfaces are the ones of the value,
+// and a nil interface allows any value.
+func (s *schemas) of(v any) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	return s.value(reflect.ValueOf(v))
+}
+
+// value returns the schema of a value.
+// A struct with values in its interfaces is inlined, as its schema depends on the value and not only on its type.
+func (s *schemas) value(v reflect.Value) *Schema {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return &Schema{}
+		}
+		return s.value(v.Elem())
+	case reflect.Pointer:
+		if v.IsNil() {
+			return s.typ(v.Type().Elem())
+		}
+		return s.value(v.Elem())
+	case reflect.Struct:
+		if v.Type() == timeType || !dynamic(v) {
+			return s.typ(v.Type())
+		}
+		return s.object(v.Type(), func(i int) *Schema { return s.value(v.Field(i)) })
+	case reflect.Map:
+		if v.Len() == 0 || v.Type().Key().Kind() != reflect.String {
+			return s.typ(v.Type())
+		}
+		sc := &Schema{Type: Types{"object"}, Properties: make(map[string]*Schema)}
+		for _, k := range v.MapKeys() {
+			sc.Properties[k.String()] = s.value(v.MapIndex(k))
+			sc.Required = append(sc.Required, k.String())
+		}
+		sort.Strings(sc.Required)
+		return sc
+	}
+	return s.typ(v.Type())
+}
+
+// typ returns the schema of a type.
+func (s *schemas) typ(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return s.typ(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Slice, reflect.Array:
+		// -> a nil slice is encoded as null
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string"}, Format: "byte"}
+		}
+		sc := &Schema{Type: Types{"array"}, Items: s.typ(t.Elem())}
+		if t.Kind() == reflect.Slice {
+			sc.Type = append(sc.Type, "null")
+		}
+		return sc
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: s.typ(t.Elem())}
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: Types{"string"}, Format: "date-time"}
+		}
+		if t.Name() == "" {
+			return s.object(t, func(i int) *Schema { return s.typ(t.Field(i).Type) })
+		}
+		if _, ok := s.components[t]; !ok {
+			// -> registered before generating its fields, so recursive types end
+			s.components[t] = nil
+			s.components[t] = s.object(t, func(i int) *Schema { return s.typ(t.Field(i).Type) })
+		}
+		ref := &Schema{typ: t}
+		s.refs = append(s.refs, ref)
+		return ref
+	}
+	// -> interfaces, channels and functions allow any value
+	return &Schema{}
+}
+
+// object returns the schema of the exported fields of a struct, with the schema of each field given by field.
+// Embedded structs without a json name are flattened, and the fields without omitempty are required.
+func (s *schemas) object(t reflect.Type, field func(i int) *Schema) *Schema {
+	sc := &Schema{Type: Types{"object"}, Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, omitempty, ok := jsonName(f)
+		if !ok {
+			continue
+		}
+		fs := field(i)
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			embedded := fs
+			if fs.typ != nil {
+				embedded = s.components[fs.typ]
+			}
+			for k, v := range embedded.Properties {
+				sc.Properties[k] = v
+			}
+			sc.Required = append(sc.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if err := constrain(fs, f.Tag.Get("openapi")); err != nil {
+			s.errs = append(s.errs, fmt.Errorf("%w of field %s of %s", err, f.Name, t))
+		}
+		sc.Properties[name] = fs
+		if !omitempty {
+			sc.Required = append(sc.Required, name)
+		}
+	}
+	sort.Strings(sc.Required)
+	return sc
+}
+
+// resolve names the components after the go types, prefixed by their package when two types share a name,
+// and points the references to them.
+func (s *schemas) resolve() (components map[string]*Schema) {
+	count := make(map[string]int)
+	for t := range s.components {
+		count[t.Name()]++
+	}
+	names := make(map[reflect.Type]string)
+	components = make(map[string]*Schema)
+	for t, sc := range s.components {
+		name := t.Name()
+		if count[name] > 1 {
+			pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+			name = string(unicode.ToUpper(rune(pkg[0]))) + pkg[1:] + name
+		}
+		names[t] = name
+		components[name] = sc
+	}
+	for _, ref := range s.refs {
+		ref.Ref = "#/components/schemas/" + names[ref.typ]
+	}
+	return
+}
+
+// timeType is the type of the times, encoded as RFC 3339 strings.
+var timeType = reflect.TypeOf(time.Time{})
+
+// jsonName returns the json name of a field and whether it is omitted when empty.
+// The name is empty for the fields without one, and ok is false for the fields that are not encoded.
+func jsonName(f reflect.StructField) (name string, omitempty, ok bool) {
+	if !f.IsExported() && !(f.Anonymous && f.Type.Kind() == reflect.Struct) {
+		return
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	omitempty = strings.Contains(","+opts+",", ",omitempty,")
+	ok = true
+	return
+}
+
+// dynamic returns whether the schema of a struct depends on the value, as some of its interfaces are not nil.
+func dynamic(v reflect.Value) bool {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if _, _, ok := jsonName(f); ok && f.Type.Kind() == reflect.Interface && !v.Field(i).IsNil() {
+			return true
+		}
+	}
+	return false
+}
+
+// constants are the named values of the constraints, by name.
+var constants = make(map[string]float64)
+
+// Define names a value the constraints of the numbers may take in place of a literal, as maximum=max_speed,
+// so a bound defined once constrains the document of the api too. It is called from the init of the packages
+// whose types are constrained with it.
+func Define(name string, value float64) {
+	constants[name] = value
+}
+
+// constrain sets the constraints of a schema, written as comma separated name=value pairs:
+// minimum and maximum for the numbers, either a number or the name of a constant, minLength for the strings.
+func constrain(sc *Schema, constraints string) (err error) {
+	if constraints == "" {
+		return
+	}
+	for _, c := range strings.Split(constraints, ",") {
+		name, value, _ := strings.Cut(c, "=")
+		switch name {
+		case "minimum", "maximum":
+			n, ok := constants[value]
+			if !ok {
+				n, err = strconv.ParseFloat(value, 64)
+			}
+			if err != nil {
+				err = fmt.Errorf("%w %q", ErrOpenAPIConstraint, c)
+				return
+			}
+			if name == "minimum" {
+				sc.Minimum = &n
+			} else {
+				sc.Maximum = &n
+			}
+		case "minLength":
+			var n int
+			if n, err = strconv.Atoi(value); err != nil {
+				err = fmt.Errorf("%w %q", ErrOpenAPIConstraint, c)
+				return
+			}
+			sc.MinLength = &n
+		default:
+			err = fmt.Errorf("%w %q", ErrOpenAPIConstraint, c)
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,265 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidationError is an struct that represents a value of a request or a response that does not match the document.
+type ValidationError struct {
+	// In is where the value is: path, query, header or body.
+	In string `json:"in"`
+	// Field is the name of the parameter, empty for the body.
+	Field string `json:"field,omitempty"`
+	// Pointer is the JSON pointer to the value within the body, empty for the whole body and the parameters.
+	Pointer string `json:"pointer,omitempty"`
+	// Message tells what is wrong with the value.
+	Message string `json:"message"`
+}
+
+// String returns the error as its location followed by the message.
+func (e ValidationError) String() string {
+	location := e.In
+	if e.Field != "" {
+		location += " " + e.Field
+	}
+	return location + " " + e.Pointer + ": " + e.Message
+}
+
+// ValidateParams returns the errors of the parameters of a request, given by value.
+// value returns the raw value of a parameter and whether the request has it.
+func (d *Document) ValidateParams(op *Operation, value func(in, name string) (raw string, ok bool)) (errs []ValidationError) {
+	for _, p := range op.Parameters {
+		raw, ok := value(p.In, p.Name)
+		if !ok {
+			if p.Required {
+				errs = append(errs, ValidationError{In: p.In, Field: p.Name, Message: "is required"})
+			}
+			continue
+		}
+		v, err := d.parse(p.Schema, raw)
+		if err != nil {
+			errs = append(errs, ValidationError{In: p.In, Field: p.Name, Message: err.Error()})
+			continue
+		}
+		for _, e := range d.validate(p.Schema, v, "") {
+			e.In, e.Field = p.In, p.Name
+			errs = append(errs, e)
+		}
+	}
+	return
+}
+
+// ValidateBody returns the errors of the json body of a request.
+func (d *Document) ValidateBody(op *Operation, body []byte) (errs []ValidationError) {
+	if op.RequestBody == nil {
+		return
+	}
+	mt, ok := op.RequestBody.Content["application/json"]
+	if !ok {
+		return
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			errs = append(errs, ValidationError{In: "body", Message: "is required"})
+		}
+		return
+	}
+	v, err := decode(body)
+	if err != nil {
+		errs = append(errs, ValidationError{In: "body", Message: err.Error()})
+		return
+	}
+	for _, e := range d.validate(mt.Schema, v, "") {
+		e.In = "body"
+		errs = append(errs, e)
+	}
+	return
+}
+
+// ValidateResponse returns the errors of a response of an operation: a status code it does not document,
+// or a json body that does not match the schema of the status code.
+func (d *Document) ValidateResponse(op *Operation, code int, contentType string, body []byte) (errs []ValidationError) {
+	res, ok := op.Responses[strconv.Itoa(code)]
+	if !ok {
+		errs = append(errs, ValidationError{In: "status", Message: fmt.Sprintf("%d is not documented", code)})
+		return
+	}
+	if len(res.Content) == 0 || len(body) == 0 {
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	mt, ok := res.Content[mediaType]
+	if !ok {
+		errs = append(errs, ValidationError{In: "header", Field: "Content-Type", Message: fmt.Sprintf("%s is not documented", mediaType)})
+		return
+	}
+	if mediaType != "application/json" {
+		return
+	}
+	v, err := decode(body)
+	if err != nil {
+		errs = append(errs, ValidationError{In: "body", Message: err.Error()})
+		return
+	}
+	for _, e := range d.validate(mt.Schema, v, "") {
+		e.In = "body"
+		errs = append(errs, e)
+	}
+	return
+}
+
+// decode decodes a json value, keeping its numbers as written.
+func decode(data []byte) (v any, err error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err = dec.Decode(&v); err != nil {
+		err = fmt.Errorf("malformed json: %v", err)
+		return
+	}
+	if _, err = dec.Token(); !errors.Is(err, io.EOF) {
+		err = errors.New("malformed json: data after the value")
+		return
+	}
+	err = nil
+	return
+}
+
+// parse returns the value of a raw parameter as the type of its schema.
+func (d *Document) parse(sc *Schema, raw string) (v any, err error) {
+	sc = d.resolve(sc)
+	switch {
+	case sc.Type.has("integer"):
+		if _, e := strconv.ParseInt(raw, 10, 64); e != nil {
+			err = errors.New("must be an integer")
+			return
+		}
+		v = json.Number(raw)
+	case sc.Type.has("number"):
+		f, e := strconv.ParseFloat(raw, 64)
+		if e != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			err = errors.New("must be a number")
+			return
+		}
+		v = json.Number(raw)
+	case sc.Type.has("boolean"):
+		b, e := strconv.ParseBool(raw)
+		if e != nil {
+			err = errors.New("must be a boolean")
+			return
+		}
+		v = b
+	default:
+		v = raw
+	}
+	return
+}
+
+// resolve returns the component a schema references, or the schema itself.
+func (d *Document) resolve(sc *Schema) *Schema {
+	for sc.Ref != "" {
+		sc = d.Components.Schemas[strings.TrimPrefix(sc.Ref, "#/components/schemas/")]
+	}
+	return sc
+}
+
+// validate returns the errors of a decoded json value against a schema, located by their pointer.
+func (d *Document) validate(sc *Schema, v any, pointer string) (errs []ValidationError) {
+	sc = d.resolve(sc)
+	fail := func(format string, args ...any) []ValidationError {
+		return append(errs, ValidationError{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+	if len(sc.Type) > 0 && !sc.Type.match(v) {
+		return fail("must be %s", strings.Join(sc.Type, " or "))
+	}
+
+	switch v := v.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		if sc.Minimum != nil && f < *sc.Minimum {
+			return fail("must not be lower than %v", *sc.Minimum)
+		}
+		if sc.Maximum != nil && f > *sc.Maximum {
+			return fail("must not be greater than %v", *sc.Maximum)
+		}
+	case string:
+		if sc.MinLength != nil && len([]rune(v)) < *sc.MinLength {
+			if *sc.MinLength == 1 {
+				return fail("must not be empty")
+			}
+			return fail("must have at least %d characters", *sc.MinLength)
+		}
+	case []any:
+		if sc.Items != nil {
+			for i, item := range v {
+				errs = append(errs, d.validate(sc.Items, item, pointer+"/"+strconv.Itoa(i))...)
+			}
+		}
+	case map[string]any:
+		for _, name := range sc.Required {
+			if _, ok := v[name]; !ok {
+				errs = append(errs, ValidationError{Pointer: pointer + "/" + escape(name), Message: "is required"})
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := sc.Properties[name]; ok {
+				errs = append(errs, d.validate(property, v[name], pointer+"/"+escape(name))...)
+			} else if sc.AdditionalProperties != nil {
+				errs = append(errs, d.validate(sc.AdditionalProperties, v[name], pointer+"/"+escape(name))...)
+			}
+		}
+	}
+	return
+}
+
+// has returns whether the types have the type.
+func (t Types) has(typ string) bool {
+	for _, tt := range t {
+		if tt == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// match returns whether a decoded json value has any of the types.
+// An integer is a number without a fraction nor an exponent, as the handlers bind it.
+func (t Types) match(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return t.has("null")
+	case bool:
+		return t.has("boolean")
+	case string:
+		return t.has("string")
+	case json.Number:
+		if t.has("number") {
+			return true
+		}
+		_, err := strconv.ParseInt(string(v), 10, 64)
+		return t.has("integer") && err == nil
+	case []any:
+		return t.has("array")
+	case map[string]any:
+		return t.has("object")
+	}
+	return false
+}
+
+// escape escapes a name as a reference token of a JSON pointer.
+func escape(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
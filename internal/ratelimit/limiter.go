@@ -0,0 +1,23 @@
+package ratelimit
+
+import "time"
+
+// Result is an struct that represents the outcome of a rate limit check.
+type Result struct {
+	// Allowed is true if the request can go on.
+	Allowed bool
+	// Limit is the maximum number of requests the key can burst.
+	Limit int
+	// Remaining is the number of requests the key can still make right away.
+	Remaining int
+	// Reset is the time left until the key recovers its whole limit.
+	Reset time.Duration
+	// RetryAfter is the time left until the key can make the next request, when not allowed.
+	RetryAfter time.Duration
+}
+
+// Limiter is the interface that wraps the basic methods for a rate limiter.
+type Limiter interface {
+	// Allow consumes a request of the key.
+	Allow(key string) (r Result)
+}
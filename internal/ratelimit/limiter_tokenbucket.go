@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepEvery is the number of checks between the removal of the idle buckets.
+const sweepEvery = 1024
+
+// NewLimiterTokenBucket returns a new instance of a token bucket rate limiter.
+// Every key refills rate tokens per second up to burst tokens.
+func NewLimiterTokenBucket(rate float64, burst int) *LimiterTokenBucket {
+	return &LimiterTokenBucket{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// LimiterTokenBucket is an struct that implements the Limiter interface.
+type LimiterTokenBucket struct {
+	// rate is the number of tokens refilled per second.
+	rate float64
+	// burst is the capacity of every bucket.
+	burst int
+	// buckets are the buckets of every key.
+	buckets map[string]*bucket
+	// checks counts the checks since the last sweep.
+	checks int
+	// now returns the current time.
+	now func() time.Time
+	mu  sync.Mutex
+}
+
+// bucket is an struct that represents the tokens left for a key.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Allow consumes a request of the key.
+func (l *LimiterTokenBucket) Allow(key string) (r Result) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(l.burst), b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	r.Limit = l.burst
+	if b.tokens >= 1 {
+		b.tokens--
+		r.Allowed = true
+	} else {
+		r.RetryAfter = l.duration(1 - b.tokens)
+	}
+	r.Remaining = int(b.tokens)
+	r.Reset = l.duration(float64(l.burst) - b.tokens)
+	return
+}
+
+// duration returns the time needed to refill the tokens.
+func (l *LimiterTokenBucket) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// sweep removes the buckets that are full again, as they are equivalent to a new bucket.
+func (l *LimiterTokenBucket) sweep(now time.Time) {
+	l.checks++
+	if l.checks < sweepEvery {
+		return
+	}
+	l.checks = 0
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// NewQuotaDaily returns a new instance of a daily quota of requests per key.
+// A limit of zero only counts the requests.
+func NewQuotaDaily(limit int) *QuotaDaily {
+	return &QuotaDaily{limit: limit, counters: make(map[string]int), now: time.Now}
+}
+
+// QuotaDaily is an struct that represents the daily counters of requests per key.
+// The counters reset at midnight UTC.
+type QuotaDaily struct {
+	// limit is the maximum number of requests of a key per day.
+	limit int
+	// day is the day of the counters.
+	day string
+	// counters are the requests made by every key during the day.
+	counters map[string]int
+	// now returns the current time.
+	now func() time.Time
+	mu  sync.Mutex
+}
+
+// QuotaUsage is an struct that represents the usage of the daily quota of a key.
+type QuotaUsage struct {
+	Key   string `json:"key"`
+	Used  int    `json:"used"`
+	Limit int    `json:"limit"`
+}
+
+// Consume counts a request of the key and returns false if the key already spent its quota.
+// When the quota is spent it also returns the time left until the counters reset.
+func (q *QuotaDaily) Consume(key string) (ok bool, reset time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.rotate()
+	if q.limit > 0 && q.counters[key] >= q.limit {
+		reset = now.Truncate(24 * time.Hour).Add(24 * time.Hour).Sub(now)
+		return
+	}
+	q.counters[key]++
+	ok = true
+	return
+}
+
+// Usage returns the usage of the daily quota of every key.
+func (q *QuotaDaily) Usage() (day string, u []QuotaUsage) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rotate()
+	day = q.day
+	u = make([]QuotaUsage, 0, len(q.counters))
+	for key, used := range q.counters {
+		u = append(u, QuotaUsage{Key: key, Used: used, Limit: q.limit})
+	}
+	sort.Slice(u, func(i, j int) bool { return u[i].Key < u[j].Key })
+	return
+}
+
+// rotate resets the counters when the day changed and returns the current time.
+func (q *QuotaDaily) rotate() (now time.Time) {
+	now = q.now().UTC()
+	if day := now.Format("2006-01-02"); day != q.day {
+		q.day = day
+		q.counters = make(map[string]int)
+	}
+	return
+}
@@ -0,0 +1,37 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Default is the tenant of the requests that do not resolve to any tenant.
+const Default = "default"
+
+// Resolver is the interface that wraps the basic methods for a tenant resolver.
+type Resolver interface {
+	// Resolve returns the tenant of the request.
+	Resolve(r *http.Request) (id string, err error)
+}
+
+var (
+	// ErrTenantNotResolved is returned when the request does not carry a tenant.
+	ErrTenantNotResolved = errors.New("tenant: not resolved")
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries the tenant.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant carried by ctx, or Default if there is none.
+func FromContext(ctx context.Context) (id string) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	if !ok {
+		id = Default
+	}
+	return
+}
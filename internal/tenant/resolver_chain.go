@@ -0,0 +1,30 @@
+package tenant
+
+import (
+	"errors"
+	"net/http"
+)
+
+// NewResolverChain returns a new instance of a tenant resolver that tries every resolver in order.
+func NewResolverChain(rs ...Resolver) *ResolverChain {
+	return &ResolverChain{rs: rs}
+}
+
+// ResolverChain is an struct that implements the Resolver interface.
+type ResolverChain struct {
+	// rs are the resolvers tried in order.
+	rs []Resolver
+}
+
+// Resolve returns the tenant of the first resolver that resolves the request.
+func (r *ResolverChain) Resolve(req *http.Request) (id string, err error) {
+	for _, rs := range r.rs {
+		id, err = rs.Resolve(req)
+		if errors.Is(err, ErrTenantNotResolved) {
+			continue
+		}
+		return
+	}
+	err = ErrTenantNotResolved
+	return
+}
@@ -0,0 +1,25 @@
+package tenant
+
+import (
+	"app/internal/auth"
+	"net/http"
+)
+
+// NewResolverClaim returns a new instance of a tenant resolver that reads the tenant claim of the principal.
+func NewResolverClaim() *ResolverClaim {
+	return &ResolverClaim{}
+}
+
+// ResolverClaim is an struct that implements the Resolver interface.
+type ResolverClaim struct{}
+
+// Resolve returns the tenant of the request.
+func (r *ResolverClaim) Resolve(req *http.Request) (id string, err error) {
+	principal := auth.PrincipalFromContext(req.Context())
+	if principal == nil || principal.Tenant == "" {
+		err = ErrTenantNotResolved
+		return
+	}
+	id = principal.Tenant
+	return
+}
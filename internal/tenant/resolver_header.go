@@ -0,0 +1,24 @@
+package tenant
+
+import "net/http"
+
+// NewResolverHeader returns a new instance of a tenant resolver that reads a header.
+func NewResolverHeader(header string) *ResolverHeader {
+	return &ResolverHeader{Header: header}
+}
+
+// ResolverHeader is an struct that implements the Resolver interface.
+type ResolverHeader struct {
+	// Header is the name of the header carrying the tenant.
+	Header string
+}
+
+// Resolve returns the tenant of the request.
+func (r *ResolverHeader) Resolve(req *http.Request) (id string, err error) {
+	id = req.Header.Get(r.Header)
+	if id == "" {
+		err = ErrTenantNotResolved
+		return
+	}
+	return
+}
@@ -0,0 +1,40 @@
+package tenant
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// NewResolverSubdomain returns a new instance of a tenant resolver that reads the subdomain of the host.
+func NewResolverSubdomain(domain string) *ResolverSubdomain {
+	return &ResolverSubdomain{Domain: domain}
+}
+
+// ResolverSubdomain is an struct that implements the Resolver interface.
+// The request to acme.fleet.example.com resolves to the tenant acme for the domain fleet.example.com.
+type ResolverSubdomain struct {
+	// Domain is the parent domain of the tenant subdomains.
+	Domain string
+}
+
+// Resolve returns the tenant of the request.
+func (r *ResolverSubdomain) Resolve(req *http.Request) (id string, err error) {
+	host := req.Host
+	if h, _, e := net.SplitHostPort(host); e == nil {
+		host = h
+	}
+
+	suffix := "." + r.Domain
+	if r.Domain == "" || !strings.HasSuffix(host, suffix) {
+		err = ErrTenantNotResolved
+		return
+	}
+	id = strings.TrimSuffix(host, suffix)
+	if id == "" || strings.Contains(id, ".") {
+		id = ""
+		err = ErrTenantNotResolved
+		return
+	}
+	return
+}
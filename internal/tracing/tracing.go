@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// ErrTracingConfig is returned when the tracing configuration is invalid.
+	ErrTracingConfig = errors.New("tracing: invalid configuration")
+)
+
+// Config is an struct that represents the configuration of the tracing.
+type Config struct {
+	// Exporter is where the spans are exported: stdout, file, or empty to disable the export.
+	Exporter string
+	// File is the path of the file the spans are written to, for the file exporter.
+	File string
+	// ServiceName is the name of the service reported in the spans.
+	ServiceName string
+}
+
+// Setup installs the global tracer provider and the W3C trace context propagator.
+// The returned shutdown flushes the pending spans and closes the exporter.
+func Setup(cfg Config) (shutdown func(ctx context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var w io.Writer
+	var closer io.Closer
+	switch cfg.Exporter {
+	case "":
+		// the global provider stays a no-op, the incoming trace context is still propagated
+		shutdown = func(context.Context) error { return nil }
+		return
+	case "stdout":
+		w = os.Stdout
+	case "file":
+		var f *os.File
+		f, err = os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			err = fmt.Errorf("%w. %v", ErrTracingConfig, err)
+			return
+		}
+		w, closer = f, f
+	default:
+		err = fmt.Errorf("%w. unknown exporter %q", ErrTracingConfig, cfg.Exporter)
+		return
+	}
+
+	exp, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrTracingConfig, err)
+		return
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName))),
+	)
+	otel.SetTracerProvider(tp)
+
+	shutdown = func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closer != nil {
+			err = errors.Join(err, closer.Close())
+		}
+		return err
+	}
+	return
+}
+
+// End records the error, if any, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,83 @@
+// Package events publishes the changes made to the vehicles of every tenant to the subscribers of each tenant.
+package events
+
+import (
+	"app/internal/domain"
+	"context"
+	"sync"
+)
+
+// Kind is the kind of a change.
+type Kind string
+
+const (
+	// KindCreated is the kind of the vehicles added.
+	KindCreated Kind = "created"
+	// KindUpdated is the kind of the vehicles whose max speed was updated.
+	KindUpdated Kind = "updated"
+	// KindDeleted is the kind of the vehicles deleted.
+	KindDeleted Kind = "deleted"
+)
+
+// Event is an struct that represents a change made to a vehicle.
+type Event struct {
+	Kind Kind
+	// Tenant is the tenant of the vehicle.
+	Tenant string
+	// Vehicle is the vehicle after the change, or as it was before being deleted.
+	Vehicle *domain.Vehicle
+}
+
+// NewBus returns a new instance of a bus whose subscribers buffer up to buffer events.
+func NewBus(buffer int) *Bus {
+	return &Bus{buffer: buffer, subscribers: make(map[*subscriber]struct{})}
+}
+
+// Bus is an struct that represents the delivery of the events to their subscribers.
+// Publishing never blocks: a subscriber whose buffer is full misses the events until it catches up.
+type Bus struct {
+	// buffer is the number of events a subscriber buffers.
+	buffer int
+	// mu guards subscribers.
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+// subscriber is an struct that represents a subscription to the events of a tenant.
+type subscriber struct {
+	tenant string
+	ch     chan Event
+}
+
+// Publish delivers the event to the subscribers of its tenant.
+func (b *Bus) Publish(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subscribers {
+		if s.tenant != e.Tenant {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+		}
+	}
+}
+
+// Subscribe returns the events of the tenant published from now on.
+// The channel is closed once the context is done.
+func (b *Bus) Subscribe(ctx context.Context, tenant string) <-chan Event {
+	s := &subscriber{tenant: tenant, ch: make(chan Event, b.buffer)}
+	b.mu.Lock()
+	b.subscribers[s] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subscribers, s)
+		b.mu.Unlock()
+		close(s.ch)
+	}()
+	return s.ch
+}
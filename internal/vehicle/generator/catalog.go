@@ -0,0 +1,56 @@
+package generator
+
+// Brand is an struct that represents a brand of the catalog and its models.
+type Brand struct {
+	// Name is the name of the brand.
+	Name string
+	// Models are the models of the brand, picked uniformly.
+	Models []string
+}
+
+// Catalog are the brands and models of the bundled datasets.
+var Catalog = []Brand{
+	{"Acura", []string{"NSX", "TL", "MDX", "Integra", "RL"}},
+	{"BMW", []string{"3 Series", "5 Series", "7 Series", "X5", "M3", "Z4"}},
+	{"Bentley", []string{"Mulsanne", "Continental", "Arnage", "Azure"}},
+	{"Buick", []string{"Roadmaster", "Century", "Regal", "LaCrosse", "LeSabre"}},
+	{"Chevrolet", []string{"Camaro", "Cavalier", "G-Series 2500", "Suburban 2500", "HHR", "Corvette", "Malibu", "Silverado 1500"}},
+	{"Dodge", []string{"Ram 1500 Club", "Ram Van 3500", "Journey", "Viper", "Caravan", "Dakota"}},
+	{"Ford", []string{"Escape", "Escort", "Mustang", "Crown Victoria", "Ranger", "F150", "Explorer", "Taurus"}},
+	{"GMC", []string{"3500 Club Coupe", "Sierra 3500", "1500 Club Coupe", "Yukon XL 2500", "Yukon", "Savana 1500"}},
+	{"Honda", []string{"Accord", "Civic", "CR-V", "Odyssey", "Prelude"}},
+	{"Hummer", []string{"H1", "H2", "H3"}},
+	{"Kia", []string{"Sorento", "Spectra", "Sportage", "Rio"}},
+	{"Land Rover", []string{"Discovery", "Range Rover", "Defender", "Freelander"}},
+	{"Lexus", []string{"GS", "SC", "ES", "LS", "RX"}},
+	{"Mazda", []string{"B-Series", "Mazda3", "323", "MX-5", "RX-8", "Tribute"}},
+	{"Mercedes-Benz", []string{"E-Class", "S-Class", "C-Class", "SL-Class", "G-Class"}},
+	{"Mitsubishi", []string{"Challenger", "Montero", "Lancer", "Eclipse", "Galant"}},
+	{"Nissan", []string{"Altima", "Maxima", "Pathfinder", "Frontier", "350Z"}},
+	{"Pontiac", []string{"Grand Prix", "Firebird", "Bonneville", "Sunfire", "Vibe"}},
+	{"Suzuki", []string{"Swift", "XL-7", "SJ", "Grand Vitara", "Sidekick"}},
+	{"Toyota", []string{"Camry", "Previa", "Tacoma", "Avalon", "RAV4", "Corolla", "Land Cruiser"}},
+	{"Volkswagen", []string{"Cabriolet", "Eos", "Golf", "Jetta", "Passat", "Touareg"}},
+}
+
+// Colors are the colors of the bundled datasets, picked uniformly.
+var Colors = []string{
+	"Aquamarine", "Blue", "Crimson", "Fuscia", "Goldenrod", "Green", "Indigo", "Khaki", "Maroon", "Mauv",
+	"Orange", "Pink", "Puce", "Purple", "Red", "Teal", "Turquoise", "Violet", "Yellow",
+}
+
+// FuelTypes are the fuel types of the bundled datasets.
+var FuelTypes = []string{"biodiesel", "diesel", "gas", "gasoline"}
+
+// Transmissions are the transmissions of the bundled datasets, picked uniformly.
+var Transmissions = []string{"automatic", "manual", "semi-automatic"}
+
+// models returns the models of a brand of the catalog, or a generic one for the other brands.
+func models(brand string) []string {
+	for _, b := range Catalog {
+		if b.Name == brand {
+			return b.Models
+		}
+	}
+	return []string{"Standard"}
+}
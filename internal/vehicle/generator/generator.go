@@ -0,0 +1,351 @@
+// Package generator produces synthetic fleets for load and scale tests: arbitrarily large, reproducible for a seed,
+// shaped by configurable distributions and with deliberate anomalies to exercise the validation of the loaders.
+package generator
+
+import (
+	"app/internal/domain"
+	"app/internal/vehicle/saver"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrGeneratorInvalid is returned when the configuration of a generator is invalid.
+	ErrGeneratorInvalid = errors.New("generator: invalid config")
+)
+
+// Anomaly is the kind of a deliberate problem of a generated vehicle.
+type Anomaly string
+
+const (
+	// AnomalyDuplicateId repeats the id of an earlier vehicle.
+	AnomalyDuplicateId Anomaly = "duplicate_id"
+	// AnomalyDuplicateRegistration repeats the registration of an earlier vehicle.
+	AnomalyDuplicateRegistration Anomaly = "duplicate_registration"
+	// AnomalyInvalidMaxSpeed sets a negative or a too high max speed.
+	AnomalyInvalidMaxSpeed Anomaly = "invalid_max_speed"
+	// AnomalyMissingBrand leaves the brand empty.
+	AnomalyMissingBrand Anomaly = "missing_brand"
+	// AnomalyMissingRegistration leaves the registration empty.
+	AnomalyMissingRegistration Anomaly = "missing_registration"
+)
+
+// Anomalies are the kinds of anomalies, in the order they are drawn.
+var Anomalies = []Anomaly{
+	AnomalyDuplicateId,
+	AnomalyDuplicateRegistration,
+	AnomalyInvalidMaxSpeed,
+	AnomalyMissingBrand,
+	AnomalyMissingRegistration,
+}
+
+// Weighted is an struct that represents a value picked with a probability proportional to its weight.
+type Weighted struct {
+	Value  string
+	Weight float64
+}
+
+// Distribution is an struct that represents how a number is drawn.
+type Distribution struct {
+	// Kind is uniform, between Min and Max, or normal, clamped to them.
+	Kind string
+	// Min and Max are the bounds of the numbers.
+	Min, Max float64
+	// Mean and StdDev shape the normal distribution.
+	Mean, StdDev float64
+}
+
+// Config is an struct that represents what a generator produces.
+type Config struct {
+	// Count is the number of vehicles.
+	Count int
+	// Seed makes the vehicles reproducible: the same configuration and seed produce the same vehicles.
+	Seed int64
+	// StartId is the id of the first vehicle, the next ones are consecutive.
+	StartId int
+	// Brands are the brands and their weights, the catalog with equal weights if empty.
+	Brands []Weighted
+	// FuelTypes are the fuel types and their weights, the bundled ones with equal weights if empty.
+	FuelTypes []Weighted
+	// Year is the distribution of the fabrication years.
+	Year Distribution
+	// Weight is the distribution of the weights.
+	Weight Distribution
+	// Rates are the probabilities of each vehicle having an anomaly, a vehicle has one at most.
+	Rates map[Anomaly]float64
+}
+
+// Default returns the configuration resembling the bundled datasets.
+func Default() *Config {
+	return &Config{
+		Count:   1000,
+		Seed:    1,
+		StartId: 1,
+		Year:    Distribution{Kind: "normal", Min: 1957, Max: 2013, Mean: 2000, StdDev: 9},
+		Weight:  Distribution{Kind: "uniform", Min: 1, Max: 300},
+	}
+}
+
+// Validate returns an error wrapping ErrGeneratorInvalid for every problem of the configuration.
+func (c *Config) Validate() (err error) {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w. "+format, append([]any{ErrGeneratorInvalid}, args...)...))
+	}
+
+	if c.Count < 0 {
+		fail("count %d must not be negative", c.Count)
+	}
+	if c.StartId <= 0 {
+		fail("start id %d must be positive", c.StartId)
+	}
+	for name, weights := range map[string][]Weighted{"brand": c.Brands, "fuel type": c.FuelTypes} {
+		for _, w := range weights {
+			if w.Value == "" || w.Weight <= 0 {
+				fail("%s %q must have a name and a positive weight", name, w.Value)
+			}
+		}
+	}
+	for name, d := range map[string]Distribution{"year": c.Year, "weight": c.Weight} {
+		switch {
+		case d.Kind != "uniform" && d.Kind != "normal":
+			fail("%s distribution %q must be uniform or normal", name, d.Kind)
+		case d.Min > d.Max:
+			fail("%s min %v must not be greater than max %v", name, d.Min, d.Max)
+		case d.Kind == "normal" && d.StdDev < 0:
+			fail("%s stddev %v must not be negative", name, d.StdDev)
+		}
+	}
+	var total float64
+	for anomaly, rate := range c.Rates {
+		if !isAnomaly(anomaly) {
+			fail("unknown anomaly %q", anomaly)
+		}
+		if rate < 0 || rate > 1 {
+			fail("rate %v of %s must be between 0 and 1", rate, anomaly)
+		}
+		total += rate
+	}
+	if total > 1 {
+		fail("the anomaly rates add up to %v, more than 1", total)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Stats is an struct that represents what a generator produced.
+type Stats struct {
+	// Vehicles is the number of vehicles.
+	Vehicles int
+	// Anomalies is the number of vehicles with each kind of anomaly.
+	Anomalies map[Anomaly]int
+}
+
+// NewGenerator returns a new generator of the configuration.
+func NewGenerator(cfg Config) (g *Generator, err error) {
+	if err = cfg.Validate(); err != nil {
+		return
+	}
+	if len(cfg.Brands) == 0 {
+		for _, b := range Catalog {
+			cfg.Brands = append(cfg.Brands, Weighted{Value: b.Name, Weight: 1})
+		}
+	}
+	if len(cfg.FuelTypes) == 0 {
+		for _, f := range FuelTypes {
+			cfg.FuelTypes = append(cfg.FuelTypes, Weighted{Value: f, Weight: 1})
+		}
+	}
+
+	g = &Generator{
+		cfg:       cfg,
+		rd:        rand.New(rand.NewSource(cfg.Seed)),
+		brands:    newPicker(cfg.Brands),
+		fuelTypes: newPicker(cfg.FuelTypes),
+		stats:     Stats{Anomalies: make(map[Anomaly]int)},
+	}
+	return
+}
+
+// Generator is an struct that produces the vehicles of a configuration one at a time.
+// It is not safe for concurrent use.
+type Generator struct {
+	cfg Config
+	// rd is the only source of randomness, seeded by the configuration.
+	rd *rand.Rand
+	// brands and fuelTypes pick the weighted values.
+	brands, fuelTypes *picker
+	// stats is what was produced so far.
+	stats Stats
+}
+
+// Next returns the next vehicle, and the anomaly it has if any.
+// The vehicles are not bounded by the count of the configuration, Write is.
+func (g *Generator) Next() (v *domain.Vehicle, anomaly Anomaly) {
+	n := g.stats.Vehicles
+	brand := g.brands.pick(g.rd)
+	models := models(brand)
+	v = &domain.Vehicle{
+		Id: g.cfg.StartId + n,
+		Attributes: domain.VehicleAttributes{
+			Brand:        brand,
+			Model:        models[g.rd.Intn(len(models))],
+			Registration: registration(n),
+			Year:         int(math.Round(g.cfg.Year.draw(g.rd))),
+			Color:        Colors[g.rd.Intn(len(Colors))],
+			MaxSpeed:     80 + g.rd.Intn(171),
+			FuelType:     g.fuelTypes.pick(g.rd),
+			Transmission: Transmissions[g.rd.Intn(len(Transmissions))],
+			Passengers:   1 + g.rd.Intn(6),
+			Height:       round(1 + g.rd.Float64()*299),
+			Width:        round(1 + g.rd.Float64()*299),
+			Weight:       round(g.cfg.Weight.draw(g.rd)),
+		},
+	}
+
+	// anomaly, the earlier vehicles are repeated only once there is one
+	anomaly = g.anomaly()
+	switch {
+	case anomaly == AnomalyDuplicateId && n > 0:
+		v.Id = g.cfg.StartId + g.rd.Intn(n)
+	case anomaly == AnomalyDuplicateRegistration && n > 0:
+		v.Attributes.Registration = registration(g.rd.Intn(n))
+	case anomaly == AnomalyInvalidMaxSpeed:
+		v.Attributes.MaxSpeed = domain.MaxSpeedLimit + 1 + g.rd.Intn(600)
+		if g.rd.Intn(2) == 0 {
+			v.Attributes.MaxSpeed = -v.Attributes.MaxSpeed
+		}
+	case anomaly == AnomalyMissingBrand:
+		v.Attributes.Brand = ""
+	case anomaly == AnomalyMissingRegistration:
+		v.Attributes.Registration = ""
+	default:
+		anomaly = ""
+	}
+
+	g.stats.Vehicles++
+	if anomaly != "" {
+		g.stats.Anomalies[anomaly]++
+	}
+	return
+}
+
+// Write encodes the count of vehicles of the configuration and ends the data written.
+func (g *Generator) Write(enc saver.Encoder) (st Stats, err error) {
+	for i := 0; i < g.cfg.Count; i++ {
+		v, _ := g.Next()
+		if err = enc.Encode(v); err != nil {
+			return
+		}
+	}
+	if err = enc.Close(); err != nil {
+		return
+	}
+	st = g.Stats()
+	return
+}
+
+// Stats returns what was produced so far.
+func (g *Generator) Stats() (st Stats) {
+	st = Stats{Vehicles: g.stats.Vehicles, Anomalies: make(map[Anomaly]int, len(g.stats.Anomalies))}
+	for anomaly, n := range g.stats.Anomalies {
+		st.Anomalies[anomaly] = n
+	}
+	return
+}
+
+// anomaly draws the anomaly of a vehicle, empty for none.
+// A single number is drawn whatever the rates, so they do not change the rest of the vehicles of a seed.
+func (g *Generator) anomaly() (anomaly Anomaly) {
+	x := g.rd.Float64()
+	for _, a := range Anomalies {
+		if x -= g.cfg.Rates[a]; x < 0 {
+			return a
+		}
+	}
+	return
+}
+
+// draw returns a number of the distribution.
+func (d Distribution) draw(rd *rand.Rand) float64 {
+	if d.Kind == "normal" {
+		return math.Min(d.Max, math.Max(d.Min, d.Mean+rd.NormFloat64()*d.StdDev))
+	}
+	return d.Min + rd.Float64()*(d.Max-d.Min)
+}
+
+// picker is an struct that picks weighted values.
+type picker struct {
+	values []string
+	// cumulative are the running sums of the weights.
+	cumulative []float64
+}
+
+// newPicker returns a new picker of the weighted values.
+func newPicker(weights []Weighted) *picker {
+	p := &picker{}
+	var total float64
+	for _, w := range weights {
+		total += w.Weight
+		p.values = append(p.values, w.Value)
+		p.cumulative = append(p.cumulative, total)
+	}
+	return p
+}
+
+// pick returns a value with a probability proportional to its weight.
+func (p *picker) pick(rd *rand.Rand) string {
+	x := rd.Float64() * p.cumulative[len(p.cumulative)-1]
+	return p.values[sort.Search(len(p.cumulative), func(i int) bool { return p.cumulative[i] > x })]
+}
+
+// plateLetters are the letters of the registrations, without vowels nor look-alikes.
+const plateLetters = "BCDFGHJKLMNPRSTVWXYZ"
+
+// registration returns the registration of the n-th vehicle, four digits and three or more letters, unique for each n.
+// The digits are scrambled within each block of ten thousand vehicles, 7919 being coprime with it.
+func registration(n int) string {
+	var letters []byte
+	q := n / 10000
+	for i := 0; i < 3 || q > 0; i++ {
+		letters = append(letters, plateLetters[q%len(plateLetters)])
+		q /= len(plateLetters)
+	}
+	for i, j := 0, len(letters)-1; i < j; i, j = i+1, j-1 {
+		letters[i], letters[j] = letters[j], letters[i]
+	}
+	return fmt.Sprintf("%04d-%s", n%10000*7919%10000, letters)
+}
+
+// round rounds a number to two decimals, as the bundled datasets.
+func round(x float64) float64 {
+	return math.Round(x*100) / 100
+}
+
+// isAnomaly returns whether a kind of anomaly exists.
+func isAnomaly(anomaly Anomaly) bool {
+	for _, a := range Anomalies {
+		if a == anomaly {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the anomalies of the stats, ordered by kind, such as "duplicate_id=3 missing_brand=1".
+func (s Stats) String() string {
+	var parts []string
+	for _, a := range Anomalies {
+		if n := s.Anomalies[a]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%d", a, n))
+		}
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, " ")
+}
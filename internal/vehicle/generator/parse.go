@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseWeights returns the weighted values of comma separated value=weight pairs, such as "Ford=3,Toyota=2,BMW".
+// A value without a weight weighs 1.
+func ParseWeights(s string) (weights []Weighted, err error) {
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		value, weight, ok := strings.Cut(pair, "=")
+		w := Weighted{Value: strings.TrimSpace(value), Weight: 1}
+		if ok {
+			if w.Weight, err = strconv.ParseFloat(strings.TrimSpace(weight), 64); err != nil {
+				err = fmt.Errorf("%w. weight of %q: %v", ErrGeneratorInvalid, w.Value, err)
+				return
+			}
+		}
+		weights = append(weights, w)
+	}
+	return
+}
+
+// ParseDistribution returns the distribution of "uniform:min:max" or "normal:mean:stddev:min:max".
+func ParseDistribution(s string) (d Distribution, err error) {
+	parts := strings.Split(s, ":")
+	var numbers []*float64
+	switch d.Kind = parts[0]; d.Kind {
+	case "uniform":
+		numbers = []*float64{&d.Min, &d.Max}
+	case "normal":
+		numbers = []*float64{&d.Mean, &d.StdDev, &d.Min, &d.Max}
+	default:
+		err = fmt.Errorf("%w. distribution %q must be uniform:min:max or normal:mean:stddev:min:max", ErrGeneratorInvalid, s)
+		return
+	}
+	if len(parts)-1 != len(numbers) {
+		err = fmt.Errorf("%w. distribution %q must have %d numbers", ErrGeneratorInvalid, s, len(numbers))
+		return
+	}
+	for i, n := range numbers {
+		if *n, err = strconv.ParseFloat(parts[i+1], 64); err != nil {
+			err = fmt.Errorf("%w. distribution %q: %v", ErrGeneratorInvalid, s, err)
+			return
+		}
+	}
+	return
+}
+
+// ParseRates returns the anomaly rates of comma separated anomaly=rate pairs, such as "duplicate_id=0.01,missing_brand=0.005".
+func ParseRates(s string) (rates map[Anomaly]float64, err error) {
+	weights, err := ParseWeights(s)
+	if err != nil {
+		return
+	}
+	rates = make(map[Anomaly]float64, len(weights))
+	for _, w := range weights {
+		if !isAnomaly(Anomaly(w.Value)) {
+			err = fmt.Errorf("%w. unknown anomaly %q", ErrGeneratorInvalid, w.Value)
+			return
+		}
+		rates[Anomaly(w.Value)] = w.Weight
+	}
+	return
+}
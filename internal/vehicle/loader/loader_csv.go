@@ -0,0 +1,222 @@
+package loader
+
+import (
+	"app/internal/domain"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// Fields are the fields of a vehicle, as named in the data files.
+var Fields = []string{
+	"id", "brand", "model", "registration", "year", "color", "max_speed",
+	"fuel_type", "transmission", "passengers", "height", "width", "weight",
+}
+
+// NewLoaderVehicleCSV returns a new instance of a csv vehicle loader.
+// The delimiter defaults to a comma, and every field not in columns is read from the column named after it.
+func NewLoaderVehicleCSV(path string, delimiter rune, columns map[string]string) *LoaderVehicleCSV {
+	if delimiter == 0 {
+		delimiter = ','
+	}
+	return &LoaderVehicleCSV{Path: path, Delimiter: delimiter, Columns: columns}
+}
+
+// LoaderVehicleCSV is an struct that implements the LoaderVehicle interface for csv files with a header row.
+type LoaderVehicleCSV struct {
+	Path string
+	// Delimiter is the field delimiter of the file.
+	Delimiter rune
+	// Columns maps a field of the vehicle to the header of its column in the file.
+	Columns map[string]string
+	// Mode is how strictly the records are checked, permissive if empty.
+	Mode Mode
+	// Logger logs the records skipped by a lenient load.
+	Logger *slog.Logger
+	// Report, if not nil, is set to the report of every load, with the problems a lenient load skips.
+	Report *Report
+}
+
+// Load returns all vehicles.
+func (l *LoaderVehicleCSV) Load() (v map[int]*domain.VehicleAttributes, err error) {
+	// open file
+	f, err := open(l.Path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma = l.Delimiter
+	r.TrimLeadingSpace = true
+
+	// header: the index of the column of every field
+	header, err := r.Read()
+	if err != nil {
+		err = fmt.Errorf("%w. header: %v", ErrLoaderVehicleInternal, err)
+		return
+	}
+	index, err := l.index(header)
+	if err != nil {
+		return
+	}
+
+	// unknown columns
+	c := newChecker(l.Path, l.Mode, l.Logger, l.Report)
+	if c.strict() {
+		for i, name := range header {
+			if !l.known(strings.TrimSpace(name)) {
+				line, column := r.FieldPos(i)
+				c.report.Problems = append(c.report.Problems, Problem{Record: -1, Line: line, Column: column, Message: fmt.Sprintf("unknown column %q", name)})
+			}
+		}
+	}
+
+	// read and serialize vehicles
+	for i := 0; ; i++ {
+		record, e := r.Read()
+		if errors.Is(e, io.EOF) {
+			break
+		}
+		var ep *csv.ParseError
+		if errors.As(e, &ep) {
+			if !c.fail(i, ep.StartLine, ep.Column, "%v", ep.Err) {
+				return c.result()
+			}
+			continue
+		}
+		if e != nil {
+			err = fmt.Errorf("%w. %v", ErrLoaderVehicleInternal, e)
+			return
+		}
+
+		line, column := r.FieldPos(0)
+		p := &csvRecord{record: record, index: index}
+		id := p.int("id")
+		attributes := &domain.VehicleAttributes{
+			Brand:        p.string("brand"),
+			Model:        p.string("model"),
+			Registration: p.string("registration"),
+			Year:         p.int("year"),
+			Color:        p.string("color"),
+			MaxSpeed:     p.int("max_speed"),
+			FuelType:     p.string("fuel_type"),
+			Transmission: p.string("transmission"),
+			Passengers:   p.int("passengers"),
+			Height:       p.float("height"),
+			Width:        p.float("width"),
+			Weight:       p.float("weight"),
+		}
+		if p.err != nil {
+			line, column := r.FieldPos(index[p.errField])
+			if !c.fail(i, line, column, "%v", p.err) {
+				return c.result()
+			}
+			continue
+		}
+		c.add(i, line, column, id, attributes)
+	}
+
+	return c.result()
+}
+
+// known returns true if the column is mapped to a field of the vehicle.
+func (l *LoaderVehicleCSV) known(column string) bool {
+	for _, field := range Fields {
+		name := field
+		if c, ok := l.Columns[field]; ok {
+			name = c
+		}
+		if name == column {
+			return true
+		}
+	}
+	return false
+}
+
+// index returns the index of the column of every field found in the header.
+func (l *LoaderVehicleCSV) index(header []string) (index map[string]int, err error) {
+	for field := range l.Columns {
+		if !isField(field) {
+			err = fmt.Errorf("%w. unknown field %q in the column mapping", ErrLoaderVehicleInternal, field)
+			return
+		}
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	index = make(map[string]int, len(Fields))
+	for _, field := range Fields {
+		name := field
+		if c, ok := l.Columns[field]; ok {
+			name = c
+		}
+		if i, ok := columns[name]; ok {
+			index[field] = i
+		}
+	}
+	if _, ok := index["id"]; !ok {
+		err = fmt.Errorf("%w. header: missing the id column", ErrLoaderVehicleInternal)
+	}
+	return
+}
+
+// isField returns true if the field is a field of the vehicle.
+func isField(field string) bool {
+	for _, f := range Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// csvRecord is an struct that represents a csv record being serialized, keeping its first error.
+type csvRecord struct {
+	record []string
+	index  map[string]int
+	err    error
+	// errField is the field of the error.
+	errField string
+}
+
+// string returns the value of the field, empty if its column is missing.
+func (r *csvRecord) string(field string) string {
+	i, ok := r.index[field]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+// int returns the value of the field as an integer, zero if it is empty.
+func (r *csvRecord) int(field string) (n int) {
+	s := r.string(field)
+	if s == "" {
+		return
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil && r.err == nil {
+		r.err, r.errField = fmt.Errorf("%s: %q is not an integer", field, s), field
+	}
+	return
+}
+
+// float returns the value of the field as a float, zero if it is empty.
+func (r *csvRecord) float(field string) (n float64) {
+	s := r.string(field)
+	if s == "" {
+		return
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil && r.err == nil {
+		r.err, r.errField = fmt.Errorf("%s: %q is not a number", field, s), field
+	}
+	return
+}
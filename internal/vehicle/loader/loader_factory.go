@@ -0,0 +1,137 @@
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Formats of the data files.
+const (
+	FormatJSON   = "json"
+	FormatCSV    = "csv"
+	FormatYAML   = "yaml"
+	FormatNDJSON = "ndjson"
+)
+
+var (
+	// ErrLoaderVehicleFormat is returned when the format of a data file is not supported.
+	ErrLoaderVehicleFormat = errors.New("loader: unsupported format")
+)
+
+// extensions maps the extension of a data file to its format.
+var extensions = map[string]string{
+	".json":   FormatJSON,
+	".csv":    FormatCSV,
+	".yaml":   FormatYAML,
+	".yml":    FormatYAML,
+	".ndjson": FormatNDJSON,
+	".jsonl":  FormatNDJSON,
+}
+
+// Options is an struct that represents how the data files are read.
+type Options struct {
+	// Format is the format of the files, empty to pick it from their extension.
+	Format string
+	// Delimiter is the field delimiter of the csv files, a comma if zero.
+	Delimiter rune
+	// Columns maps a field of the vehicle to the header of its column in the csv files.
+	Columns map[string]string
+	// Mode is how strictly the records are checked, permissive if empty.
+	Mode Mode
+	// Logger logs the records skipped by a lenient load.
+	Logger *slog.Logger
+	// Report, if not nil, is set to the report of every load, with the problems a lenient load skips.
+	Report *Report
+}
+
+// FormatOf returns the format of a data file from its extension, ignoring a trailing .gz.
+func FormatOf(path string) (format string, err error) {
+	format, ok := extensions[strings.ToLower(filepath.Ext(strings.TrimSuffix(path, ".gz")))]
+	if !ok {
+		err = fmt.Errorf("%w. %s", ErrLoaderVehicleFormat, path)
+	}
+	return
+}
+
+// NewLoaderVehicle returns the loader of a data file, for the format of the options or else the one of its extension.
+func NewLoaderVehicle(path string, opts Options) (ld LoaderVehicle, err error) {
+	format := opts.Format
+	if format == "" {
+		format, err = FormatOf(path)
+		if err != nil {
+			return
+		}
+	}
+
+	switch format {
+	case FormatJSON:
+		l := NewLoaderVehicleJSON(path)
+		l.Mode, l.Logger, l.Report = opts.Mode, opts.Logger, opts.Report
+		ld = l
+	case FormatCSV:
+		l := NewLoaderVehicleCSV(path, opts.Delimiter, opts.Columns)
+		l.Mode, l.Logger, l.Report = opts.Mode, opts.Logger, opts.Report
+		ld = l
+	case FormatYAML:
+		l := NewLoaderVehicleYAML(path)
+		l.Mode, l.Logger, l.Report = opts.Mode, opts.Logger, opts.Report
+		ld = l
+	case FormatNDJSON:
+		l := NewLoaderVehicleNDJSON(path)
+		l.Mode, l.Logger, l.Report = opts.Mode, opts.Logger, opts.Report
+		ld = l
+	default:
+		err = fmt.Errorf("%w. %q", ErrLoaderVehicleFormat, format)
+	}
+	return
+}
+
+// NewLoadersTenants returns a loader for every <tenant>.<extension> data file of the directory,
+// optionally gzip compressed as <tenant>.<extension>.gz. Files of unsupported formats are skipped.
+func NewLoadersTenants(dir string, opts Options) (loaders map[string]LoaderVehicle, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrLoaderVehicleInternal, err)
+		return
+	}
+
+	loaders = make(map[string]LoaderVehicle)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, e := FormatOf(entry.Name()); e != nil {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".gz")
+		id := strings.TrimSuffix(name, filepath.Ext(name))
+		if _, ok := loaders[id]; ok {
+			err = fmt.Errorf("%w. tenant %s has more than one data file", ErrLoaderVehicleInternal, id)
+			return
+		}
+		loaders[id], err = NewLoaderVehicle(filepath.Join(dir, entry.Name()), opts)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// ParseColumns returns the column mapping of field=column pairs.
+func ParseColumns(pairs []string) (columns map[string]string, err error) {
+	columns = make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		field, column, ok := strings.Cut(pair, "=")
+		field, column = strings.TrimSpace(field), strings.TrimSpace(column)
+		if !ok || column == "" || !isField(field) {
+			err = fmt.Errorf("%w. invalid column mapping %q, expected <field>=<column> with a field in %v", ErrLoaderVehicleInternal, pair, Fields)
+			return
+		}
+		columns[field] = column
+	}
+	return
+}
@@ -2,9 +2,13 @@ package loader
 
 import (
 	"app/internal/domain"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"log/slog"
+	"strings"
 )
 
 // NewLoaderVehicleJSON returns a new instance of a vehicle loader.
@@ -15,6 +19,12 @@ func NewLoaderVehicleJSON(path string) *LoaderVehicleJSON {
 // LoaderVehicleJSON is an struct that implements the LoaderVehicle interface.
 type LoaderVehicleJSON struct {
 	Path string
+	// Mode is how strictly the records are checked, permissive if empty.
+	Mode Mode
+	// Logger logs the records skipped by a lenient load.
+	Logger *slog.Logger
+	// Report, if not nil, is set to the report of every load, with the problems a lenient load skips.
+	Report *Report
 }
 
 // Load returns all vehicles.
@@ -34,43 +44,122 @@ type VehicleJSON struct {
 	Weight       float64 `json:"weight"`
 }
 
+// attributes returns the attributes of the vehicle.
+func (v *VehicleJSON) attributes() *domain.VehicleAttributes {
+	return &domain.VehicleAttributes{
+		Brand:        v.Brand,
+		Model:        v.Model,
+		Registration: v.Registration,
+		Year:         v.Year,
+		Color:        v.Color,
+		MaxSpeed:     v.MaxSpeed,
+		FuelType:     v.FuelType,
+		Transmission: v.Transmission,
+		Passengers:   v.Passengers,
+		Height:       v.Height,
+		Width:        v.Width,
+		Weight:       v.Weight,
+	}
+}
+
 // Load returns all vehicles.
 func (l *LoaderVehicleJSON) Load() (v map[int]*domain.VehicleAttributes, err error) {
 	// open file
-	f, err := os.Open(l.Path)
+	f, err := open(l.Path)
 	if err != nil {
-		err = fmt.Errorf("%w. %v", ErrLoaderVehicleInternal, err)
 		return
 	}
 	defer f.Close()
 
-	// read file
-	var vehiclesJSON []*VehicleJSON
-	err = json.NewDecoder(f).Decode(&vehiclesJSON)
+	// read file, whole as the positions of the records are reported
+	data, err := io.ReadAll(f)
 	if err != nil {
 		err = fmt.Errorf("%w. %v", ErrLoaderVehicleInternal, err)
 		return
 	}
 
-	// serialize vehicles
-	v = make(map[int]*domain.VehicleAttributes)
-	for _, vehicleJSON := range vehiclesJSON {
-		v[vehicleJSON.ID] = &domain.VehicleAttributes{
-			Brand:        vehicleJSON.Brand,
-			Model:        vehicleJSON.Model,
-			Registration: vehicleJSON.Registration,
-			Year:         vehicleJSON.Year,
-			Color:        vehicleJSON.Color,
-			MaxSpeed:     vehicleJSON.MaxSpeed,
-			FuelType:     vehicleJSON.FuelType,
-			Transmission: vehicleJSON.Transmission,
-			Passengers:   vehicleJSON.Passengers,
-			Height:       vehicleJSON.Height,
-			Width:        vehicleJSON.Width,
-			Weight:       vehicleJSON.Weight,
+	// serialize vehicles, one array element at a time
+	c := newChecker(l.Path, l.Mode, l.Logger, l.Report)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tk, e := dec.Token(); e != nil || tk != json.Delim('[') {
+		line, column := position(data, int(dec.InputOffset()))
+		c.fail(0, line, column, "expected an array of vehicles")
+		return c.result()
+	}
+	for i := 0; dec.More(); i++ {
+		start := int(dec.InputOffset())
+		for start < len(data) && strings.ContainsRune(" \t\r\n,", rune(data[start])) {
+			start++
+		}
+		line, column := position(data, start)
+
+		var raw json.RawMessage
+		if e := dec.Decode(&raw); e != nil {
+			// the rest of the file can not be decoded
+			var es *json.SyntaxError
+			if errors.As(e, &es) {
+				line, column = position(data, int(es.Offset))
+			}
+			c.fail(i, line, column, "%v", e)
+			return c.result()
+		}
+
+		if name, offset, ok := unknownField(raw); c.strict() && ok {
+			line, column := position(data, start+offset)
+			if !c.fail(i, line, column, "unknown field %q", name) {
+				return c.result()
+			}
+			continue
+		}
+
+		var vehicleJSON VehicleJSON
+		if e := json.Unmarshal(raw, &vehicleJSON); e != nil {
+			offset := start
+			var et *json.UnmarshalTypeError
+			if errors.As(e, &et) {
+				offset += int(et.Offset)
+			}
+			line, column := position(data, offset)
+			if !c.fail(i, line, column, "%v", e) {
+				return c.result()
+			}
+			continue
 		}
+		c.add(i, line, column, vehicleJSON.ID, vehicleJSON.attributes())
+	}
+	if _, e := dec.Token(); e != nil {
+		line, column := position(data, int(dec.InputOffset()))
+		c.fail(c.report.Records, line, column, "%v", e)
 	}
 
-	return
+	return c.result()
 }
 
+// unknownField returns the first key of a json object that is not a field of the vehicle, and its offset in raw.
+// A value that is not an object has no keys, and its error is reported when decoding the vehicle.
+func unknownField(raw json.RawMessage) (name string, offset int, ok bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tk, e := dec.Token(); e != nil || tk != json.Delim('{') {
+		return
+	}
+	for dec.More() {
+		// -> the offset of the key, past the separator of the previous value
+		start := int(dec.InputOffset())
+		for start < len(raw) && strings.ContainsRune(" \t\r\n,", rune(raw[start])) {
+			start++
+		}
+		tk, e := dec.Token()
+		if e != nil {
+			return
+		}
+		key, _ := tk.(string)
+		if !isField(key) {
+			return key, start, true
+		}
+		var value json.RawMessage
+		if dec.Decode(&value) != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package loader
+
+import (
+	"app/internal/domain"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// NewLoaderVehicleNDJSON returns a new instance of a newline delimited json vehicle loader.
+func NewLoaderVehicleNDJSON(path string) *LoaderVehicleNDJSON {
+	return &LoaderVehicleNDJSON{Path: path}
+}
+
+// LoaderVehicleNDJSON is an struct that implements the LoaderVehicle interface for files holding a json vehicle per line.
+// The vehicles are decoded a line at a time, so the file is never held in memory as a whole.
+type LoaderVehicleNDJSON struct {
+	Path string
+	// Mode is how strictly the records are checked, permissive if empty.
+	Mode Mode
+	// Logger logs the records skipped by a lenient load.
+	Logger *slog.Logger
+	// Report, if not nil, is set to the report of every load, with the problems a lenient load skips.
+	Report *Report
+}
+
+// ndjsonMaxLine is the maximum length of a line of a newline delimited json file.
+const ndjsonMaxLine = 1 << 20
+
+// Load returns all vehicles.
+func (l *LoaderVehicleNDJSON) Load() (v map[int]*domain.VehicleAttributes, err error) {
+	// open file
+	f, err := open(l.Path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	// read and serialize vehicles, a line at a time
+	c := newChecker(l.Path, l.Mode, l.Logger, l.Report)
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), ndjsonMaxLine)
+	for line, i := 1, 0; sc.Scan(); line++ {
+		raw := bytes.TrimSpace(sc.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var vehicleJSON VehicleJSON
+		if name, offset, ok := unknownField(raw); c.strict() && ok {
+			if !c.fail(i, line, 1+offset, "unknown field %q", name) {
+				return c.result()
+			}
+		} else if e := json.Unmarshal(raw, &vehicleJSON); e != nil {
+			column := 1
+			var es *json.SyntaxError
+			var et *json.UnmarshalTypeError
+			switch {
+			case errors.As(e, &es):
+				column += int(es.Offset)
+			case errors.As(e, &et):
+				column += int(et.Offset)
+			}
+			if !c.fail(i, line, column, "%v", e) {
+				return c.result()
+			}
+		} else {
+			c.add(i, line, 1, vehicleJSON.ID, vehicleJSON.attributes())
+		}
+		i++
+	}
+	if err = sc.Err(); err != nil {
+		err = fmt.Errorf("%w. %v", ErrLoaderVehicleInternal, err)
+		return
+	}
+
+	return c.result()
+}
@@ -0,0 +1,43 @@
+package loader
+
+import (
+	"app/internal/domain"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadTenants loads the vehicles of every tenant with the loader of the tenant.
+func LoadTenants(loaders map[string]LoaderVehicle) (dbs map[string]map[int]*domain.VehicleAttributes, err error) {
+	dbs = make(map[string]map[int]*domain.VehicleAttributes, len(loaders))
+	for id, ld := range loaders {
+		var db map[int]*domain.VehicleAttributes
+		db, err = ld.Load()
+		if err != nil {
+			err = fmt.Errorf("tenant %s: %w", id, err)
+			return
+		}
+		dbs[id] = db
+	}
+	return
+}
+
+// NewLoadersTenantsJSON returns a json loader for every <tenant>.json file of the directory.
+func NewLoadersTenantsJSON(dir string) (loaders map[string]LoaderVehicle, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrLoaderVehicleInternal, err)
+		return
+	}
+
+	loaders = make(map[string]LoaderVehicle)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		id := strings.TrimSuffix(entry.Name(), ".json")
+		loaders[id] = NewLoaderVehicleJSON(filepath.Join(dir, entry.Name()))
+	}
+	return
+}
@@ -0,0 +1,115 @@
+package loader
+
+import (
+	"app/internal/domain"
+	"errors"
+	"io"
+	"log/slog"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NewLoaderVehicleYAML returns a new instance of a yaml vehicle loader.
+func NewLoaderVehicleYAML(path string) *LoaderVehicleYAML {
+	return &LoaderVehicleYAML{Path: path}
+}
+
+// LoaderVehicleYAML is an struct that implements the LoaderVehicle interface for yaml files holding a list of vehicles.
+type LoaderVehicleYAML struct {
+	Path string
+	// Mode is how strictly the records are checked, permissive if empty.
+	Mode Mode
+	// Logger logs the records skipped by a lenient load.
+	Logger *slog.Logger
+	// Report, if not nil, is set to the report of every load, with the problems a lenient load skips.
+	Report *Report
+}
+
+// VehicleYAML is an struct that represents a vehicle in a yaml file.
+type VehicleYAML struct {
+	ID           int     `yaml:"id"`
+	Brand        string  `yaml:"brand"`
+	Model        string  `yaml:"model"`
+	Registration string  `yaml:"registration"`
+	Year         int     `yaml:"year"`
+	Color        string  `yaml:"color"`
+	MaxSpeed     int     `yaml:"max_speed"`
+	FuelType     string  `yaml:"fuel_type"`
+	Transmission string  `yaml:"transmission"`
+	Passengers   int     `yaml:"passengers"`
+	Height       float64 `yaml:"height"`
+	Width        float64 `yaml:"width"`
+	Weight       float64 `yaml:"weight"`
+}
+
+// Load returns all vehicles.
+func (l *LoaderVehicleYAML) Load() (v map[int]*domain.VehicleAttributes, err error) {
+	// open file
+	f, err := open(l.Path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	// read file, as nodes to know the position of every vehicle
+	c := newChecker(l.Path, l.Mode, l.Logger, l.Report)
+	var doc yaml.Node
+	if e := yaml.NewDecoder(f).Decode(&doc); e != nil && !errors.Is(e, io.EOF) {
+		c.fail(0, 1, 1, "%v", e)
+		return c.result()
+	}
+	if len(doc.Content) == 0 {
+		return c.result()
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.SequenceNode {
+		c.fail(0, root.Line, root.Column, "expected a list of vehicles")
+		return c.result()
+	}
+
+	// serialize vehicles
+	for i, node := range root.Content {
+		if key := unknownKey(node); c.strict() && key != nil {
+			c.fail(i, key.Line, key.Column, "unknown field %q", key.Value)
+			continue
+		}
+
+		var vehicleYAML VehicleYAML
+		if e := node.Decode(&vehicleYAML); e != nil {
+			if !c.fail(i, node.Line, node.Column, "%v", e) {
+				return c.result()
+			}
+			continue
+		}
+		c.add(i, node.Line, node.Column, vehicleYAML.ID, &domain.VehicleAttributes{
+			Brand:        vehicleYAML.Brand,
+			Model:        vehicleYAML.Model,
+			Registration: vehicleYAML.Registration,
+			Year:         vehicleYAML.Year,
+			Color:        vehicleYAML.Color,
+			MaxSpeed:     vehicleYAML.MaxSpeed,
+			FuelType:     vehicleYAML.FuelType,
+			Transmission: vehicleYAML.Transmission,
+			Passengers:   vehicleYAML.Passengers,
+			Height:       vehicleYAML.Height,
+			Width:        vehicleYAML.Width,
+			Weight:       vehicleYAML.Weight,
+		})
+	}
+
+	return c.result()
+}
+
+// unknownKey returns the first key of a mapping node that is not a field of the vehicle, or nil.
+func unknownKey(node *yaml.Node) (key *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !isField(node.Content[i].Value) {
+			key = node.Content[i]
+			return
+		}
+	}
+	return
+}
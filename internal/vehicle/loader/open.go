@@ -0,0 +1,54 @@
+package loader
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+)
+
+// gzipMagic are the first bytes of a gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// file is an struct that represents an open data file, decompressed if it is gzip compressed.
+type file struct {
+	io.Reader
+	// closers close the decompressor and the file.
+	closers []io.Closer
+}
+
+// Close closes the decompressor and the file.
+func (f *file) Close() (err error) {
+	for _, c := range f.closers {
+		if e := c.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return
+}
+
+// open opens a data file. Gzip compressed files are detected by their content and decompressed transparently.
+func open(path string) (f *file, err error) {
+	osf, err := os.Open(path)
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrLoaderVehicleInternal, err)
+		return
+	}
+
+	br := bufio.NewReader(osf)
+	magic, _ := br.Peek(len(gzipMagic))
+	if string(magic) != string(gzipMagic) {
+		f = &file{Reader: br, closers: []io.Closer{osf}}
+		return
+	}
+
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		osf.Close()
+		err = fmt.Errorf("%w. %v", ErrLoaderVehicleInternal, err)
+		return
+	}
+	f = &file{Reader: gz, closers: []io.Closer{gz, osf}}
+	return
+}
@@ -0,0 +1,192 @@
+package loader
+
+import (
+	"app/internal/domain"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Mode is how strictly the records of a data file are checked.
+type Mode string
+
+const (
+	// ModePermissive loads every record it can decode: unknown fields are ignored and a repeated id overwrites the earlier record.
+	// The load fails on the first record that can not be decoded.
+	ModePermissive Mode = "permissive"
+	// ModeStrict rejects unknown fields, repeated ids and registrations, and vehicles failing the domain validation,
+	// and fails the load with a report of every problem.
+	ModeStrict Mode = "strict"
+	// ModeLenient runs the checks of ModeStrict, but loads the valid records and logs the rest.
+	ModeLenient Mode = "lenient"
+)
+
+// reportMaxProblems is the number of problems detailed by the error of a report.
+const reportMaxProblems = 20
+
+// Problem is an struct that represents a problem of a record of a data file.
+type Problem struct {
+	// Record is the index of the record in the file, starting at 0, or -1 for the header of a csv file.
+	Record int `json:"record"`
+	// Line and Column are the position of the record, or of the problem within it when known, starting at 1.
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+// String returns the problem as a message.
+func (p Problem) String() string {
+	return fmt.Sprintf("record %d (line %d, column %d): %s", p.Record, p.Line, p.Column, p.Message)
+}
+
+// Report is an struct that represents the problems found loading a data file.
+type Report struct {
+	Path string `json:"path"`
+	// Records is the number of records read, and Loaded the number of them loaded.
+	Records  int       `json:"records"`
+	Loaded   int       `json:"loaded"`
+	Problems []Problem `json:"problems"`
+}
+
+// ReportError is an struct that represents the error of a strict load, reporting every problem found.
+type ReportError struct {
+	Report *Report
+}
+
+// Error returns the problems of the report, detailing the first ones.
+func (e *ReportError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s. %s: %d problems in %d records", ErrLoaderVehicleInvalid, e.Report.Path, len(e.Report.Problems), e.Report.Records)
+	for i, p := range e.Report.Problems {
+		if i == reportMaxProblems {
+			fmt.Fprintf(&sb, "\n... and %d more", len(e.Report.Problems)-i)
+			break
+		}
+		sb.WriteString("\n" + p.String())
+	}
+	return sb.String()
+}
+
+// Unwrap returns ErrLoaderVehicleInvalid.
+func (e *ReportError) Unwrap() error {
+	return ErrLoaderVehicleInvalid
+}
+
+// newChecker returns a new instance of the checker of the records of a data file,
+// reporting its problems in the report if not nil.
+func newChecker(path string, mode Mode, lg *slog.Logger, report *Report) *checker {
+	if mode == "" {
+		mode = ModePermissive
+	}
+	if report == nil {
+		report = &Report{}
+	}
+	*report = Report{Path: path}
+	return &checker{
+		mode:          mode,
+		lg:            lg,
+		report:        report,
+		v:             make(map[int]*domain.VehicleAttributes),
+		ids:           make(map[int]int),
+		registrations: make(map[string]int),
+	}
+}
+
+// checker is an struct that represents the checks of the records of a data file, collecting the loaded vehicles.
+type checker struct {
+	mode Mode
+	// lg logs the problems of a lenient load, if set.
+	lg     *slog.Logger
+	report *Report
+	// v are the vehicles loaded.
+	v map[int]*domain.VehicleAttributes
+	// ids and registrations are the record where every loaded id and registration was first seen.
+	ids           map[int]int
+	registrations map[string]int
+	// err is the error that ends a permissive load.
+	err error
+}
+
+// strict returns true if the records are checked, as opposed to a permissive load.
+func (c *checker) strict() bool {
+	return c.mode != ModePermissive
+}
+
+// fail records a record that could not be decoded. It returns false if the load can not go on.
+func (c *checker) fail(record, line, column int, format string, args ...any) (ok bool) {
+	c.report.Records++
+	p := Problem{Record: record, Line: line, Column: column, Message: fmt.Sprintf(format, args...)}
+	if !c.strict() {
+		c.err = fmt.Errorf("%w. %s", ErrLoaderVehicleInternal, p)
+		return false
+	}
+	c.report.Problems = append(c.report.Problems, p)
+	return true
+}
+
+// add checks a decoded record and loads it if it passes the checks.
+func (c *checker) add(record, line, column int, id int, attributes *domain.VehicleAttributes) {
+	c.report.Records++
+	if !c.strict() {
+		c.v[id] = attributes
+		return
+	}
+
+	n := len(c.report.Problems)
+	problem := func(format string, args ...any) {
+		c.report.Problems = append(c.report.Problems, Problem{Record: record, Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+	}
+	if err := validateVehicle(id, attributes); err != nil {
+		problem("%v", err)
+	}
+	if first, ok := c.ids[id]; ok {
+		problem("duplicate id %d, first seen in record %d", id, first)
+	}
+	if first, ok := c.registrations[attributes.Registration]; ok && attributes.Registration != "" {
+		problem("duplicate registration %q, first seen in record %d", attributes.Registration, first)
+	}
+	if len(c.report.Problems) > n {
+		return
+	}
+
+	c.v[id] = attributes
+	c.ids[id] = record
+	c.registrations[attributes.Registration] = record
+}
+
+// result returns the vehicles loaded, or the error of the load.
+func (c *checker) result() (v map[int]*domain.VehicleAttributes, err error) {
+	if c.err != nil {
+		err = c.err
+		return
+	}
+	c.report.Loaded = len(c.v)
+	if len(c.report.Problems) == 0 {
+		v = c.v
+		return
+	}
+
+	if c.mode == ModeStrict {
+		err = &ReportError{Report: c.report}
+		return
+	}
+	if c.lg != nil {
+		for _, p := range c.report.Problems {
+			c.lg.Warn("vehicle record skipped", "path", c.report.Path, "record", p.Record, "line", p.Line, "column", p.Column, "problem", p.Message)
+		}
+		c.lg.Warn("vehicles loaded with problems", "path", c.report.Path, "records", c.report.Records, "loaded", c.report.Loaded, "problems", len(c.report.Problems))
+	}
+	v = c.v
+	return
+}
+
+// position returns the line and column of an offset of the data, starting at 1.
+func position(data []byte, offset int) (line, column int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	line = bytes.Count(data[:offset], []byte("\n")) + 1
+	column = offset - bytes.LastIndexByte(data[:offset], '\n')
+	return
+}
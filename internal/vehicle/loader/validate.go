@@ -0,0 +1,60 @@
+package loader
+
+import (
+	"app/internal/domain"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrLoaderVehicleInvalid is returned when the loaded vehicles are not valid.
+	ErrLoaderVehicleInvalid = errors.New("loader: invalid vehicles")
+)
+
+// Validate returns an error describing the invalid vehicles of a dataset, or whether it is empty.
+// A dataset with no vehicles is invalid, as it is most likely a file truncated while being written.
+func Validate(v map[int]*domain.VehicleAttributes) (err error) {
+	if len(v) == 0 {
+		err = fmt.Errorf("%w. no vehicles", ErrLoaderVehicleInvalid)
+		return
+	}
+	err = ValidateVehicles(v)
+	return
+}
+
+// ValidateVehicles returns an error describing the invalid vehicles of a dataset, which may be empty,
+// such as the fleet of a tenant whose vehicles were all deleted.
+func ValidateVehicles(v map[int]*domain.VehicleAttributes) (err error) {
+	ids := make([]int, 0, len(v))
+	for id := range v {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var errs []error
+	for _, id := range ids {
+		if e := validateVehicle(id, v[id]); e != nil {
+			errs = append(errs, fmt.Errorf("vehicle %d: %w", id, e))
+		}
+	}
+	if len(errs) > 0 {
+		err = fmt.Errorf("%w. %w", ErrLoaderVehicleInvalid, errors.Join(errs...))
+	}
+	return
+}
+
+// validateVehicle returns the first domain rule the vehicle breaks.
+func validateVehicle(id int, attributes *domain.VehicleAttributes) (err error) {
+	switch {
+	case id <= 0:
+		err = errors.New("id must be positive")
+	case attributes.Registration == "":
+		err = errors.New("registration is required")
+	case attributes.Brand == "":
+		err = errors.New("brand is required")
+	case attributes.MaxSpeed < 0 || attributes.MaxSpeed > domain.MaxSpeedLimit:
+		err = fmt.Errorf("max speed %d out of range", attributes.MaxSpeed)
+	}
+	return
+}
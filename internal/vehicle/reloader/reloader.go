@@ -0,0 +1,191 @@
+package reloader
+
+import (
+	"app/internal/domain"
+	"app/internal/vehicle/loader"
+	"app/internal/vehicle/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Policy is what happens to the changes made through the api since the last load when the vehicles are reloaded.
+type Policy string
+
+const (
+	// PolicyDiscard replaces the vehicles with the loaded ones, the changes made through the api are lost.
+	PolicyDiscard Policy = "discard"
+	// PolicyMerge applies the changes made through the api on top of the loaded vehicles, so they survive the reloads.
+	PolicyMerge Policy = "merge"
+)
+
+// Trigger is what started a reload.
+type Trigger string
+
+const (
+	TriggerStartup Trigger = "startup"
+	TriggerWatch   Trigger = "watch"
+	TriggerManual  Trigger = "manual"
+)
+
+var (
+	// ErrReloaderPolicyInvalid is returned when the reload policy is unknown.
+	ErrReloaderPolicyInvalid = errors.New("reloader: invalid policy")
+)
+
+// Sources returns the loader of every tenant. It is called on every reload, so new tenants are picked up.
+type Sources func() (loaders map[string]loader.LoaderVehicle, err error)
+
+// NewReloader returns a new instance of a reloader of the vehicles of every tenant.
+func NewReloader(sources Sources, rp *repository.RepositoryVehicleTenantsInMemory, policy Policy, lg *slog.Logger) (r *Reloader, err error) {
+	if policy != PolicyDiscard && policy != PolicyMerge {
+		err = fmt.Errorf("%w. %q", ErrReloaderPolicyInvalid, policy)
+		return
+	}
+	r = &Reloader{sources: sources, rp: rp, policy: policy, lg: lg}
+	return
+}
+
+// Reloader is an struct that represents the reload of the vehicles of every tenant into the repository.
+type Reloader struct {
+	// sources returns the loaders of the tenants.
+	sources Sources
+	// rp is the repository whose vehicles are replaced.
+	rp *repository.RepositoryVehicleTenantsInMemory
+	// policy is what happens to the changes made through the api.
+	policy Policy
+	// lg is the logger of the reloader.
+	lg *slog.Logger
+
+	// mu serializes the reloads.
+	mu sync.Mutex
+	// base are the vehicles of the last load, to tell the changes made through the api apart.
+	base map[string]map[int]*domain.VehicleAttributes
+
+	// muStatus guards the status, so it can be read while a reload runs.
+	muStatus sync.RWMutex
+	// status is the result of the last reload.
+	status Status
+}
+
+// Status is an struct that represents the result of a reload.
+type Status struct {
+	// Trigger is what started the reload, empty if there has been none.
+	Trigger Trigger `json:"trigger"`
+	// Time is when the reload started.
+	Time     time.Time `json:"time"`
+	Duration string    `json:"duration"`
+	Success  bool      `json:"success"`
+	Error    string    `json:"error,omitempty"`
+	Policy   Policy    `json:"policy"`
+	// Tenants and Vehicles are the counts in the repository after the reload.
+	Tenants  int `json:"tenants"`
+	Vehicles int `json:"vehicles"`
+	// Kept is the number of changes made through the api that were applied on top of the loaded vehicles.
+	Kept int `json:"kept"`
+	// Report are the problems of the data file that failed a strict load.
+	Report *loader.Report `json:"report,omitempty"`
+}
+
+// Status returns the result of the last reload.
+func (r *Reloader) Status() (st Status) {
+	r.muStatus.RLock()
+	defer r.muStatus.RUnlock()
+
+	return r.status
+}
+
+// Reload loads and validates the vehicles of every tenant, and swaps the contents of the repository for them.
+// If any tenant fails to load or is not valid the repository is left untouched.
+func (r *Reloader) Reload(ctx context.Context, trigger Trigger) (st Status, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	st = Status{Trigger: trigger, Time: time.Now(), Policy: r.policy}
+	defer func() {
+		st.Duration = time.Since(st.Time).String()
+		if err != nil {
+			st.Error = err.Error()
+			var re *loader.ReportError
+			if errors.As(err, &re) {
+				st.Report = re.Report
+			}
+			r.lg.ErrorContext(ctx, "vehicles reload failed", "trigger", trigger, "error", err)
+		} else {
+			st.Success = true
+			r.lg.InfoContext(ctx, "vehicles reloaded", "trigger", trigger, "policy", r.policy, "tenants", st.Tenants, "vehicles", st.Vehicles, "kept", st.Kept)
+		}
+		r.muStatus.Lock()
+		r.status = st
+		r.muStatus.Unlock()
+	}()
+
+	// load and validate every tenant
+	loaders, err := r.sources()
+	if err != nil {
+		return
+	}
+	dbs, err := loader.LoadTenants(loaders)
+	if err != nil {
+		return
+	}
+	// -> a tenant may have no vehicles left, so its empty file is a valid fleet
+	for id, db := range dbs {
+		if err = loader.ValidateVehicles(db); err != nil {
+			err = fmt.Errorf("tenant %s: %w", id, err)
+			return
+		}
+	}
+	base := copyTenants(dbs)
+
+	// swap
+	r.rp.ReplaceFunc(func(current map[string]map[int]*domain.VehicleAttributes) map[string]map[int]*domain.VehicleAttributes {
+		if r.policy == PolicyMerge {
+			st.Kept = merge(dbs, r.base, current)
+		}
+		return dbs
+	})
+	r.base = base
+
+	st.Tenants = len(dbs)
+	for _, db := range dbs {
+		st.Vehicles += len(db)
+	}
+	return
+}
+
+// merge applies on dbs the changes of current since base: the vehicles added, updated or deleted through the api.
+// The changes of the tenants that are no longer loaded are dropped.
+func merge(dbs, base, current map[string]map[int]*domain.VehicleAttributes) (kept int) {
+	for id, db := range dbs {
+		for vid, attributes := range current[id] {
+			if b, ok := base[id][vid]; !ok || *b != *attributes {
+				db[vid] = attributes
+				kept++
+			}
+		}
+		for vid := range base[id] {
+			if _, ok := current[id][vid]; !ok {
+				delete(db, vid)
+				kept++
+			}
+		}
+	}
+	return
+}
+
+// copyTenants returns a deep copy of the vehicles of every tenant.
+func copyTenants(dbs map[string]map[int]*domain.VehicleAttributes) (c map[string]map[int]*domain.VehicleAttributes) {
+	c = make(map[string]map[int]*domain.VehicleAttributes, len(dbs))
+	for id, db := range dbs {
+		c[id] = make(map[int]*domain.VehicleAttributes, len(db))
+		for vid, attributes := range db {
+			a := *attributes
+			c[id][vid] = &a
+		}
+	}
+	return
+}
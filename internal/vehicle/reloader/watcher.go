@@ -0,0 +1,78 @@
+package reloader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Watch polls the files every interval and reloads the vehicles once they change, until the context is done.
+// A directory is watched through its files, so adding or removing a tenant file triggers a reload as well.
+// A change is only reloaded once two consecutive polls agree, so a file being written is not loaded half written.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration, paths ...string) {
+	last := fingerprint(paths)
+	var pending string
+
+	tk := time.NewTicker(interval)
+	defer tk.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tk.C:
+		}
+
+		fp := fingerprint(paths)
+		switch {
+		case fp == last:
+			pending = ""
+		case fp != pending:
+			// changed since the last poll, wait for the next one
+			pending = fp
+		default:
+			// a failed reload is not retried until the files change again
+			r.Reload(ctx, TriggerWatch)
+			last, pending = fp, ""
+		}
+	}
+}
+
+// fingerprint returns a summary of the name, size and modification time of the files.
+func fingerprint(paths []string) string {
+	var sb strings.Builder
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			fmt.Fprintf(&sb, "%s:missing;", path)
+			continue
+		}
+		if !info.IsDir() {
+			fmt.Fprintf(&sb, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			fmt.Fprintf(&sb, "%s:unreadable;", path)
+			continue
+		}
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if info, err := os.Stat(filepath.Join(path, name)); err == nil && !info.IsDir() {
+				fmt.Fprintf(&sb, "%s:%d:%d;", filepath.Join(path, name), info.Size(), info.ModTime().UnixNano())
+			}
+		}
+	}
+	return sb.String()
+}
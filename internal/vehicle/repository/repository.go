@@ -2,25 +2,43 @@ package repository
 
 import (
 	"app/internal/domain"
+	"context"
 	"errors"
+
+	"go.opentelemetry.io/otel"
 )
 
+// tracer creates the spans of the repositories.
+var tracer = otel.Tracer("app/internal/vehicle/repository")
+
 // RepositoryVehicle is the interface that wraps the basic methods for a vehicle repository.
+// Its contract is pinned down by the conformance suite of package repositorytest, which every implementation must pass.
 type RepositoryVehicle interface {
 	// GetAll returns all vehicles
-	GetAll() (v []*domain.Vehicle, err error)
-	GetByColorAndYear(color string, year int) (v []*domain.Vehicle, err error)
-	GetByBrandAndPeriod(brand string, start int, end int) (v []*domain.Vehicle, err error)
-	GetSpeedAverageByBrand(brand string) (v float64, err error)
-	GetByFuelType(fuel string) (v []*domain.Vehicle, err error)
-	GetByWeight(min float64, max float64) (v []*domain.Vehicle, err error)
+	GetAll(ctx context.Context) (v []*domain.Vehicle, err error)
+	// GetById returns the vehicle with the given id
+	GetById(ctx context.Context, id int) (v *domain.Vehicle, err error)
+	GetByColorAndYear(ctx context.Context, color string, year int) (v []*domain.Vehicle, err error)
+	GetByBrandAndPeriod(ctx context.Context, brand string, start int, end int) (v []*domain.Vehicle, err error)
+	GetSpeedAverageByBrand(ctx context.Context, brand string) (v float64, err error)
+	GetByFuelType(ctx context.Context, fuel string) (v []*domain.Vehicle, err error)
+	GetByWeight(ctx context.Context, min float64, max float64) (v []*domain.Vehicle, err error)
+
+	AddVehicle(ctx context.Context, attributes *domain.Vehicle) (v *domain.Vehicle, err error)
+	AddVehicles(ctx context.Context, attributes []*domain.Vehicle) (v []*domain.Vehicle, err error)
 
-	AddVehicle(attributes *domain.Vehicle) (v *domain.Vehicle, err error)
-	AddVehicles(attributes []*domain.Vehicle) (v []*domain.Vehicle, err error)
+	UpdateSpeed(ctx context.Context, vehicle *domain.Vehicle) (v *domain.Vehicle, err error)
 
-	UpdateSpeed(vehicle *domain.Vehicle) (v *domain.Vehicle, err error)
+	DeleteVehicle(ctx context.Context, id int) (v *domain.Vehicle, err error)
+}
 
-	DeleteVehicle(id int) (v *domain.Vehicle, err error)
+// RepositoryVehicleTenants is the interface that resolves the vehicle repository of a tenant.
+// Every tenant owns its own repository, so ids and queries never cross tenants.
+type RepositoryVehicleTenants interface {
+	// Tenant returns the repository of the tenant
+	Tenant(id string) (rp RepositoryVehicle, err error)
+	// Tenants returns the ids of every tenant
+	Tenants() (ids []string)
 }
 
 var (
@@ -33,4 +51,10 @@ var (
 	ErrRepositoryVehicleExist             = errors.New("repository: identificador del vehículo ya existente")
 	ErrRepositoryVehicleNotFoundWithValue = errors.New("repository: vehiculos no encontrados con esos criterios")
 	ErrRepositoryImposibleMaxSpeed        = errors.New("repository: Velocidad mal formada o fuera de rango")
+
+	// ErrRepositoryVehicleCanceled is returned when the context of an operation is canceled or its deadline is exceeded.
+	ErrRepositoryVehicleCanceled = errors.New("repository: operation canceled")
+
+	// ErrRepositoryTenantNotFound is returned when a tenant has no repository.
+	ErrRepositoryTenantNotFound = errors.New("repository: tenant not found")
 )
@@ -2,21 +2,91 @@ package repository
 
 import (
 	"app/internal/domain"
+	"app/internal/tracing"
+	"context"
 	"fmt"
+	"log/slog"
+	"sync"
+	"time"
 )
 
-func NewRepositoryVehicleInMemory(db map[int]*domain.VehicleAttributes) *RepositoryVehicleInMemory {
-	return &RepositoryVehicleInMemory{db: db}
+// scanCheckEvery is the number of vehicles scanned between two checks of the context.
+const scanCheckEvery = 256
+
+// canceled returns a repository error wrapping the error of the context once it is done.
+// The deadline is checked as well, as the timer of the context may not have fired yet on a busy scan.
+func canceled(ctx context.Context) (err error) {
+	e := ctx.Err()
+	if deadline, ok := ctx.Deadline(); e == nil && ok && !time.Now().Before(deadline) {
+		e = context.DeadlineExceeded
+	}
+	if e != nil {
+		err = fmt.Errorf("%w. %w", ErrRepositoryVehicleCanceled, e)
+	}
+	return
+}
+
+// NewRepositoryVehicleInMemory returns a new instance of a vehicle repository in memory.
+func NewRepositoryVehicleInMemory(db map[int]*domain.VehicleAttributes, lg *slog.Logger) *RepositoryVehicleInMemory {
+	return &RepositoryVehicleInMemory{db: db, lg: lg, version: NewVersion()}
 }
 
 // RepositoryVehicleInMemory is an struct that represents a vehicle storage in memory.
 type RepositoryVehicleInMemory struct {
 	// db is the database of vehicles.
 	db map[int]*domain.VehicleAttributes
+	// lg is the logger of the repository.
+	lg *slog.Logger
+	// mu guards the database, as the handlers run concurrently.
+	mu sync.RWMutex
+	// version numbers the modifications of the database.
+	version *Version
+}
+
+// Version returns the number of the last modification of the vehicles and its time.
+func (s *RepositoryVehicleInMemory) Version() (n uint64, modified time.Time) {
+	return s.version.Version()
+}
+
+// snapshot returns a copy of the database.
+func (s *RepositoryVehicleInMemory) snapshot() (db map[int]*domain.VehicleAttributes) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	db = copyDB(s.db)
+	return
+}
+
+// countByFuelType returns the number of vehicles per fuel type.
+func (s *RepositoryVehicleInMemory) countByFuelType() (counts map[string]int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts = make(map[string]int)
+	for _, attributes := range s.db {
+		counts[attributes.FuelType]++
+	}
+	return
+}
+
+// copyDB returns a copy of the database.
+func copyDB(db map[int]*domain.VehicleAttributes) (c map[int]*domain.VehicleAttributes) {
+	c = make(map[int]*domain.VehicleAttributes, len(db))
+	for id, attributes := range db {
+		a := *attributes
+		c[id] = &a
+	}
+	return
 }
 
 // GetAll returns all vehicles
-func (s *RepositoryVehicleInMemory) GetAll() (v []*domain.Vehicle, err error) {
+func (s *RepositoryVehicleInMemory) GetAll(ctx context.Context) (v []*domain.Vehicle, err error) {
+	_, span := tracer.Start(ctx, "RepositoryVehicleInMemory.GetAll")
+	defer func() { tracing.End(span, err) }()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	// check if the database is empty
 	if len(s.db) == 0 {
 		err = ErrRepositoryVehicleNotFound
@@ -26,6 +96,12 @@ func (s *RepositoryVehicleInMemory) GetAll() (v []*domain.Vehicle, err error) {
 	// get all vehicles from the database
 	v = make([]*domain.Vehicle, 0, len(s.db))
 	for key, value := range s.db {
+		if len(v)%scanCheckEvery == 0 {
+			if err = canceled(ctx); err != nil {
+				v = nil
+				return
+			}
+		}
 		v = append(v, &domain.Vehicle{
 			Id:         key,
 			Attributes: *value,
@@ -35,82 +111,132 @@ func (s *RepositoryVehicleInMemory) GetAll() (v []*domain.Vehicle, err error) {
 	return
 }
 
+// filter returns the vehicles that match, or ErrRepositoryVehicleNotFoundWithValue if none does.
+func (s *RepositoryVehicleInMemory) filter(ctx context.Context, match func(vehicle *domain.Vehicle) bool) (v []*domain.Vehicle, err error) {
+	// get all vehicles from the database
+	vehicles, err := s.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	for i, vehicle := range vehicles {
+		if i%scanCheckEvery == 0 {
+			if err = canceled(ctx); err != nil {
+				v = nil
+				return
+			}
+		}
+		if match(vehicle) {
+			v = append(v, vehicle)
+		}
+	}
+	if len(v) == 0 {
+		err = ErrRepositoryVehicleNotFoundWithValue
+		return
+	}
+	return
+}
+
 // AddVehicle returns a new vehicles
-func (s *RepositoryVehicleInMemory) AddVehicle(v *domain.Vehicle) (vehicle *domain.Vehicle, err error) {
+func (s *RepositoryVehicleInMemory) AddVehicle(ctx context.Context, v *domain.Vehicle) (vehicle *domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryVehicleInMemory.AddVehicle")
+	defer func() { tracing.End(span, err) }()
+
+	if err = canceled(ctx); err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if vcl := s.db[v.Id]; vcl != nil {
 		err = ErrRepositoryVehicleExist
 		return
 	}
-	s.db[v.Id] = &v.Attributes
-	fmt.Println("Se agrego a la bd correctamente")
+	attributes := v.Attributes
+	s.db[v.Id] = &attributes
+	s.version.Bump()
+	s.lg.InfoContext(ctx, "vehicle added", "id", v.Id, "registration", v.Attributes.Registration)
 	vehicle = v
 	return
 }
 
-func (s *RepositoryVehicleInMemory) AddVehicles(vehicles []*domain.Vehicle) (v []*domain.Vehicle, err error) {
-	for _, vehicle := range vehicles {
+// AddVehicles adds all the vehicles, or none of them if any id already exists
+func (s *RepositoryVehicleInMemory) AddVehicles(ctx context.Context, vehicles []*domain.Vehicle) (v []*domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryVehicleInMemory.AddVehicles")
+	defer func() { tracing.End(span, err) }()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, vehicle := range vehicles {
+		if i%scanCheckEvery == 0 {
+			if err = canceled(ctx); err != nil {
+				return
+			}
+		}
 		if vcl := s.db[vehicle.Id]; vcl != nil {
 			err = ErrRepositoryVehicleExist
 			return
 		}
 	}
-	for _, vehicle := range vehicles {
-		addVehicle, _ := s.AddVehicle(vehicle)
-		v = append(v, addVehicle)
-	}
-	fmt.Println("Se agregegaron los autos a la bd correctamente")
-	return
-}
 
-func (s *RepositoryVehicleInMemory) GetByColorAndYear(color string, year int) (v []*domain.Vehicle, err error) {
-	// get all vehicles from the database
-	vehicles, err := s.GetAll()
-	if err != nil {
+	// last chance to cancel: once the vehicles are being added the batch completes
+	if err = canceled(ctx); err != nil {
 		return
 	}
 	for _, vehicle := range vehicles {
-		if vehicle.Attributes.Color == color && vehicle.Attributes.Year == year {
-			v = append(v, vehicle)
-		}
-	}
-	if len(v) == 0 {
-		err = ErrRepositoryVehicleNotFoundWithValue
-		return
+		attributes := vehicle.Attributes
+		s.db[vehicle.Id] = &attributes
+		v = append(v, vehicle)
 	}
+	s.version.Bump()
+	s.lg.InfoContext(ctx, "vehicles added", "count", len(v))
 	return
 }
 
-func (s *RepositoryVehicleInMemory) GetByBrandAndPeriod(brand string, start int, end int) (v []*domain.Vehicle, err error) {
-	// get all vehicles from the database
-	vehicles, err := s.GetAll()
-	if err != nil {
-		return
-	}
-	for _, vehicle := range vehicles {
-		if vehicle.Attributes.Brand == brand {
-			if vehicle.Attributes.Year >= start && vehicle.Attributes.Year < end {
-				v = append(v, vehicle)
-			}
-		}
-	}
-	if len(v) == 0 {
-		err = ErrRepositoryVehicleNotFoundWithValue
+// GetByColorAndYear returns the vehicles of the color made in the year
+func (s *RepositoryVehicleInMemory) GetByColorAndYear(ctx context.Context, color string, year int) (v []*domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryVehicleInMemory.GetByColorAndYear")
+	defer func() { tracing.End(span, err) }()
+
+	return s.filter(ctx, func(vehicle *domain.Vehicle) bool {
+		return vehicle.Attributes.Color == color && vehicle.Attributes.Year == year
+	})
+}
+
+// GetByBrandAndPeriod returns the vehicles of the brand made from the start year up to, but not including, the end year
+func (s *RepositoryVehicleInMemory) GetByBrandAndPeriod(ctx context.Context, brand string, start int, end int) (v []*domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryVehicleInMemory.GetByBrandAndPeriod")
+	defer func() { tracing.End(span, err) }()
+
+	return s.filter(ctx, func(vehicle *domain.Vehicle) bool {
+		return vehicle.Attributes.Brand == brand && vehicle.Attributes.Year >= start && vehicle.Attributes.Year < end
+	})
+}
+
+// UpdateSpeed updates the max speed of a vehicle
+func (s *RepositoryVehicleInMemory) UpdateSpeed(ctx context.Context, v *domain.Vehicle) (vehicle *domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryVehicleInMemory.UpdateSpeed")
+	defer func() { tracing.End(span, err) }()
+
+	if err = canceled(ctx); err != nil {
 		return
 	}
-	return
-}
 
-// AddVehicle returns a new vehicles
-func (s *RepositoryVehicleInMemory) UpdateSpeed(v *domain.Vehicle) (vehicle *domain.Vehicle, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if vcl := s.db[v.Id]; vcl == nil {
 		err = ErrRepositoryVehicleNotFound
 		return
 	}
-	if v.Attributes.MaxSpeed < 0 || v.Attributes.MaxSpeed > 400 {
+	if v.Attributes.MaxSpeed < 0 || v.Attributes.MaxSpeed > domain.MaxSpeedLimit {
 		err = ErrRepositoryImposibleMaxSpeed
+		return
 	}
 	s.db[v.Id].MaxSpeed = v.Attributes.MaxSpeed
-	fmt.Println("Se actualizo la velocidad correctamente")
+	s.version.Bump()
+	s.lg.InfoContext(ctx, "vehicle speed updated", "id", v.Id, "max_speed", v.Attributes.MaxSpeed)
 	vehicle = &domain.Vehicle{
 		Id:         v.Id,
 		Attributes: *s.db[v.Id],
@@ -118,68 +244,57 @@ func (s *RepositoryVehicleInMemory) UpdateSpeed(v *domain.Vehicle) (vehicle *dom
 	return
 }
 
-func (s *RepositoryVehicleInMemory) GetSpeedAverageByBrand(brand string) (average float64, err error) {
-	// get all vehicles from the database
-	vehicles, err := s.GetAll()
+// GetSpeedAverageByBrand returns the average max speed of the brand
+func (s *RepositoryVehicleInMemory) GetSpeedAverageByBrand(ctx context.Context, brand string) (average float64, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryVehicleInMemory.GetSpeedAverageByBrand")
+	defer func() { tracing.End(span, err) }()
+
+	vehicles, err := s.filter(ctx, func(vehicle *domain.Vehicle) bool {
+		return vehicle.Attributes.Brand == brand
+	})
 	if err != nil {
 		return
 	}
-	var counter int
 	var sumSpeed int
 	for _, vehicle := range vehicles {
-		if vehicle.Attributes.Brand == brand {
-			counter++
-			sumSpeed += vehicle.Attributes.MaxSpeed
-		}
+		sumSpeed += vehicle.Attributes.MaxSpeed
 	}
-	if counter == 0 {
-		err = ErrRepositoryVehicleNotFoundWithValue
-		return
-	}
-	average = float64(sumSpeed) / float64(counter)
+	average = float64(sumSpeed) / float64(len(vehicles))
 	return
 }
 
-func (s *RepositoryVehicleInMemory) GetByFuelType(fuel string) (v []*domain.Vehicle, err error) {
-	// get all vehicles from the database
-	vehicles, err := s.GetAll()
-	if err != nil {
-		return
-	}
-	for _, vehicle := range vehicles {
-		if vehicle.Attributes.FuelType == fuel {
-			v = append(v, vehicle)
-		}
-	}
-	if len(v) == 0 {
-		err = ErrRepositoryVehicleNotFoundWithValue
-		return
-	}
-	return
+// GetByFuelType returns the vehicles of the fuel type
+func (s *RepositoryVehicleInMemory) GetByFuelType(ctx context.Context, fuel string) (v []*domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryVehicleInMemory.GetByFuelType")
+	defer func() { tracing.End(span, err) }()
+
+	return s.filter(ctx, func(vehicle *domain.Vehicle) bool {
+		return vehicle.Attributes.FuelType == fuel
+	})
 }
 
-func (s *RepositoryVehicleInMemory) GetByWeight(min float64, max float64) (v []*domain.Vehicle, err error) {
-	// get all vehicles from the database
-	vehicles, err := s.GetAll()
-	if err != nil {
-		return
-	}
-	for _, vehicle := range vehicles {
-		if vehicle.Attributes.Weight >= min && vehicle.Attributes.Weight <= max {
-			v = append(v, vehicle)
-		}
-	}
-	if len(v) == 0 {
-		err = ErrRepositoryVehicleNotFoundWithValue
-		return
-	}
-	return
+// GetByWeight returns the vehicles whose weight is between min and max, both included
+func (s *RepositoryVehicleInMemory) GetByWeight(ctx context.Context, min float64, max float64) (v []*domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryVehicleInMemory.GetByWeight")
+	defer func() { tracing.End(span, err) }()
+
+	return s.filter(ctx, func(vehicle *domain.Vehicle) bool {
+		return vehicle.Attributes.Weight >= min && vehicle.Attributes.Weight <= max
+	})
 }
 
-func (s *RepositoryVehicleInMemory) GetById(id int) (v *domain.Vehicle, err error) {
+// GetById returns the vehicle with the given id
+func (s *RepositoryVehicleInMemory) GetById(ctx context.Context, id int) (v *domain.Vehicle, err error) {
+	_, span := tracer.Start(ctx, "RepositoryVehicleInMemory.GetById")
+	defer func() { tracing.End(span, err) }()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	vehicle := s.db[id]
 	if vehicle == nil {
 		err = ErrRepositoryVehicleNotFound
+		return
 	}
 	v = &domain.Vehicle{
 		Id:         id,
@@ -188,13 +303,29 @@ func (s *RepositoryVehicleInMemory) GetById(id int) (v *domain.Vehicle, err erro
 	return
 }
 
-func (s *RepositoryVehicleInMemory) DeleteVehicle(id int) (v *domain.Vehicle, err error) {
-	// get all vehicles from the database
-	v, err = s.GetById(id)
-	if err != nil {
+// DeleteVehicle deletes the vehicle with the given id
+func (s *RepositoryVehicleInMemory) DeleteVehicle(ctx context.Context, id int) (v *domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryVehicleInMemory.DeleteVehicle")
+	defer func() { tracing.End(span, err) }()
+
+	if err = canceled(ctx); err != nil {
 		return
 	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vehicle := s.db[id]
+	if vehicle == nil {
+		err = ErrRepositoryVehicleNotFound
+		return
+	}
+	v = &domain.Vehicle{
+		Id:         id,
+		Attributes: *vehicle,
+	}
 	delete(s.db, id)
-	fmt.Println("Se elimino el vehiculo correctamente")
+	s.version.Bump()
+	s.lg.InfoContext(ctx, "vehicle deleted", "id", id, "registration", v.Attributes.Registration)
 	return
 }
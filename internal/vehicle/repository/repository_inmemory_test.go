@@ -0,0 +1,38 @@
+package repository_test
+
+import (
+	"app/internal/domain"
+	"app/internal/logging"
+	"app/internal/metrics"
+	"app/internal/vehicle/repository"
+	"app/internal/vehicle/repository/repositorytest"
+	"testing"
+)
+
+func TestRepositoryVehicleInMemory(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, db map[int]*domain.VehicleAttributes) repository.RepositoryVehicle {
+		return repository.NewRepositoryVehicleInMemory(db, logging.Discard())
+	})
+}
+
+func TestRepositoryVehicleTenantsInMemory(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, db map[int]*domain.VehicleAttributes) repository.RepositoryVehicle {
+		rps := repository.NewRepositoryVehicleTenantsInMemory(map[string]map[int]*domain.VehicleAttributes{"acme": db}, logging.Discard())
+		rp, err := rps.Tenant("acme")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rp
+	})
+}
+
+func TestRepositoryVehicleTenantsMetrics(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, db map[int]*domain.VehicleAttributes) repository.RepositoryVehicle {
+		rps := repository.NewRepositoryVehicleTenantsInMemory(map[string]map[int]*domain.VehicleAttributes{"acme": db}, logging.Discard())
+		rp, err := repository.NewRepositoryVehicleTenantsMetrics(rps, rps, metrics.NewRegistry()).Tenant("acme")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rp
+	})
+}
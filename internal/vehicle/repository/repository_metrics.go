@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"app/internal/domain"
+	"app/internal/metrics"
+	"context"
+	"time"
+)
+
+// NewRepositoryVehicleTenantsMetrics returns a new instance of a repository that measures the repositories of every tenant.
+// It registers the timings of the operations, and the fleet gauges of the vehicles counted by fl, in the registry.
+func NewRepositoryVehicleTenantsMetrics(rp RepositoryVehicleTenants, fl Fleet, rg *metrics.Registry) *RepositoryVehicleTenantsMetrics {
+	s := &RepositoryVehicleTenantsMetrics{
+		rp: rp,
+		fl: fl,
+		duration: rg.NewHistogramVec("vehicle_repository_operation_duration_seconds",
+			"Duration of the vehicle repository operations.", metrics.DefBuckets, "operation"),
+	}
+	rg.NewGaugeFunc([]metrics.Gauge{
+		{Name: "vehicles_total", Help: "Number of vehicles per tenant.", LabelNames: []string{"tenant"}},
+		{Name: "vehicles_by_fuel_type", Help: "Number of vehicles per tenant and fuel type.", LabelNames: []string{"tenant", "fuel_type"}},
+	}, s.samples)
+	return s
+}
+
+// Fleet is the interface that wraps the count of the vehicles of every tenant per fuel type.
+// It is read on every scrape, so it neither copies the vehicles nor traces the read.
+type Fleet interface {
+	CountByFuelType() (counts map[string]map[string]int)
+}
+
+// RepositoryVehicleTenantsMetrics is an struct that implements the RepositoryVehicleTenants interface.
+type RepositoryVehicleTenantsMetrics struct {
+	// rp is the measured repository.
+	rp RepositoryVehicleTenants
+	// fl counts the vehicles of the fleet gauges.
+	fl Fleet
+	// duration is the histogram of the operation timings.
+	duration *metrics.HistogramVec
+}
+
+// Tenant returns the measured repository of the tenant
+func (s *RepositoryVehicleTenantsMetrics) Tenant(id string) (rp RepositoryVehicle, err error) {
+	rp, err = s.rp.Tenant(id)
+	if err != nil {
+		return
+	}
+	rp = &RepositoryVehicleMetrics{rp: rp, duration: s.duration}
+	return
+}
+
+// Tenants returns the ids of every tenant
+func (s *RepositoryVehicleTenantsMetrics) Tenants() (ids []string) {
+	return s.rp.Tenants()
+}
+
+// samples returns the samples of the fleet gauges: the vehicles of every tenant, and those of each fuel type.
+func (s *RepositoryVehicleTenantsMetrics) samples() (samples [][]metrics.Sample) {
+	var total, fuelType []metrics.Sample
+	for id, counts := range s.fl.CountByFuelType() {
+		n := 0
+		for fuel, count := range counts {
+			n += count
+			fuelType = append(fuelType, metrics.Sample{LabelValues: []string{id, fuel}, Value: float64(count)})
+		}
+		total = append(total, metrics.Sample{LabelValues: []string{id}, Value: float64(n)})
+	}
+	samples = [][]metrics.Sample{total, fuelType}
+	return
+}
+
+// RepositoryVehicleMetrics is an struct that implements the RepositoryVehicle interface measuring every operation.
+type RepositoryVehicleMetrics struct {
+	// rp is the measured repository.
+	rp RepositoryVehicle
+	// duration is the histogram of the operation timings.
+	duration *metrics.HistogramVec
+}
+
+// observe records the duration of an operation started at start.
+func (s *RepositoryVehicleMetrics) observe(operation string, start time.Time) {
+	s.duration.Observe(time.Since(start).Seconds(), operation)
+}
+
+// GetAll returns all vehicles
+func (s *RepositoryVehicleMetrics) GetAll(ctx context.Context) (v []*domain.Vehicle, err error) {
+	defer s.observe("GetAll", time.Now())
+	return s.rp.GetAll(ctx)
+}
+
+// GetById returns the vehicle with the given id
+func (s *RepositoryVehicleMetrics) GetById(ctx context.Context, id int) (v *domain.Vehicle, err error) {
+	defer s.observe("GetById", time.Now())
+	return s.rp.GetById(ctx, id)
+}
+
+// GetByColorAndYear returns the vehicles of the color made in the year
+func (s *RepositoryVehicleMetrics) GetByColorAndYear(ctx context.Context, color string, year int) (v []*domain.Vehicle, err error) {
+	defer s.observe("GetByColorAndYear", time.Now())
+	return s.rp.GetByColorAndYear(ctx, color, year)
+}
+
+// GetByBrandAndPeriod returns the vehicles of the brand made between the years
+func (s *RepositoryVehicleMetrics) GetByBrandAndPeriod(ctx context.Context, brand string, start int, end int) (v []*domain.Vehicle, err error) {
+	defer s.observe("GetByBrandAndPeriod", time.Now())
+	return s.rp.GetByBrandAndPeriod(ctx, brand, start, end)
+}
+
+// GetSpeedAverageByBrand returns the average max speed of the brand
+func (s *RepositoryVehicleMetrics) GetSpeedAverageByBrand(ctx context.Context, brand string) (v float64, err error) {
+	defer s.observe("GetSpeedAverageByBrand", time.Now())
+	return s.rp.GetSpeedAverageByBrand(ctx, brand)
+}
+
+// GetByFuelType returns the vehicles of the fuel type
+func (s *RepositoryVehicleMetrics) GetByFuelType(ctx context.Context, fuel string) (v []*domain.Vehicle, err error) {
+	defer s.observe("GetByFuelType", time.Now())
+	return s.rp.GetByFuelType(ctx, fuel)
+}
+
+// GetByWeight returns the vehicles whose weight is in the range
+func (s *RepositoryVehicleMetrics) GetByWeight(ctx context.Context, min float64, max float64) (v []*domain.Vehicle, err error) {
+	defer s.observe("GetByWeight", time.Now())
+	return s.rp.GetByWeight(ctx, min, max)
+}
+
+// AddVehicle adds a vehicle
+func (s *RepositoryVehicleMetrics) AddVehicle(ctx context.Context, vehicle *domain.Vehicle) (v *domain.Vehicle, err error) {
+	defer s.observe("AddVehicle", time.Now())
+	return s.rp.AddVehicle(ctx, vehicle)
+}
+
+// AddVehicles adds the vehicles
+func (s *RepositoryVehicleMetrics) AddVehicles(ctx context.Context, vehicles []*domain.Vehicle) (v []*domain.Vehicle, err error) {
+	defer s.observe("AddVehicles", time.Now())
+	return s.rp.AddVehicles(ctx, vehicles)
+}
+
+// UpdateSpeed updates the max speed of a vehicle
+func (s *RepositoryVehicleMetrics) UpdateSpeed(ctx context.Context, vehicle *domain.Vehicle) (v *domain.Vehicle, err error) {
+	defer s.observe("UpdateSpeed", time.Now())
+	return s.rp.UpdateSpeed(ctx, vehicle)
+}
+
+// DeleteVehicle deletes a vehicle
+func (s *RepositoryVehicleMetrics) DeleteVehicle(ctx context.Context, id int) (v *domain.Vehicle, err error) {
+	defer s.observe("DeleteVehicle", time.Now())
+	return s.rp.DeleteVehicle(ctx, id)
+}
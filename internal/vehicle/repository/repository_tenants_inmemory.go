@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"app/internal/domain"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// NewRepositoryVehicleTenantsInMemory returns a new instance of an in memory repository for every tenant.
+func NewRepositoryVehicleTenantsInMemory(dbs map[string]map[int]*domain.VehicleAttributes, lg *slog.Logger) *RepositoryVehicleTenantsInMemory {
+	s := &RepositoryVehicleTenantsInMemory{lg: lg}
+	s.Replace(dbs)
+	return s
+}
+
+// RepositoryVehicleTenantsInMemory is an struct that implements the RepositoryVehicleTenants interface.
+type RepositoryVehicleTenantsInMemory struct {
+	// rps are the repositories of every tenant.
+	rps map[string]*RepositoryVehicleInMemory
+	// lg is the logger of the repositories.
+	lg *slog.Logger
+	// mu guards the repositories of the tenants.
+	mu sync.RWMutex
+}
+
+// Version returns the number of the last modification of the vehicles of the tenant and its time.
+// Every change made through the repository of the tenant counts, and so does every replacement of its vehicles.
+func (s *RepositoryVehicleTenantsInMemory) Version(id string) (n uint64, modified time.Time, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rp, ok := s.rps[id]
+	if !ok {
+		err = fmt.Errorf("%w. %s", ErrRepositoryTenantNotFound, id)
+		return
+	}
+	n, modified = rp.Version()
+	return
+}
+
+// Replace swaps the vehicles of every tenant for dbs.
+func (s *RepositoryVehicleTenantsInMemory) Replace(dbs map[string]map[int]*domain.VehicleAttributes) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unlock := s.lockTenants()
+	defer unlock()
+
+	s.swap(dbs)
+}
+
+// ReplaceFunc swaps the vehicles of every tenant for the ones returned by fn, which receives a copy of the current
+// vehicles. The repositories of the tenants are locked meanwhile, so no write made through them is lost and
+// no request sees them half replaced.
+func (s *RepositoryVehicleTenantsInMemory) ReplaceFunc(fn func(current map[string]map[int]*domain.VehicleAttributes) (dbs map[string]map[int]*domain.VehicleAttributes)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unlock := s.lockTenants()
+	defer unlock()
+
+	current := make(map[string]map[int]*domain.VehicleAttributes, len(s.rps))
+	for id, rp := range s.rps {
+		current[id] = copyDB(rp.db)
+	}
+	s.swap(fn(current))
+}
+
+// lockTenants locks the repository of every tenant for writing and returns the function unlocking them.
+// The caller holds the lock.
+func (s *RepositoryVehicleTenantsInMemory) lockTenants() (unlock func()) {
+	rps := make([]*RepositoryVehicleInMemory, 0, len(s.rps))
+	for _, rp := range s.rps {
+		rp.mu.Lock()
+		rps = append(rps, rp)
+	}
+	unlock = func() {
+		for _, rp := range rps {
+			rp.mu.Unlock()
+		}
+	}
+	return
+}
+
+// swap sets dbs as the vehicles of the tenants. The requests may hold the repository of a tenant,
+// so the repositories of the tenants still loaded hold the new vehicles in place of being replaced.
+// The caller holds the lock and the locks of the repositories of the tenants.
+func (s *RepositoryVehicleTenantsInMemory) swap(dbs map[string]map[int]*domain.VehicleAttributes) {
+	rps := make(map[string]*RepositoryVehicleInMemory, len(dbs))
+	for id, db := range dbs {
+		if rp, ok := s.rps[id]; ok {
+			rp.db = db
+			rp.version.Bump()
+			rps[id] = rp
+			continue
+		}
+		rps[id] = NewRepositoryVehicleInMemory(db, s.lg.With("tenant", id))
+	}
+	s.rps = rps
+}
+
+// Snapshot returns a copy of the vehicles of every tenant.
+func (s *RepositoryVehicleTenantsInMemory) Snapshot() (dbs map[string]map[int]*domain.VehicleAttributes) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.snapshot()
+}
+
+// snapshot returns a copy of the vehicles of every tenant. The caller holds the lock.
+func (s *RepositoryVehicleTenantsInMemory) snapshot() (dbs map[string]map[int]*domain.VehicleAttributes) {
+	dbs = make(map[string]map[int]*domain.VehicleAttributes, len(s.rps))
+	for id, rp := range s.rps {
+		dbs[id] = rp.snapshot()
+	}
+	return
+}
+
+// CountByFuelType returns the number of vehicles of every tenant per fuel type, counted in place.
+func (s *RepositoryVehicleTenantsInMemory) CountByFuelType() (counts map[string]map[string]int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts = make(map[string]map[string]int, len(s.rps))
+	for id, rp := range s.rps {
+		counts[id] = rp.countByFuelType()
+	}
+	return
+}
+
+// Tenant returns the repository of the tenant
+func (s *RepositoryVehicleTenantsInMemory) Tenant(id string) (rp RepositoryVehicle, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r, ok := s.rps[id]
+	if !ok {
+		err = fmt.Errorf("%w. %s", ErrRepositoryTenantNotFound, id)
+		return
+	}
+	rp = r
+	return
+}
+
+// Tenants returns the ids of every tenant
+func (s *RepositoryVehicleTenantsInMemory) Tenants() (ids []string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids = make([]string, 0, len(s.rps))
+	for id := range s.rps {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return
+}
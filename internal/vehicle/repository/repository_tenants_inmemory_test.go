@@ -0,0 +1,74 @@
+package repository_test
+
+import (
+	"app/internal/domain"
+	"app/internal/logging"
+	"app/internal/vehicle/repository"
+	"app/internal/vehicle/repository/repositorytest"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestRepositoryVehicleTenantsInMemory_Replace(t *testing.T) {
+	rps := repository.NewRepositoryVehicleTenantsInMemory(map[string]map[int]*domain.VehicleAttributes{"acme": repositorytest.Fixture()}, logging.Discard())
+	// -> a request holds the repository of the tenant across the replacement
+	held, err := rps.Tenant("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rps.Replace(map[string]map[int]*domain.VehicleAttributes{"acme": repositorytest.Fixture()})
+
+	if _, err = held.DeleteVehicle(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	rp, err := rps.Tenant("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = rp.GetById(context.Background(), 1); !errors.Is(err, repository.ErrRepositoryVehicleNotFound) {
+		t.Errorf("GetById of the vehicle deleted through the held repository: err = %v, want %v", err, repository.ErrRepositoryVehicleNotFound)
+	}
+}
+
+func TestRepositoryVehicleTenantsInMemory_ReplaceFunc(t *testing.T) {
+	rps := repository.NewRepositoryVehicleTenantsInMemory(map[string]map[int]*domain.VehicleAttributes{"acme": repositorytest.Fixture()}, logging.Discard())
+	held, err := rps.Tenant("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// -> the vehicles are added through the held repository while the reloads keep the current vehicles
+	const n = 200
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				rps.ReplaceFunc(func(current map[string]map[int]*domain.VehicleAttributes) map[string]map[int]*domain.VehicleAttributes {
+					return current
+				})
+			}
+		}
+	}()
+	for id := 100; id < 100+n; id++ {
+		v := domain.Vehicle{Id: id, Attributes: domain.VehicleAttributes{Brand: "Ford", Registration: fmt.Sprintf("%04d-CCC", id)}}
+		if _, err = held.AddVehicle(context.Background(), &v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	vehicles := rps.Snapshot()["acme"]
+	if got, want := len(vehicles), len(repositorytest.Fixture())+n; got != want {
+		t.Errorf("vehicles after the reloads = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// sequence numbers the modifications of every version, so no two versions of the process are ever the same,
+// even those of a tenant that was removed and loaded again.
+var sequence atomic.Uint64
+
+// NewVersion returns a new instance of a version, modified now.
+func NewVersion() *Version {
+	v := &Version{}
+	v.Bump()
+	return v
+}
+
+// Version is an struct that represents the number of the last modification made to the vehicles of a repository,
+// and its time. It grows with every modification, once it is made and never before, so what is read after
+// a version is at least as recent as it. The modifications of a repository are made one at a time.
+type Version struct {
+	n        atomic.Uint64
+	modified atomic.Int64
+}
+
+// Bump numbers a modification made now.
+func (v *Version) Bump() {
+	v.modified.Store(time.Now().UnixNano())
+	v.n.Store(sequence.Add(1))
+}
+
+// Version returns the number of the last modification and its time.
+func (v *Version) Version() (n uint64, modified time.Time) {
+	n = v.n.Load()
+	modified = time.Unix(0, v.modified.Load())
+	return
+}
@@ -0,0 +1,53 @@
+package repositorytest
+
+import (
+	"app/internal/domain"
+	"fmt"
+)
+
+// Fixture returns the dataset every repository of the suite starts with, a new copy on each call.
+// It is small enough to reason about each case by hand:
+//
+//	id  brand      color  year  max_speed  fuel_type  weight
+//	1   Ford       Red    2000  100        gasoline   100.5
+//	2   Ford       Blue   2005  150        diesel     150
+//	3   Ford       Red    2010  200        gasoline   200.25
+//	4   Toyota     Red    2000  120        diesel     99.99
+//	5   Toyota     White  2015  180        gas        250
+//	6   Chevrolet  Black  1999  400        biodiesel  300
+func Fixture() map[int]*domain.VehicleAttributes {
+	return map[int]*domain.VehicleAttributes{
+		1: vehicle("Ford", "Mustang", "0001-BBB", "Red", 2000, 100, "gasoline", 100.5),
+		2: vehicle("Ford", "Ranger", "0002-BBB", "Blue", 2005, 150, "diesel", 150),
+		3: vehicle("Ford", "Escape", "0003-BBB", "Red", 2010, 200, "gasoline", 200.25),
+		4: vehicle("Toyota", "Camry", "0004-BBB", "Red", 2000, 120, "diesel", 99.99),
+		5: vehicle("Toyota", "RAV4", "0005-BBB", "White", 2015, 180, "gas", 250),
+		6: vehicle("Chevrolet", "Camaro", "0006-BBB", "Black", 1999, 400, "biodiesel", 300),
+	}
+}
+
+// vehicle returns the attributes of a vehicle of the fixture.
+func vehicle(brand, model, registration, color string, year, maxSpeed int, fuelType string, weight float64) *domain.VehicleAttributes {
+	return &domain.VehicleAttributes{
+		Brand:        brand,
+		Model:        model,
+		Registration: registration,
+		Year:         year,
+		Color:        color,
+		MaxSpeed:     maxSpeed,
+		FuelType:     fuelType,
+		Transmission: "manual",
+		Passengers:   4,
+		Height:       150,
+		Width:        180,
+		Weight:       weight,
+	}
+}
+
+// newVehicle returns a vehicle that is not in the fixture.
+func newVehicle(id int) *domain.Vehicle {
+	return &domain.Vehicle{
+		Id:         id,
+		Attributes: *vehicle("Mazda", "MX-5", fmt.Sprintf("%04d-CCC", id), "Green", 2020, 210, "gasoline", 90),
+	}
+}
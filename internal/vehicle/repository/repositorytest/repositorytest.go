@@ -0,0 +1,578 @@
+// Package repositorytest is the conformance suite of the RepositoryVehicle implementations.
+// It pins down the contract of every method, so that every backend behaves as the in-memory repository:
+//
+//	func TestRepositoryVehicleInMemory(t *testing.T) {
+//		repositorytest.Run(t, func(t *testing.T, db map[int]*domain.VehicleAttributes) repository.RepositoryVehicle {
+//			return repository.NewRepositoryVehicleInMemory(db, logging.Discard())
+//		})
+//	}
+//
+// The contract, beyond the doc comments of RepositoryVehicle:
+//   - the order of the vehicles returned is unspecified;
+//   - GetAll returns ErrRepositoryVehicleNotFound when the repository is empty, and so do the filters,
+//     which return ErrRepositoryVehicleNotFoundWithValue when the repository has vehicles but none matches;
+//   - filters match strings exactly, GetByBrandAndPeriod includes the start year but excludes the end year,
+//     and GetByWeight includes both bounds;
+//   - GetById, UpdateSpeed and DeleteVehicle return ErrRepositoryVehicleNotFound for a missing id;
+//   - AddVehicle returns ErrRepositoryVehicleExist for an existing id and leaves it untouched;
+//   - AddVehicles adds all the vehicles or, when any id exists, none of them; repeated ids within a batch are unspecified;
+//   - UpdateSpeed only changes the max speed, which must be between 0 and 400 or else ErrRepositoryImposibleMaxSpeed;
+//   - the vehicles returned and given are copies: changing them does not change the repository;
+//   - on a done context the scans and the changes return ErrRepositoryVehicleCanceled wrapping the error of the
+//     context, and the changes are not made;
+//   - the methods are safe for concurrent use.
+package repositorytest
+
+import (
+	"app/internal/domain"
+	"app/internal/vehicle/repository"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// Factory returns a new repository holding the vehicles of db, which it may keep.
+// Each case of the suite asks for a repository of its own.
+type Factory func(t *testing.T, db map[int]*domain.VehicleAttributes) repository.RepositoryVehicle
+
+// Run runs the suite against the repositories of the factory.
+func Run(t *testing.T, factory Factory) {
+	s := &suite{factory: factory}
+	cases := []struct {
+		name string
+		run  func(t *testing.T)
+	}{
+		{"GetAll", s.getAll},
+		{"GetById", s.getById},
+		{"GetByColorAndYear", s.getByColorAndYear},
+		{"GetByBrandAndPeriod", s.getByBrandAndPeriod},
+		{"GetSpeedAverageByBrand", s.getSpeedAverageByBrand},
+		{"GetByFuelType", s.getByFuelType},
+		{"GetByWeight", s.getByWeight},
+		{"AddVehicle", s.addVehicle},
+		{"AddVehicles", s.addVehicles},
+		{"UpdateSpeed", s.updateSpeed},
+		{"DeleteVehicle", s.deleteVehicle},
+		{"Copies", s.copies},
+		{"Canceled", s.canceled},
+		{"Concurrent", s.concurrent},
+	}
+	for _, c := range cases {
+		t.Run(c.name, c.run)
+	}
+}
+
+// suite is an struct that holds the factory of the repositories under test.
+type suite struct {
+	factory Factory
+}
+
+// fixture returns a new repository with the fixture.
+func (s *suite) fixture(t *testing.T) repository.RepositoryVehicle {
+	t.Helper()
+	return s.factory(t, Fixture())
+}
+
+// empty returns a new empty repository.
+func (s *suite) empty(t *testing.T) repository.RepositoryVehicle {
+	t.Helper()
+	return s.factory(t, map[int]*domain.VehicleAttributes{})
+}
+
+func (s *suite) getAll(t *testing.T) {
+	t.Run("returns every vehicle", func(t *testing.T) {
+		v, err := s.fixture(t).GetAll(context.Background())
+		expectError(t, err, nil)
+		expectVehicles(t, v, Fixture(), 1, 2, 3, 4, 5, 6)
+	})
+	t.Run("empty repository", func(t *testing.T) {
+		v, err := s.empty(t).GetAll(context.Background())
+		expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+		expectLen(t, v, 0)
+	})
+}
+
+func (s *suite) getById(t *testing.T) {
+	t.Run("existing id", func(t *testing.T) {
+		v, err := s.fixture(t).GetById(context.Background(), 3)
+		expectError(t, err, nil)
+		expectVehicle(t, v, 3, Fixture()[3])
+	})
+	t.Run("missing id", func(t *testing.T) {
+		_, err := s.fixture(t).GetById(context.Background(), 99)
+		expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+	})
+}
+
+func (s *suite) getByColorAndYear(t *testing.T) {
+	cases := []struct {
+		name  string
+		color string
+		year  int
+		ids   []int
+		err   error
+	}{
+		{"matches color and year", "Red", 2000, []int{1, 4}, nil},
+		{"single match", "Red", 2010, []int{3}, nil},
+		{"color of another year", "Blue", 2000, nil, repository.ErrRepositoryVehicleNotFoundWithValue},
+		{"color is case sensitive", "red", 2000, nil, repository.ErrRepositoryVehicleNotFoundWithValue},
+		{"unknown color", "Purple", 2000, nil, repository.ErrRepositoryVehicleNotFoundWithValue},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := s.fixture(t).GetByColorAndYear(context.Background(), c.color, c.year)
+			expectError(t, err, c.err)
+			expectVehicles(t, v, Fixture(), c.ids...)
+		})
+	}
+	t.Run("empty repository", func(t *testing.T) {
+		_, err := s.empty(t).GetByColorAndYear(context.Background(), "Red", 2000)
+		expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+	})
+}
+
+func (s *suite) getByBrandAndPeriod(t *testing.T) {
+	cases := []struct {
+		name       string
+		brand      string
+		start, end int
+		ids        []int
+		err        error
+	}{
+		{"whole period", "Ford", 1900, 2100, []int{1, 2, 3}, nil},
+		{"start year is included", "Ford", 2005, 2100, []int{2, 3}, nil},
+		{"end year is excluded", "Ford", 2000, 2010, []int{1, 2}, nil},
+		{"single year", "Ford", 2005, 2006, []int{2}, nil},
+		{"start equal to end", "Ford", 2005, 2005, nil, repository.ErrRepositoryVehicleNotFoundWithValue},
+		{"start after end", "Ford", 2010, 2000, nil, repository.ErrRepositoryVehicleNotFoundWithValue},
+		{"brand out of the period", "Chevrolet", 2000, 2100, nil, repository.ErrRepositoryVehicleNotFoundWithValue},
+		{"unknown brand", "Tesla", 1900, 2100, nil, repository.ErrRepositoryVehicleNotFoundWithValue},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := s.fixture(t).GetByBrandAndPeriod(context.Background(), c.brand, c.start, c.end)
+			expectError(t, err, c.err)
+			expectVehicles(t, v, Fixture(), c.ids...)
+		})
+	}
+	t.Run("empty repository", func(t *testing.T) {
+		_, err := s.empty(t).GetByBrandAndPeriod(context.Background(), "Ford", 1900, 2100)
+		expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+	})
+}
+
+func (s *suite) getSpeedAverageByBrand(t *testing.T) {
+	cases := []struct {
+		name    string
+		brand   string
+		average float64
+		err     error
+	}{
+		{"average of the brand", "Ford", 150, nil},
+		{"two vehicles", "Toyota", 150, nil},
+		{"single vehicle", "Chevrolet", 400, nil},
+		{"unknown brand", "Tesla", 0, repository.ErrRepositoryVehicleNotFoundWithValue},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			average, err := s.fixture(t).GetSpeedAverageByBrand(context.Background(), c.brand)
+			expectError(t, err, c.err)
+			if err == nil && math.Abs(average-c.average) > 1e-9 {
+				t.Errorf("average = %v, want %v", average, c.average)
+			}
+		})
+	}
+	t.Run("average is not truncated", func(t *testing.T) {
+		rp := s.fixture(t)
+		_, err := rp.AddVehicle(context.Background(), &domain.Vehicle{Id: 7, Attributes: domain.VehicleAttributes{Brand: "Chevrolet", Registration: "0007-BBB", MaxSpeed: 1}})
+		expectError(t, err, nil)
+		average, err := rp.GetSpeedAverageByBrand(context.Background(), "Chevrolet")
+		expectError(t, err, nil)
+		if average != 200.5 {
+			t.Errorf("average = %v, want 200.5", average)
+		}
+	})
+	t.Run("empty repository", func(t *testing.T) {
+		_, err := s.empty(t).GetSpeedAverageByBrand(context.Background(), "Ford")
+		expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+	})
+}
+
+func (s *suite) getByFuelType(t *testing.T) {
+	cases := []struct {
+		name string
+		fuel string
+		ids  []int
+		err  error
+	}{
+		{"matches the fuel type", "gasoline", []int{1, 3}, nil},
+		{"gas is not gasoline", "gas", []int{5}, nil},
+		{"unknown fuel type", "electric", nil, repository.ErrRepositoryVehicleNotFoundWithValue},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := s.fixture(t).GetByFuelType(context.Background(), c.fuel)
+			expectError(t, err, c.err)
+			expectVehicles(t, v, Fixture(), c.ids...)
+		})
+	}
+	t.Run("empty repository", func(t *testing.T) {
+		_, err := s.empty(t).GetByFuelType(context.Background(), "gas")
+		expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+	})
+}
+
+func (s *suite) getByWeight(t *testing.T) {
+	cases := []struct {
+		name     string
+		min, max float64
+		ids      []int
+		err      error
+	}{
+		{"every weight", 0, 1000, []int{1, 2, 3, 4, 5, 6}, nil},
+		{"both bounds are included", 100.5, 200.25, []int{1, 2, 3}, nil},
+		{"fractional bounds", 99.995, 100.5, []int{1}, nil},
+		{"single weight", 150, 150, []int{2}, nil},
+		{"min after max", 200, 100, nil, repository.ErrRepositoryVehicleNotFoundWithValue},
+		{"no weight in range", 400, 500, nil, repository.ErrRepositoryVehicleNotFoundWithValue},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := s.fixture(t).GetByWeight(context.Background(), c.min, c.max)
+			expectError(t, err, c.err)
+			expectVehicles(t, v, Fixture(), c.ids...)
+		})
+	}
+	t.Run("empty repository", func(t *testing.T) {
+		_, err := s.empty(t).GetByWeight(context.Background(), 0, 1000)
+		expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+	})
+}
+
+func (s *suite) addVehicle(t *testing.T) {
+	t.Run("new id", func(t *testing.T) {
+		rp := s.fixture(t)
+		vehicle := newVehicle(7)
+		v, err := rp.AddVehicle(context.Background(), vehicle)
+		expectError(t, err, nil)
+		expectVehicle(t, v, 7, &vehicle.Attributes)
+
+		got, err := rp.GetById(context.Background(), 7)
+		expectError(t, err, nil)
+		expectVehicle(t, got, 7, &newVehicle(7).Attributes)
+		all, err := rp.GetAll(context.Background())
+		expectError(t, err, nil)
+		expectLen(t, all, 7)
+	})
+	t.Run("empty repository", func(t *testing.T) {
+		rp := s.empty(t)
+		_, err := rp.AddVehicle(context.Background(), newVehicle(1))
+		expectError(t, err, nil)
+		all, err := rp.GetAll(context.Background())
+		expectError(t, err, nil)
+		expectLen(t, all, 1)
+	})
+	t.Run("existing id", func(t *testing.T) {
+		rp := s.fixture(t)
+		_, err := rp.AddVehicle(context.Background(), newVehicle(1))
+		expectError(t, err, repository.ErrRepositoryVehicleExist)
+
+		got, err := rp.GetById(context.Background(), 1)
+		expectError(t, err, nil)
+		expectVehicle(t, got, 1, Fixture()[1])
+	})
+}
+
+func (s *suite) addVehicles(t *testing.T) {
+	t.Run("new ids", func(t *testing.T) {
+		rp := s.fixture(t)
+		v, err := rp.AddVehicles(context.Background(), []*domain.Vehicle{newVehicle(7), newVehicle(8)})
+		expectError(t, err, nil)
+		expectLen(t, v, 2)
+
+		all, err := rp.GetAll(context.Background())
+		expectError(t, err, nil)
+		expectLen(t, all, 8)
+		for _, id := range []int{7, 8} {
+			got, err := rp.GetById(context.Background(), id)
+			expectError(t, err, nil)
+			expectVehicle(t, got, id, &newVehicle(id).Attributes)
+		}
+	})
+	t.Run("any existing id adds none", func(t *testing.T) {
+		rp := s.fixture(t)
+		_, err := rp.AddVehicles(context.Background(), []*domain.Vehicle{newVehicle(7), newVehicle(2), newVehicle(8)})
+		expectError(t, err, repository.ErrRepositoryVehicleExist)
+
+		for _, id := range []int{7, 8} {
+			_, err := rp.GetById(context.Background(), id)
+			expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+		}
+		got, err := rp.GetById(context.Background(), 2)
+		expectError(t, err, nil)
+		expectVehicle(t, got, 2, Fixture()[2])
+	})
+	t.Run("empty batch", func(t *testing.T) {
+		rp := s.fixture(t)
+		v, err := rp.AddVehicles(context.Background(), nil)
+		expectError(t, err, nil)
+		expectLen(t, v, 0)
+		all, err := rp.GetAll(context.Background())
+		expectError(t, err, nil)
+		expectLen(t, all, 6)
+	})
+}
+
+func (s *suite) updateSpeed(t *testing.T) {
+	cases := []struct {
+		name  string
+		id    int
+		speed int
+		err   error
+	}{
+		{"new speed", 1, 250, nil},
+		{"lowest speed", 1, 0, nil},
+		{"highest speed", 1, 400, nil},
+		{"negative speed", 1, -1, repository.ErrRepositoryImposibleMaxSpeed},
+		{"too high speed", 1, 401, repository.ErrRepositoryImposibleMaxSpeed},
+		{"missing id", 99, 250, repository.ErrRepositoryVehicleNotFound},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rp := s.fixture(t)
+			// only the id and the speed are read, the other attributes are ignored
+			v, err := rp.UpdateSpeed(context.Background(), &domain.Vehicle{Id: c.id, Attributes: domain.VehicleAttributes{Brand: "ignored", MaxSpeed: c.speed}})
+			expectError(t, err, c.err)
+
+			want := Fixture()[c.id]
+			if want == nil {
+				return
+			}
+			if c.err == nil {
+				want.MaxSpeed = c.speed
+				expectVehicle(t, v, c.id, want)
+			}
+			got, err := rp.GetById(context.Background(), c.id)
+			expectError(t, err, nil)
+			expectVehicle(t, got, c.id, want)
+		})
+	}
+}
+
+func (s *suite) deleteVehicle(t *testing.T) {
+	t.Run("existing id", func(t *testing.T) {
+		rp := s.fixture(t)
+		v, err := rp.DeleteVehicle(context.Background(), 4)
+		expectError(t, err, nil)
+		expectVehicle(t, v, 4, Fixture()[4])
+
+		_, err = rp.GetById(context.Background(), 4)
+		expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+		all, err := rp.GetAll(context.Background())
+		expectError(t, err, nil)
+		expectVehicles(t, all, Fixture(), 1, 2, 3, 5, 6)
+
+		_, err = rp.DeleteVehicle(context.Background(), 4)
+		expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+	})
+	t.Run("missing id", func(t *testing.T) {
+		rp := s.fixture(t)
+		_, err := rp.DeleteVehicle(context.Background(), 99)
+		expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+		all, err := rp.GetAll(context.Background())
+		expectError(t, err, nil)
+		expectLen(t, all, 6)
+	})
+	t.Run("last vehicle", func(t *testing.T) {
+		rp := s.factory(t, map[int]*domain.VehicleAttributes{1: Fixture()[1]})
+		_, err := rp.DeleteVehicle(context.Background(), 1)
+		expectError(t, err, nil)
+		_, err = rp.GetAll(context.Background())
+		expectError(t, err, repository.ErrRepositoryVehicleNotFound)
+	})
+	t.Run("id is free again", func(t *testing.T) {
+		rp := s.fixture(t)
+		_, err := rp.DeleteVehicle(context.Background(), 1)
+		expectError(t, err, nil)
+		_, err = rp.AddVehicle(context.Background(), newVehicle(1))
+		expectError(t, err, nil)
+	})
+}
+
+func (s *suite) copies(t *testing.T) {
+	t.Run("returned vehicles", func(t *testing.T) {
+		rp := s.fixture(t)
+		v, err := rp.GetById(context.Background(), 1)
+		expectError(t, err, nil)
+		v.Attributes.Brand = "changed"
+		all, err := rp.GetAll(context.Background())
+		expectError(t, err, nil)
+		for _, vehicle := range all {
+			vehicle.Attributes.MaxSpeed = -1
+		}
+
+		got, err := rp.GetById(context.Background(), 1)
+		expectError(t, err, nil)
+		expectVehicle(t, got, 1, Fixture()[1])
+	})
+	t.Run("added vehicles", func(t *testing.T) {
+		rp := s.fixture(t)
+		vehicle := newVehicle(7)
+		_, err := rp.AddVehicle(context.Background(), vehicle)
+		expectError(t, err, nil)
+		batch := []*domain.Vehicle{newVehicle(8)}
+		_, err = rp.AddVehicles(context.Background(), batch)
+		expectError(t, err, nil)
+		vehicle.Attributes.Brand = "changed"
+		batch[0].Attributes.Brand = "changed"
+
+		for _, id := range []int{7, 8} {
+			got, err := rp.GetById(context.Background(), id)
+			expectError(t, err, nil)
+			expectVehicle(t, got, id, &newVehicle(id).Attributes)
+		}
+	})
+}
+
+func (s *suite) canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	operations := []struct {
+		name string
+		run  func(rp repository.RepositoryVehicle) error
+	}{
+		{"GetAll", func(rp repository.RepositoryVehicle) (err error) { _, err = rp.GetAll(ctx); return }},
+		{"GetByColorAndYear", func(rp repository.RepositoryVehicle) (err error) {
+			_, err = rp.GetByColorAndYear(ctx, "Red", 2000)
+			return
+		}},
+		{"GetByBrandAndPeriod", func(rp repository.RepositoryVehicle) (err error) {
+			_, err = rp.GetByBrandAndPeriod(ctx, "Ford", 1900, 2100)
+			return
+		}},
+		{"GetSpeedAverageByBrand", func(rp repository.RepositoryVehicle) (err error) {
+			_, err = rp.GetSpeedAverageByBrand(ctx, "Ford")
+			return
+		}},
+		{"GetByFuelType", func(rp repository.RepositoryVehicle) (err error) { _, err = rp.GetByFuelType(ctx, "gas"); return }},
+		{"GetByWeight", func(rp repository.RepositoryVehicle) (err error) { _, err = rp.GetByWeight(ctx, 0, 1000); return }},
+		{"AddVehicle", func(rp repository.RepositoryVehicle) (err error) { _, err = rp.AddVehicle(ctx, newVehicle(7)); return }},
+		{"AddVehicles", func(rp repository.RepositoryVehicle) (err error) {
+			_, err = rp.AddVehicles(ctx, []*domain.Vehicle{newVehicle(7), newVehicle(8)})
+			return
+		}},
+		{"UpdateSpeed", func(rp repository.RepositoryVehicle) (err error) {
+			_, err = rp.UpdateSpeed(ctx, &domain.Vehicle{Id: 1, Attributes: domain.VehicleAttributes{MaxSpeed: 250}})
+			return
+		}},
+		{"DeleteVehicle", func(rp repository.RepositoryVehicle) (err error) { _, err = rp.DeleteVehicle(ctx, 1); return }},
+	}
+	for _, op := range operations {
+		t.Run(op.name, func(t *testing.T) {
+			rp := s.fixture(t)
+			err := op.run(rp)
+			expectError(t, err, repository.ErrRepositoryVehicleCanceled)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("error %v does not wrap %v", err, context.Canceled)
+			}
+
+			// nothing changed
+			all, err := rp.GetAll(context.Background())
+			expectError(t, err, nil)
+			expectVehicles(t, all, Fixture(), 1, 2, 3, 4, 5, 6)
+		})
+	}
+}
+
+func (s *suite) concurrent(t *testing.T) {
+	rp := s.fixture(t)
+	const writers = 8
+	const perWriter = 25
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				id := 100 + w*perWriter + i
+				if _, err := rp.AddVehicle(context.Background(), newVehicle(id)); err != nil {
+					t.Errorf("AddVehicle(%d): %v", id, err)
+				}
+				if _, err := rp.UpdateSpeed(context.Background(), &domain.Vehicle{Id: id, Attributes: domain.VehicleAttributes{MaxSpeed: 300}}); err != nil {
+					t.Errorf("UpdateSpeed(%d): %v", id, err)
+				}
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				if _, err := rp.GetAll(context.Background()); err != nil {
+					t.Errorf("GetAll: %v", err)
+				}
+				if _, err := rp.GetByFuelType(context.Background(), "gasoline"); err != nil {
+					t.Errorf("GetByFuelType: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	all, err := rp.GetAll(context.Background())
+	expectError(t, err, nil)
+	expectLen(t, all, 6+writers*perWriter)
+}
+
+// expectError fails the test unless err is want, nil included, or wraps it.
+func expectError(t *testing.T, err, want error) {
+	t.Helper()
+	switch {
+	case want == nil && err != nil:
+		t.Fatalf("unexpected error: %v", err)
+	case want != nil && !errors.Is(err, want):
+		t.Fatalf("error = %v, want %v", err, want)
+	}
+}
+
+// expectLen fails the test unless there are n vehicles.
+func expectLen(t *testing.T, v []*domain.Vehicle, n int) {
+	t.Helper()
+	if len(v) != n {
+		t.Fatalf("%d vehicles, want %d", len(v), n)
+	}
+}
+
+// expectVehicle fails the test unless the vehicle has the id and the attributes.
+func expectVehicle(t *testing.T, v *domain.Vehicle, id int, want *domain.VehicleAttributes) {
+	t.Helper()
+	if v == nil {
+		t.Fatalf("vehicle %d is nil", id)
+	}
+	if v.Id != id || !reflect.DeepEqual(v.Attributes, *want) {
+		t.Fatalf("vehicle = %d %+v, want %d %+v", v.Id, v.Attributes, id, *want)
+	}
+}
+
+// expectVehicles fails the test unless the vehicles are the ones of the ids in db, in any order.
+func expectVehicles(t *testing.T, v []*domain.Vehicle, db map[int]*domain.VehicleAttributes, ids ...int) {
+	t.Helper()
+	got := make([]int, len(v))
+	for i, vehicle := range v {
+		got[i] = vehicle.Id
+	}
+	sort.Ints(got)
+	want := append([]int(nil), ids...)
+	sort.Ints(want)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("ids = %v, want %v", got, want)
+	}
+	for _, vehicle := range v {
+		expectVehicle(t, vehicle, vehicle.Id, db[vehicle.Id])
+	}
+}
@@ -0,0 +1,68 @@
+package saver
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// writeAtomic writes a file through write, so it is either left untouched or replaced as a whole:
+// the data goes to a temporary file of the same directory, which is renamed over the file once complete.
+// Files ending in .gz are gzip compressed.
+func writeAtomic(path string, write func(w io.Writer) error) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	bw := bufio.NewWriter(tmp)
+	var w io.Writer = bw
+	var gz *gzip.Writer
+	if strings.HasSuffix(path, ".gz") {
+		gz = gzip.NewWriter(bw)
+		w = gz
+	}
+	if err = write(w); err != nil {
+		return
+	}
+	if gz != nil {
+		if err = gz.Close(); err != nil {
+			err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+			return
+		}
+	}
+	if err = bw.Flush(); err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+		return
+	}
+
+	// the data is on disk before the file is replaced
+	if err = tmp.Sync(); err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+		return
+	}
+	if err = tmp.Chmod(0o644); err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+		return
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+		return
+	}
+	return
+}
@@ -0,0 +1,160 @@
+package saver
+
+import (
+	"app/internal/domain"
+	"app/internal/vehicle/loader"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Encoder is the interface that wraps the writing of vehicles one at a time, so a dataset is never held in memory as a whole.
+// Unlike the savers, the encoders write the vehicles as given, repeated ids included.
+type Encoder interface {
+	// Encode writes a vehicle
+	Encode(v *domain.Vehicle) (err error)
+	// Close ends the data written, without closing the writer
+	Close() (err error)
+}
+
+// NewEncoder returns the encoder of a format, writing the files read by the loader of the format.
+func NewEncoder(w io.Writer, format string, opts Options) (enc Encoder, err error) {
+	switch format {
+	case loader.FormatJSON:
+		enc = &encoderJSON{w: w}
+	case loader.FormatCSV:
+		delimiter := opts.Delimiter
+		if delimiter == 0 {
+			delimiter = ','
+		}
+		cw := csv.NewWriter(w)
+		cw.Comma = delimiter
+		enc = &encoderCSV{w: cw, columns: opts.Columns}
+	case loader.FormatNDJSON:
+		enc = &encoderNDJSON{enc: json.NewEncoder(w)}
+	default:
+		err = fmt.Errorf("%w. %q", ErrSaverVehicleFormat, format)
+	}
+	return
+}
+
+// encoderJSON is an struct that implements the Encoder interface for a json array, a vehicle per line.
+type encoderJSON struct {
+	w io.Writer
+	// n is the number of vehicles written.
+	n int
+}
+
+// Encode writes a vehicle.
+func (e *encoderJSON) Encode(v *domain.Vehicle) (err error) {
+	b, err := json.Marshal(vehicleJSON(v.Id, &v.Attributes))
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+		return
+	}
+	sep := ",\n"
+	if e.n == 0 {
+		sep = "["
+	}
+	if _, err = fmt.Fprintf(e.w, "%s%s", sep, b); err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+		return
+	}
+	e.n++
+	return
+}
+
+// Close ends the array.
+func (e *encoderJSON) Close() (err error) {
+	end := "]\n"
+	if e.n == 0 {
+		end = "[]\n"
+	}
+	if _, err = io.WriteString(e.w, end); err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+	}
+	return
+}
+
+// encoderNDJSON is an struct that implements the Encoder interface for a json vehicle per line.
+type encoderNDJSON struct {
+	enc *json.Encoder
+}
+
+// Encode writes a vehicle.
+func (e *encoderNDJSON) Encode(v *domain.Vehicle) (err error) {
+	if err = e.enc.Encode(vehicleJSON(v.Id, &v.Attributes)); err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+	}
+	return
+}
+
+// Close does nothing, the lines are complete.
+func (e *encoderNDJSON) Close() (err error) {
+	return
+}
+
+// encoderCSV is an struct that implements the Encoder interface for csv with a header row.
+type encoderCSV struct {
+	w *csv.Writer
+	// columns maps a field of the vehicle to the header of its column.
+	columns map[string]string
+	// header is set once the header row is written.
+	header bool
+}
+
+// Encode writes a vehicle, after the header row if it is the first one.
+func (e *encoderCSV) Encode(v *domain.Vehicle) (err error) {
+	if !e.header {
+		e.writeHeader()
+	}
+	a := v.Attributes
+	e.w.Write([]string{
+		strconv.Itoa(v.Id),
+		a.Brand,
+		a.Model,
+		a.Registration,
+		strconv.Itoa(a.Year),
+		a.Color,
+		strconv.Itoa(a.MaxSpeed),
+		a.FuelType,
+		a.Transmission,
+		strconv.Itoa(a.Passengers),
+		strconv.FormatFloat(a.Height, 'f', -1, 64),
+		strconv.FormatFloat(a.Width, 'f', -1, 64),
+		strconv.FormatFloat(a.Weight, 'f', -1, 64),
+	})
+	return e.flushError()
+}
+
+// Close writes the header row if no vehicle was written, and flushes the rows.
+func (e *encoderCSV) Close() (err error) {
+	if !e.header {
+		e.writeHeader()
+	}
+	e.w.Flush()
+	return e.flushError()
+}
+
+// writeHeader writes the header row.
+func (e *encoderCSV) writeHeader() {
+	header := make([]string, len(loader.Fields))
+	for i, field := range loader.Fields {
+		header[i] = field
+		if c, ok := e.columns[field]; ok {
+			header[i] = c
+		}
+	}
+	e.w.Write(header)
+	e.header = true
+}
+
+// flushError returns the error of the last write or flush.
+func (e *encoderCSV) flushError() (err error) {
+	if err = e.w.Error(); err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+	}
+	return
+}
@@ -0,0 +1,37 @@
+package saver
+
+import (
+	"app/internal/domain"
+	"errors"
+	"sort"
+)
+
+var (
+	// ErrSaverVehicleInternal is returned when an internal error occurs.
+	ErrSaverVehicleInternal = errors.New("saver: internal error")
+)
+
+// SaverVehicle is the interface that wraps the basic methods for a vehicle saver, the counterpart of the LoaderVehicle.
+type SaverVehicle interface {
+	Save(v map[int]*domain.VehicleAttributes) (err error)
+}
+
+// encode writes the vehicles sorted by id with the encoder, and ends the data written.
+func encode(enc Encoder, v map[int]*domain.VehicleAttributes) (err error) {
+	for _, id := range sortedIds(v) {
+		if err = enc.Encode(&domain.Vehicle{Id: id, Attributes: *v[id]}); err != nil {
+			return
+		}
+	}
+	return enc.Close()
+}
+
+// sortedIds returns the ids of the vehicles in increasing order, so the files are written the same way every time.
+func sortedIds(v map[int]*domain.VehicleAttributes) (ids []int) {
+	ids = make([]int, 0, len(v))
+	for id := range v {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return
+}
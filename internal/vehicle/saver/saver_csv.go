@@ -0,0 +1,36 @@
+package saver
+
+import (
+	"app/internal/domain"
+	"app/internal/vehicle/loader"
+	"io"
+)
+
+// NewSaverVehicleCSV returns a new instance of a csv vehicle saver.
+// The delimiter defaults to a comma, and every field not in columns is written to a column named after it.
+func NewSaverVehicleCSV(path string, delimiter rune, columns map[string]string) *SaverVehicleCSV {
+	if delimiter == 0 {
+		delimiter = ','
+	}
+	return &SaverVehicleCSV{Path: path, Delimiter: delimiter, Columns: columns}
+}
+
+// SaverVehicleCSV is an struct that implements the SaverVehicle interface, writing the files read by the LoaderVehicleCSV.
+type SaverVehicleCSV struct {
+	Path string
+	// Delimiter is the field delimiter of the file.
+	Delimiter rune
+	// Columns maps a field of the vehicle to the header of its column in the file.
+	Columns map[string]string
+}
+
+// Save writes all vehicles after a header row.
+func (s *SaverVehicleCSV) Save(v map[int]*domain.VehicleAttributes) (err error) {
+	return writeAtomic(s.Path, func(w io.Writer) (err error) {
+		enc, err := NewEncoder(w, loader.FormatCSV, Options{Delimiter: s.Delimiter, Columns: s.Columns})
+		if err != nil {
+			return
+		}
+		return encode(enc, v)
+	})
+}
@@ -0,0 +1,57 @@
+package saver
+
+import (
+	"app/internal/vehicle/loader"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrSaverVehicleFormat is returned when the format of a data file can not be written.
+	ErrSaverVehicleFormat = errors.New("saver: unsupported format")
+)
+
+// Options is an struct that represents how the data files are written.
+type Options struct {
+	// Format is the format of the files, empty to pick it from their extension.
+	Format string
+	// Delimiter is the field delimiter of the csv files, a comma if zero.
+	Delimiter rune
+	// Columns maps a field of the vehicle to the header of its column in the csv files.
+	Columns map[string]string
+}
+
+// Extension returns the extension of the files of a format.
+func Extension(format string) (ext string, err error) {
+	switch format {
+	case loader.FormatJSON, loader.FormatCSV, loader.FormatNDJSON:
+		ext = "." + format
+	default:
+		err = fmt.Errorf("%w. %q", ErrSaverVehicleFormat, format)
+	}
+	return
+}
+
+// NewSaverVehicle returns the saver of a data file, for the format of the options or else the one of its extension.
+func NewSaverVehicle(path string, opts Options) (sv SaverVehicle, err error) {
+	format := opts.Format
+	if format == "" {
+		format, err = loader.FormatOf(path)
+		if err != nil {
+			err = fmt.Errorf("%w. %s", ErrSaverVehicleFormat, path)
+			return
+		}
+	}
+
+	switch format {
+	case loader.FormatJSON:
+		sv = NewSaverVehicleJSON(path)
+	case loader.FormatCSV:
+		sv = NewSaverVehicleCSV(path, opts.Delimiter, opts.Columns)
+	case loader.FormatNDJSON:
+		sv = NewSaverVehicleNDJSON(path)
+	default:
+		err = fmt.Errorf("%w. %q", ErrSaverVehicleFormat, format)
+	}
+	return
+}
@@ -0,0 +1,43 @@
+package saver
+
+import (
+	"app/internal/domain"
+	"app/internal/vehicle/loader"
+	"io"
+)
+
+// NewSaverVehicleJSON returns a new instance of a json vehicle saver.
+func NewSaverVehicleJSON(path string) *SaverVehicleJSON {
+	return &SaverVehicleJSON{Path: path}
+}
+
+// SaverVehicleJSON is an struct that implements the SaverVehicle interface, writing the files read by the LoaderVehicleJSON.
+type SaverVehicleJSON struct {
+	Path string
+}
+
+// Save writes all vehicles, a vehicle per line of the array.
+func (s *SaverVehicleJSON) Save(v map[int]*domain.VehicleAttributes) (err error) {
+	return writeAtomic(s.Path, func(w io.Writer) error {
+		return encode(&encoderJSON{w: w}, v)
+	})
+}
+
+// vehicleJSON returns the vehicle as read by the json loaders.
+func vehicleJSON(id int, attributes *domain.VehicleAttributes) *loader.VehicleJSON {
+	return &loader.VehicleJSON{
+		ID:           id,
+		Brand:        attributes.Brand,
+		Model:        attributes.Model,
+		Registration: attributes.Registration,
+		Year:         attributes.Year,
+		Color:        attributes.Color,
+		MaxSpeed:     attributes.MaxSpeed,
+		FuelType:     attributes.FuelType,
+		Transmission: attributes.Transmission,
+		Passengers:   attributes.Passengers,
+		Height:       attributes.Height,
+		Width:        attributes.Width,
+		Weight:       attributes.Weight,
+	}
+}
@@ -0,0 +1,24 @@
+package saver
+
+import (
+	"app/internal/domain"
+	"encoding/json"
+	"io"
+)
+
+// NewSaverVehicleNDJSON returns a new instance of a newline delimited json vehicle saver.
+func NewSaverVehicleNDJSON(path string) *SaverVehicleNDJSON {
+	return &SaverVehicleNDJSON{Path: path}
+}
+
+// SaverVehicleNDJSON is an struct that implements the SaverVehicle interface, writing a json vehicle per line.
+type SaverVehicleNDJSON struct {
+	Path string
+}
+
+// Save writes all vehicles.
+func (s *SaverVehicleNDJSON) Save(v map[int]*domain.VehicleAttributes) (err error) {
+	return writeAtomic(s.Path, func(w io.Writer) error {
+		return encode(&encoderNDJSON{enc: json.NewEncoder(w)}, v)
+	})
+}
@@ -0,0 +1,175 @@
+package saver
+
+import (
+	"app/internal/domain"
+	"app/internal/vehicle/loader"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Trigger is what started a snapshot.
+type Trigger string
+
+const (
+	TriggerManual   Trigger = "manual"
+	TriggerAutosave Trigger = "autosave"
+	TriggerShutdown Trigger = "shutdown"
+)
+
+var (
+	// ErrSaverSnapshotDisabled is returned when a snapshot is requested with no directory to write it to.
+	ErrSaverSnapshotDisabled = errors.New("saver: snapshots disabled, no directory configured")
+)
+
+// Source is the interface that wraps the copy of the vehicles of every tenant to be written.
+type Source interface {
+	Snapshot() (dbs map[string]map[int]*domain.VehicleAttributes)
+}
+
+// NewSnapshotter returns a new instance of a snapshotter writing the vehicles of every tenant to <dir>/<tenant>.<format>,
+// json if the options have no format, and gzip compressed if gz is set. An empty directory disables the snapshots.
+func NewSnapshotter(src Source, dir string, opts Options, gz bool, lg *slog.Logger) (s *Snapshotter, err error) {
+	if opts.Format == "" {
+		opts.Format = loader.FormatJSON
+	}
+	ext, err := Extension(opts.Format)
+	if err != nil {
+		return
+	}
+	if gz {
+		ext += ".gz"
+	}
+	s = &Snapshotter{src: src, dir: dir, ext: ext, opts: opts, lg: lg}
+	return
+}
+
+// Snapshotter is an struct that represents the writing of the vehicles of every tenant to disk.
+type Snapshotter struct {
+	// src returns the vehicles to write.
+	src Source
+	// dir is the directory the files are written to.
+	dir string
+	// ext is the extension of the files.
+	ext  string
+	opts Options
+	// lg is the logger of the snapshotter.
+	lg *slog.Logger
+
+	// mu serializes the snapshots.
+	mu sync.Mutex
+	// last are the vehicles of the last snapshot, so the autosaves skip the snapshots with no changes.
+	last map[string]map[int]*domain.VehicleAttributes
+
+	// muStatus guards the status, so it can be read while a snapshot runs.
+	muStatus sync.RWMutex
+	// status is the result of the last snapshot.
+	status Status
+}
+
+// Status is an struct that represents the result of a snapshot.
+type Status struct {
+	// Trigger is what started the snapshot, empty if there has been none.
+	Trigger  Trigger   `json:"trigger"`
+	Time     time.Time `json:"time"`
+	Duration string    `json:"duration"`
+	Success  bool      `json:"success"`
+	Error    string    `json:"error,omitempty"`
+	// Unchanged is set when the vehicles did not change since the last snapshot, so nothing was written.
+	Unchanged bool     `json:"unchanged"`
+	Files     []string `json:"files"`
+	Vehicles  int      `json:"vehicles"`
+}
+
+// Status returns the result of the last snapshot written or failed.
+func (s *Snapshotter) Status() (st Status) {
+	s.muStatus.RLock()
+	defer s.muStatus.RUnlock()
+
+	return s.status
+}
+
+// Snapshot writes the vehicles of every tenant, each file atomically. The automatic snapshots are skipped
+// when the vehicles did not change since the last one, while the manual ones are always written.
+func (s *Snapshotter) Snapshot(ctx context.Context, trigger Trigger) (st Status, err error) {
+	if s.dir == "" {
+		err = ErrSaverSnapshotDisabled
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st = Status{Trigger: trigger, Time: time.Now(), Files: []string{}}
+	defer func() {
+		st.Duration = time.Since(st.Time).String()
+		switch {
+		case err != nil:
+			st.Error = err.Error()
+			s.lg.ErrorContext(ctx, "vehicles snapshot failed", "trigger", trigger, "error", err)
+		case st.Unchanged:
+			// the status keeps reporting the last snapshot written
+			st.Success = true
+			s.lg.DebugContext(ctx, "vehicles snapshot skipped, no changes", "trigger", trigger)
+			return
+		default:
+			st.Success = true
+			s.lg.InfoContext(ctx, "vehicles snapshot written", "trigger", trigger, "files", len(st.Files), "vehicles", st.Vehicles)
+		}
+		s.muStatus.Lock()
+		s.status = st
+		s.muStatus.Unlock()
+	}()
+
+	dbs := s.src.Snapshot()
+	if trigger != TriggerManual && s.last != nil && reflect.DeepEqual(dbs, s.last) {
+		st.Unchanged = true
+		return
+	}
+
+	if err = os.MkdirAll(s.dir, 0o755); err != nil {
+		err = fmt.Errorf("%w. %v", ErrSaverVehicleInternal, err)
+		return
+	}
+	ids := make([]string, 0, len(dbs))
+	for id := range dbs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		path := filepath.Join(s.dir, id+s.ext)
+		var sv SaverVehicle
+		if sv, err = NewSaverVehicle(path, s.opts); err != nil {
+			return
+		}
+		if err = sv.Save(dbs[id]); err != nil {
+			err = fmt.Errorf("tenant %s: %w", id, err)
+			return
+		}
+		st.Files = append(st.Files, path)
+		st.Vehicles += len(dbs[id])
+	}
+	s.last = dbs
+	return
+}
+
+// Autosave writes a snapshot every interval, if the vehicles changed, until the context is done.
+func (s *Snapshotter) Autosave(ctx context.Context, interval time.Duration) {
+	tk := time.NewTicker(interval)
+	defer tk.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tk.C:
+			s.Snapshot(ctx, TriggerAutosave)
+		}
+	}
+}
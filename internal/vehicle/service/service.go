@@ -2,27 +2,41 @@ package service
 
 import (
 	"app/internal/domain"
+	"context"
 	"errors"
+
+	"go.opentelemetry.io/otel"
 )
 
+// tracer creates the spans of the services.
+var tracer = otel.Tracer("app/internal/vehicle/service")
+
 // ServiceVehicle is the interface that wraps the basic methods for a vehicle service.
 // - conections with external apis
 // - business logic
 type ServiceVehicle interface {
 	// GetAll returns all vehicles
-	GetAll() (v []*domain.Vehicle, err error)
-	GetByColorAndYear(color string, year int) (v []*domain.Vehicle, err error)
-	GetByBrandAndPeriod(brand string, start int, end int) (v []*domain.Vehicle, err error)
-	GetSpeedAverageByBrand(brand string) (v float64, err error)
-	GetByFuelType(fuel string) (v []*domain.Vehicle, err error)
-	GetByWeight(min float64, max float64) (v []*domain.Vehicle, err error)
+	GetAll(ctx context.Context) (v []*domain.Vehicle, err error)
+	// GetById returns the vehicle with the given id
+	GetById(ctx context.Context, id int) (v *domain.Vehicle, err error)
+	GetByColorAndYear(ctx context.Context, color string, year int) (v []*domain.Vehicle, err error)
+	GetByBrandAndPeriod(ctx context.Context, brand string, start int, end int) (v []*domain.Vehicle, err error)
+	GetSpeedAverageByBrand(ctx context.Context, brand string) (v float64, err error)
+	GetByFuelType(ctx context.Context, fuel string) (v []*domain.Vehicle, err error)
+	GetByWeight(ctx context.Context, min float64, max float64) (v []*domain.Vehicle, err error)
+
+	AddVehicle(ctx context.Context, attributes *domain.Vehicle) (v *domain.Vehicle, err error)
+	AddVehicles(ctx context.Context, vehicles []*domain.Vehicle) (v []*domain.Vehicle, err error)
 
-	AddVehicle(attributes *domain.Vehicle) (v *domain.Vehicle, err error)
-	AddVehicles(vehicles []*domain.Vehicle) (v []*domain.Vehicle, err error)
+	UpdateSpeed(ctx context.Context, vehicle *domain.Vehicle) (v *domain.Vehicle, err error)
 
-	UpdateSpeed(vehicle *domain.Vehicle) (v *domain.Vehicle, err error)
+	DeleteVehicle(ctx context.Context, id int) (v *domain.Vehicle, err error)
+}
 
-	DeleteVehicle(id int) (v *domain.Vehicle, err error)
+// ServiceVehicleTenants is the interface that resolves the vehicle service of a tenant.
+type ServiceVehicleTenants interface {
+	// Tenant returns the service of the tenant
+	Tenant(id string) (sv ServiceVehicle, err error)
 }
 
 var (
@@ -35,4 +49,28 @@ var (
 	ErrServiceVehicleExist             = errors.New("service: identificador del vehículo ya existente")
 	ErrServiceVehicleNotFoundWithValue = errors.New("service: no se encontraron vehiculos con esos criterios")
 	ErrServiceImposibleMaxSpeed        = errors.New("service: Velocidad mal formada o fuera de rango")
+
+	// ErrServiceVehicleTimeout is returned when the deadline of an operation is exceeded.
+	ErrServiceVehicleTimeout = errors.New("service: operation timed out")
+
+	// ErrServiceVehicleCanceled is returned when an operation is canceled, e.g. by a client disconnect.
+	ErrServiceVehicleCanceled = errors.New("service: operation canceled")
+
+	// ErrServiceInvalidParam is returned when a parameter of a query is malformed or out of range.
+	ErrServiceInvalidParam = errors.New("service: parámetros inválidos")
+
+	// ErrServiceTenantNotFound is returned when a tenant does not exist.
+	ErrServiceTenantNotFound = errors.New("service: tenant not found")
+
+	// ErrServiceUnauthorized is returned when the api key of a remote api is missing or invalid.
+	ErrServiceUnauthorized = errors.New("service: unauthorized")
+
+	// ErrServiceForbidden is returned when the api key of a remote api is not allowed to make a request.
+	ErrServiceForbidden = errors.New("service: forbidden")
+
+	// ErrServiceRateLimited is returned when the api key of a remote api exceeds its rate limit or its daily quota.
+	ErrServiceRateLimited = errors.New("service: rate limited")
+
+	// ErrServiceUnavailable is returned when a remote api can not serve requests, e.g. while it loads its vehicles.
+	ErrServiceUnavailable = errors.New("service: unavailable")
 )
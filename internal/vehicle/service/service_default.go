@@ -2,40 +2,78 @@ package service
 
 import (
 	"app/internal/domain"
+	"app/internal/tracing"
 	"app/internal/vehicle/repository"
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 )
 
 // ServiceVehicleDefault is an struct that represents a vehicle service.
 type ServiceVehicleDefault struct {
 	rp repository.RepositoryVehicle
+	// lg is the logger of the service.
+	lg *slog.Logger
 }
 
 // NewServiceVehicleDefault returns a new instance of a vehicle service.
-func NewServiceVehicleDefault(rp repository.RepositoryVehicle) *ServiceVehicleDefault {
-	return &ServiceVehicleDefault{rp: rp}
+func NewServiceVehicleDefault(rp repository.RepositoryVehicle, lg *slog.Logger) *ServiceVehicleDefault {
+	return &ServiceVehicleDefault{rp: rp, lg: lg}
+}
+
+// logError logs the repository error of an operation, as an error only when it is unexpected.
+func (s *ServiceVehicleDefault) logError(ctx context.Context, op string, err error) {
+	level := slog.LevelDebug
+	if errors.Is(validateErrors(err), ErrServiceVehicleInternal) {
+		level = slog.LevelError
+	}
+	s.lg.Log(ctx, level, "vehicle operation failed", "operation", op, "error", err)
 }
 
 func validateErrors(error error) (err error) {
 	switch {
+	case errors.Is(error, context.DeadlineExceeded):
+		return fmt.Errorf("%w. %v", ErrServiceVehicleTimeout, error)
+	case errors.Is(error, repository.ErrRepositoryVehicleCanceled):
+		return fmt.Errorf("%w. %v", ErrServiceVehicleCanceled, error)
 	case errors.Is(error, repository.ErrRepositoryVehicleNotFound):
-		return fmt.Errorf("%w. %v", ErrServiceVehicleNotFound, err)
+		return fmt.Errorf("%w. %v", ErrServiceVehicleNotFound, error)
 	case errors.Is(error, repository.ErrRepositoryVehicleExist):
-		return fmt.Errorf("%w. %v", ErrServiceVehicleExist, err)
+		return fmt.Errorf("%w. %v", ErrServiceVehicleExist, error)
 	case errors.Is(error, repository.ErrRepositoryVehicleNotFoundWithValue):
-		return fmt.Errorf("%w. %v", ErrServiceVehicleNotFoundWithValue, err)
+		return fmt.Errorf("%w. %v", ErrServiceVehicleNotFoundWithValue, error)
 	case errors.Is(error, repository.ErrRepositoryImposibleMaxSpeed):
-		return fmt.Errorf("%w. %v", ErrServiceImposibleMaxSpeed, err)
+		return fmt.Errorf("%w. %v", ErrServiceImposibleMaxSpeed, error)
+	case errors.Is(error, repository.ErrRepositoryTenantNotFound):
+		return fmt.Errorf("%w. %v", ErrServiceTenantNotFound, error)
 	default:
-		return fmt.Errorf("%w. %v", ErrServiceVehicleInternal, err)
+		return fmt.Errorf("%w. %v", ErrServiceVehicleInternal, error)
 	}
 }
 
 // GetAll returns all vehicles.
-func (s *ServiceVehicleDefault) GetAll() (v []*domain.Vehicle, err error) {
-	v, err = s.rp.GetAll()
+func (s *ServiceVehicleDefault) GetAll(ctx context.Context) (v []*domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "ServiceVehicleDefault.GetAll")
+	defer func() { tracing.End(span, err) }()
+
+	v, err = s.rp.GetAll(ctx)
 	if err != nil {
+		s.logError(ctx, "GetAll", err)
+		err = validateErrors(err)
+		return
+	}
+	return
+}
+
+// GetById returns the vehicle with the given id.
+func (s *ServiceVehicleDefault) GetById(ctx context.Context, id int) (v *domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "ServiceVehicleDefault.GetById")
+	defer func() { tracing.End(span, err) }()
+
+	v, err = s.rp.GetById(ctx, id)
+	if err != nil {
+		s.logError(ctx, "GetById", err)
 		err = validateErrors(err)
 		return
 	}
@@ -43,9 +81,13 @@ func (s *ServiceVehicleDefault) GetAll() (v []*domain.Vehicle, err error) {
 }
 
 // AddVehicle add a new vehicle.
-func (s *ServiceVehicleDefault) AddVehicle(vehicle *domain.Vehicle) (v *domain.Vehicle, err error) {
-	v, err = s.rp.AddVehicle(vehicle)
+func (s *ServiceVehicleDefault) AddVehicle(ctx context.Context, vehicle *domain.Vehicle) (v *domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "ServiceVehicleDefault.AddVehicle")
+	defer func() { tracing.End(span, err) }()
+
+	v, err = s.rp.AddVehicle(ctx, vehicle)
 	if err != nil {
+		s.logError(ctx, "AddVehicle", err)
 		err = validateErrors(err)
 		return
 	}
@@ -53,9 +95,13 @@ func (s *ServiceVehicleDefault) AddVehicle(vehicle *domain.Vehicle) (v *domain.V
 	return
 }
 
-func (s *ServiceVehicleDefault) GetByColorAndYear(color string, year int) (v []*domain.Vehicle, err error) {
-	v, err = s.rp.GetByColorAndYear(color, year)
+func (s *ServiceVehicleDefault) GetByColorAndYear(ctx context.Context, color string, year int) (v []*domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "ServiceVehicleDefault.GetByColorAndYear")
+	defer func() { tracing.End(span, err) }()
+
+	v, err = s.rp.GetByColorAndYear(ctx, color, year)
 	if err != nil {
+		s.logError(ctx, "GetByColorAndYear", err)
 		err = validateErrors(err)
 		return
 	}
@@ -63,18 +109,26 @@ func (s *ServiceVehicleDefault) GetByColorAndYear(color string, year int) (v []*
 	return
 }
 
-func (s *ServiceVehicleDefault) GetByBrandAndPeriod(brand string, start int, end int) (v []*domain.Vehicle, err error) {
-	v, err = s.rp.GetByBrandAndPeriod(brand, start, end)
+func (s *ServiceVehicleDefault) GetByBrandAndPeriod(ctx context.Context, brand string, start int, end int) (v []*domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "ServiceVehicleDefault.GetByBrandAndPeriod")
+	defer func() { tracing.End(span, err) }()
+
+	v, err = s.rp.GetByBrandAndPeriod(ctx, brand, start, end)
 	if err != nil {
+		s.logError(ctx, "GetByBrandAndPeriod", err)
 		err = validateErrors(err)
 		return
 	}
 	return
 }
 
-func (s *ServiceVehicleDefault) UpdateSpeed(vehicle *domain.Vehicle) (v *domain.Vehicle, err error) {
-	v, err = s.rp.UpdateSpeed(vehicle)
+func (s *ServiceVehicleDefault) UpdateSpeed(ctx context.Context, vehicle *domain.Vehicle) (v *domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "ServiceVehicleDefault.UpdateSpeed")
+	defer func() { tracing.End(span, err) }()
+
+	v, err = s.rp.UpdateSpeed(ctx, vehicle)
 	if err != nil {
+		s.logError(ctx, "UpdateSpeed", err)
 		err = validateErrors(err)
 		return
 	}
@@ -82,45 +136,65 @@ func (s *ServiceVehicleDefault) UpdateSpeed(vehicle *domain.Vehicle) (v *domain.
 	return
 }
 
-func (s *ServiceVehicleDefault) GetSpeedAverageByBrand(brand string) (average float64, err error) {
-	average, err = s.rp.GetSpeedAverageByBrand(brand)
+func (s *ServiceVehicleDefault) GetSpeedAverageByBrand(ctx context.Context, brand string) (average float64, err error) {
+	ctx, span := tracer.Start(ctx, "ServiceVehicleDefault.GetSpeedAverageByBrand")
+	defer func() { tracing.End(span, err) }()
+
+	average, err = s.rp.GetSpeedAverageByBrand(ctx, brand)
 	if err != nil {
+		s.logError(ctx, "GetSpeedAverageByBrand", err)
 		err = validateErrors(err)
 		return
 	}
 	return
 }
 
-func (s *ServiceVehicleDefault) GetByFuelType(fuel string) (v []*domain.Vehicle, err error) {
-	v, err = s.rp.GetByFuelType(fuel)
+func (s *ServiceVehicleDefault) GetByFuelType(ctx context.Context, fuel string) (v []*domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "ServiceVehicleDefault.GetByFuelType")
+	defer func() { tracing.End(span, err) }()
+
+	v, err = s.rp.GetByFuelType(ctx, fuel)
 	if err != nil {
+		s.logError(ctx, "GetByFuelType", err)
 		err = validateErrors(err)
 		return
 	}
 	return
 }
 
-func (s *ServiceVehicleDefault) GetByWeight(min float64, max float64) (v []*domain.Vehicle, err error) {
-	v, err = s.rp.GetByWeight(min, max)
+func (s *ServiceVehicleDefault) GetByWeight(ctx context.Context, min float64, max float64) (v []*domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "ServiceVehicleDefault.GetByWeight")
+	defer func() { tracing.End(span, err) }()
+
+	v, err = s.rp.GetByWeight(ctx, min, max)
 	if err != nil {
+		s.logError(ctx, "GetByWeight", err)
 		err = validateErrors(err)
 		return
 	}
 	return
 }
 
-func (s *ServiceVehicleDefault) DeleteVehicle(id int) (v *domain.Vehicle, err error) {
-	v, err = s.rp.DeleteVehicle(id)
+func (s *ServiceVehicleDefault) DeleteVehicle(ctx context.Context, id int) (v *domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "ServiceVehicleDefault.DeleteVehicle")
+	defer func() { tracing.End(span, err) }()
+
+	v, err = s.rp.DeleteVehicle(ctx, id)
 	if err != nil {
+		s.logError(ctx, "DeleteVehicle", err)
 		err = validateErrors(err)
 		return
 	}
 	return
 }
 
-func (s *ServiceVehicleDefault) AddVehicles(vehicles []*domain.Vehicle) (v []*domain.Vehicle, err error) {
-	v, err = s.rp.AddVehicles(vehicles)
+func (s *ServiceVehicleDefault) AddVehicles(ctx context.Context, vehicles []*domain.Vehicle) (v []*domain.Vehicle, err error) {
+	ctx, span := tracer.Start(ctx, "ServiceVehicleDefault.AddVehicles")
+	defer func() { tracing.End(span, err) }()
+
+	v, err = s.rp.AddVehicles(ctx, vehicles)
 	if err != nil {
+		s.logError(ctx, "AddVehicles", err)
 		err = validateErrors(err)
 		return
 	}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"app/internal/domain"
+	"app/internal/vehicle/events"
+	"context"
+)
+
+// NewServiceVehicleTenantsEvents returns a new instance of a service that publishes the changes made through
+// the services of every tenant to the bus.
+func NewServiceVehicleTenantsEvents(st ServiceVehicleTenants, bus *events.Bus) *ServiceVehicleTenantsEvents {
+	return &ServiceVehicleTenantsEvents{st: st, bus: bus}
+}
+
+// ServiceVehicleTenantsEvents is an struct that implements the ServiceVehicleTenants interface.
+type ServiceVehicleTenantsEvents struct {
+	// st resolves the services whose changes are published.
+	st ServiceVehicleTenants
+	// bus delivers the changes to their subscribers.
+	bus *events.Bus
+}
+
+// Tenant returns the service of the tenant, publishing its changes.
+func (s *ServiceVehicleTenantsEvents) Tenant(id string) (sv ServiceVehicle, err error) {
+	sv, err = s.st.Tenant(id)
+	if err != nil {
+		return
+	}
+	sv = &ServiceVehicleEvents{ServiceVehicle: sv, tenant: id, bus: s.bus}
+	return
+}
+
+// ServiceVehicleEvents is an struct that implements the ServiceVehicle interface.
+// The queries are served by the embedded service, and its successful changes are published.
+type ServiceVehicleEvents struct {
+	ServiceVehicle
+	// tenant is the tenant of the service.
+	tenant string
+	// bus delivers the changes to their subscribers.
+	bus *events.Bus
+}
+
+// publish publishes a change of a vehicle.
+func (s *ServiceVehicleEvents) publish(kind events.Kind, v *domain.Vehicle) {
+	s.bus.Publish(events.Event{Kind: kind, Tenant: s.tenant, Vehicle: v})
+}
+
+// AddVehicle adds the vehicle and publishes its creation.
+func (s *ServiceVehicleEvents) AddVehicle(ctx context.Context, vehicle *domain.Vehicle) (v *domain.Vehicle, err error) {
+	v, err = s.ServiceVehicle.AddVehicle(ctx, vehicle)
+	if err != nil {
+		return
+	}
+	s.publish(events.KindCreated, v)
+	return
+}
+
+// AddVehicles adds the vehicles and publishes the creation of each of them.
+func (s *ServiceVehicleEvents) AddVehicles(ctx context.Context, vehicles []*domain.Vehicle) (v []*domain.Vehicle, err error) {
+	v, err = s.ServiceVehicle.AddVehicles(ctx, vehicles)
+	if err != nil {
+		return
+	}
+	for _, vehicle := range v {
+		s.publish(events.KindCreated, vehicle)
+	}
+	return
+}
+
+// UpdateSpeed updates the max speed of the vehicle and publishes the update.
+func (s *ServiceVehicleEvents) UpdateSpeed(ctx context.Context, vehicle *domain.Vehicle) (v *domain.Vehicle, err error) {
+	v, err = s.ServiceVehicle.UpdateSpeed(ctx, vehicle)
+	if err != nil {
+		return
+	}
+	s.publish(events.KindUpdated, v)
+	return
+}
+
+// DeleteVehicle deletes the vehicle and publishes its deletion.
+func (s *ServiceVehicleEvents) DeleteVehicle(ctx context.Context, id int) (v *domain.Vehicle, err error) {
+	v, err = s.ServiceVehicle.DeleteVehicle(ctx, id)
+	if err != nil {
+		return
+	}
+	s.publish(events.KindDeleted, v)
+	return
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"app/internal/domain"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// NewServiceVehicleHTTP returns a new instance of a vehicle service backed by a running vehicle api.
+// The header is sent with every request, e.g. the authorization and the tenant.
+func NewServiceVehicleHTTP(baseURL string, cl *http.Client, header http.Header) *ServiceVehicleHTTP {
+	return &ServiceVehicleHTTP{url: strings.TrimSuffix(baseURL, "/") + "/api/v1/vehicles", cl: cl, header: header}
+}
+
+// ServiceVehicleHTTP is an struct that implements the ServiceVehicle interface over the http api.
+type ServiceVehicleHTTP struct {
+	// url is the base url of the vehicle routes.
+	url string
+	// cl is the client of the requests.
+	cl *http.Client
+	// header is sent with every request.
+	header http.Header
+}
+
+// vehicleHTTP is an struct that represents a vehicle in the requests and responses of the api.
+type vehicleHTTP struct {
+	Id           int     `json:"id"`
+	Brand        string  `json:"brand"`
+	Model        string  `json:"model"`
+	Registration string  `json:"registration"`
+	Year         int     `json:"year"`
+	Color        string  `json:"color"`
+	MaxSpeed     int     `json:"max_speed"`
+	FuelType     string  `json:"fuel_type"`
+	Transmission string  `json:"transmission"`
+	Passengers   int     `json:"passengers"`
+	Height       float64 `json:"height"`
+	Width        float64 `json:"width"`
+	Weight       float64 `json:"weight"`
+}
+
+// responseHTTP is an struct that represents the body of the responses of the api.
+type responseHTTP struct {
+	Message  string          `json:"message"`
+	Data     json.RawMessage `json:"data"`
+	Vehicles []*vehicleHTTP  `json:"vehicles"`
+	Error    bool            `json:"error"`
+}
+
+func toVehicleHTTP(v *domain.Vehicle) *vehicleHTTP {
+	return &vehicleHTTP{
+		Id:           v.Id,
+		Brand:        v.Attributes.Brand,
+		Model:        v.Attributes.Model,
+		Registration: v.Attributes.Registration,
+		Year:         v.Attributes.Year,
+		Color:        v.Attributes.Color,
+		MaxSpeed:     v.Attributes.MaxSpeed,
+		FuelType:     v.Attributes.FuelType,
+		Transmission: v.Attributes.Transmission,
+		Passengers:   v.Attributes.Passengers,
+		Height:       v.Attributes.Height,
+		Width:        v.Attributes.Width,
+		Weight:       v.Attributes.Weight,
+	}
+}
+
+func (v *vehicleHTTP) vehicle() *domain.Vehicle {
+	return &domain.Vehicle{
+		Id: v.Id,
+		Attributes: domain.VehicleAttributes{
+			Brand:        v.Brand,
+			Model:        v.Model,
+			Registration: v.Registration,
+			Year:         v.Year,
+			Color:        v.Color,
+			MaxSpeed:     v.MaxSpeed,
+			FuelType:     v.FuelType,
+			Transmission: v.Transmission,
+			Passengers:   v.Passengers,
+			Height:       v.Height,
+			Width:        v.Width,
+			Weight:       v.Weight,
+		},
+	}
+}
+
+// do sends a request to the api and decodes its response body.
+// A 404 is returned as the notFound error, which tells apart the routes of a vehicle from the ones of a search.
+func (s *ServiceVehicleHTTP) do(ctx context.Context, method, path string, query url.Values, in any, notFound error) (res *responseHTTP, err error) {
+	// request
+	var body bytes.Buffer
+	if in != nil {
+		if err = json.NewEncoder(&body).Encode(in); err != nil {
+			err = fmt.Errorf("%w. %v", ErrServiceVehicleInternal, err)
+			return
+		}
+	}
+	u := s.url + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, &body)
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrServiceVehicleInternal, err)
+		return
+	}
+	for key, values := range s.header {
+		req.Header[key] = values
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// process
+	r, err := s.cl.Do(req)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			err = fmt.Errorf("%w. %v", ErrServiceVehicleTimeout, err)
+		case errors.Is(err, context.Canceled):
+			err = fmt.Errorf("%w. %v", ErrServiceVehicleCanceled, err)
+		default:
+			err = fmt.Errorf("%w. %v", ErrServiceVehicleInternal, err)
+		}
+		return
+	}
+	defer r.Body.Close()
+
+	// response
+	res = &responseHTTP{}
+	if r.StatusCode != http.StatusNoContent {
+		if e := json.NewDecoder(r.Body).Decode(res); e != nil && r.StatusCode < 300 {
+			err = fmt.Errorf("%w. %s: %v", ErrServiceVehicleInternal, r.Status, e)
+			return
+		}
+	}
+	if r.StatusCode >= 300 {
+		err = statusErrors(r.StatusCode, res.Message, notFound)
+	}
+	return
+}
+
+// statusErrors returns the service error of a status of the api, by its code only, with the message of the response.
+func statusErrors(code int, message string, notFound error) (err error) {
+	switch code {
+	case http.StatusBadRequest:
+		err = ErrServiceInvalidParam
+	case http.StatusUnauthorized:
+		err = ErrServiceUnauthorized
+	case http.StatusForbidden:
+		err = ErrServiceForbidden
+	case http.StatusNotFound:
+		err = notFound
+	case http.StatusConflict:
+		err = ErrServiceVehicleExist
+	case http.StatusTooManyRequests:
+		err = ErrServiceRateLimited
+	case http.StatusServiceUnavailable:
+		err = ErrServiceUnavailable
+	case http.StatusGatewayTimeout:
+		err = ErrServiceVehicleTimeout
+	default:
+		err = fmt.Errorf("%w. %d %s", ErrServiceVehicleInternal, code, message)
+		return
+	}
+	err = fmt.Errorf("%w. %s", err, message)
+	return
+}
+
+// list returns the vehicles of a list response.
+func list(res *responseHTTP) (v []*domain.Vehicle) {
+	v = make([]*domain.Vehicle, 0, len(res.Vehicles))
+	for _, vehicle := range res.Vehicles {
+		v = append(v, vehicle.vehicle())
+	}
+	return
+}
+
+// one returns the vehicle of a single vehicle response.
+func one(res *responseHTTP) (v *domain.Vehicle, err error) {
+	var vehicle vehicleHTTP
+	if err = json.Unmarshal(res.Data, &vehicle); err != nil {
+		err = fmt.Errorf("%w. %v", ErrServiceVehicleInternal, err)
+		return
+	}
+	v = vehicle.vehicle()
+	return
+}
+
+// GetAll returns all vehicles.
+func (s *ServiceVehicleHTTP) GetAll(ctx context.Context) (v []*domain.Vehicle, err error) {
+	res, err := s.do(ctx, http.MethodGet, "", nil, nil, ErrServiceVehicleNotFound)
+	if err != nil {
+		return
+	}
+	v = list(res)
+	return
+}
+
+// GetById returns the vehicle with the given id. The api has no route for it, so it is searched among all vehicles.
+func (s *ServiceVehicleHTTP) GetById(ctx context.Context, id int) (v *domain.Vehicle, err error) {
+	vehicles, err := s.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	for _, vehicle := range vehicles {
+		if vehicle.Id == id {
+			v = vehicle
+			return
+		}
+	}
+	err = fmt.Errorf("%w. %d", ErrServiceVehicleNotFound, id)
+	return
+}
+
+// GetByColorAndYear returns the vehicles of the color made in the year.
+func (s *ServiceVehicleHTTP) GetByColorAndYear(ctx context.Context, color string, year int) (v []*domain.Vehicle, err error) {
+	res, err := s.do(ctx, http.MethodGet, "/color/"+url.PathEscape(color)+"/year/"+strconv.Itoa(year), nil, nil, ErrServiceVehicleNotFoundWithValue)
+	if err != nil {
+		return
+	}
+	v = list(res)
+	return
+}
+
+// GetByBrandAndPeriod returns the vehicles of the brand made from the start year up to, but not including, the end year.
+func (s *ServiceVehicleHTTP) GetByBrandAndPeriod(ctx context.Context, brand string, start int, end int) (v []*domain.Vehicle, err error) {
+	res, err := s.do(ctx, http.MethodGet, "/brand/"+url.PathEscape(brand)+"/between/"+strconv.Itoa(start)+"/"+strconv.Itoa(end), nil, nil, ErrServiceVehicleNotFoundWithValue)
+	if err != nil {
+		return
+	}
+	v = list(res)
+	return
+}
+
+// GetSpeedAverageByBrand returns the average max speed of the brand.
+func (s *ServiceVehicleHTTP) GetSpeedAverageByBrand(ctx context.Context, brand string) (v float64, err error) {
+	res, err := s.do(ctx, http.MethodGet, "/average_speed/brand/"+url.PathEscape(brand), nil, nil, ErrServiceVehicleNotFoundWithValue)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(res.Data, &v); err != nil {
+		err = fmt.Errorf("%w. %v", ErrServiceVehicleInternal, err)
+	}
+	return
+}
+
+// GetByFuelType returns the vehicles of the fuel type.
+func (s *ServiceVehicleHTTP) GetByFuelType(ctx context.Context, fuel string) (v []*domain.Vehicle, err error) {
+	res, err := s.do(ctx, http.MethodGet, "/fuel_type/"+url.PathEscape(fuel), nil, nil, ErrServiceVehicleNotFoundWithValue)
+	if err != nil {
+		return
+	}
+	v = list(res)
+	return
+}
+
+// GetByWeight returns the vehicles whose weight is between min and max, both included.
+// An infinite bound is left out of the query, so the range is open on its side.
+func (s *ServiceVehicleHTTP) GetByWeight(ctx context.Context, min float64, max float64) (v []*domain.Vehicle, err error) {
+	query := url.Values{}
+	if !math.IsInf(min, 0) {
+		query.Set("weight_min", strconv.FormatFloat(min, 'f', -1, 64))
+	}
+	if !math.IsInf(max, 0) {
+		query.Set("weight_max", strconv.FormatFloat(max, 'f', -1, 64))
+	}
+	res, err := s.do(ctx, http.MethodGet, "/weight", query, nil, ErrServiceVehicleNotFoundWithValue)
+	if err != nil {
+		return
+	}
+	v = list(res)
+	return
+}
+
+// AddVehicle adds a vehicle.
+func (s *ServiceVehicleHTTP) AddVehicle(ctx context.Context, vehicle *domain.Vehicle) (v *domain.Vehicle, err error) {
+	res, err := s.do(ctx, http.MethodPost, "", nil, toVehicleHTTP(vehicle), ErrServiceVehicleNotFound)
+	if err != nil {
+		return
+	}
+	return one(res)
+}
+
+// AddVehicles adds all the vehicles, or none of them.
+func (s *ServiceVehicleHTTP) AddVehicles(ctx context.Context, vehicles []*domain.Vehicle) (v []*domain.Vehicle, err error) {
+	in := make([]*vehicleHTTP, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		in = append(in, toVehicleHTTP(vehicle))
+	}
+	res, err := s.do(ctx, http.MethodPost, "/batch", nil, in, ErrServiceVehicleNotFound)
+	if err != nil {
+		return
+	}
+	v = list(res)
+	return
+}
+
+// UpdateSpeed updates the max speed of a vehicle.
+func (s *ServiceVehicleHTTP) UpdateSpeed(ctx context.Context, vehicle *domain.Vehicle) (v *domain.Vehicle, err error) {
+	res, err := s.do(ctx, http.MethodPut, "/"+strconv.Itoa(vehicle.Id)+"/update_speed", nil, map[string]int{"max_speed": vehicle.Attributes.MaxSpeed}, ErrServiceVehicleNotFound)
+	if err != nil {
+		return
+	}
+	return one(res)
+}
+
+// DeleteVehicle deletes the vehicle with the given id. The api does not return the deleted vehicle, so it is read first.
+func (s *ServiceVehicleHTTP) DeleteVehicle(ctx context.Context, id int) (v *domain.Vehicle, err error) {
+	v, err = s.GetById(ctx, id)
+	if err != nil {
+		return
+	}
+	if _, err = s.do(ctx, http.MethodDelete, "/"+strconv.Itoa(id), nil, nil, ErrServiceVehicleNotFound); err != nil {
+		v = nil
+	}
+	return
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"app/internal/vehicle/repository"
+	"log/slog"
+)
+
+// NewServiceVehicleTenantsDefault returns a new instance of a vehicle service for every tenant.
+func NewServiceVehicleTenantsDefault(rp repository.RepositoryVehicleTenants, lg *slog.Logger) *ServiceVehicleTenantsDefault {
+	return &ServiceVehicleTenantsDefault{rp: rp, lg: lg}
+}
+
+// ServiceVehicleTenantsDefault is an struct that implements the ServiceVehicleTenants interface.
+type ServiceVehicleTenantsDefault struct {
+	rp repository.RepositoryVehicleTenants
+	// lg is the logger of the services.
+	lg *slog.Logger
+}
+
+// Tenant returns the service of the tenant.
+func (s *ServiceVehicleTenantsDefault) Tenant(id string) (sv ServiceVehicle, err error) {
+	rp, err := s.rp.Tenant(id)
+	if err != nil {
+		err = validateErrors(err)
+		return
+	}
+	sv = NewServiceVehicleDefault(rp, s.lg.With("tenant", id))
+	return
+}
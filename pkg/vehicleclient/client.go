@@ -0,0 +1,237 @@
+// Package vehicleclient is the Go client of the gRPC api of the vehicle catalog, defined in proto/vehicle/v1.
+// It sends the api key and the tenant of the client with every call, collects the streamed lists,
+// and returns the errors of the api as the errors of this package:
+//
+//	c, err := vehicleclient.Dial("localhost:9090", vehicleclient.Options{APIKey: key, Tenant: "acme"})
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//	vehicles, err := c.ListVehiclesByFuelType(ctx, "diesel")
+//	if errors.Is(err, vehicleclient.ErrNotFound) {
+//		// no diesel vehicles
+//	}
+package vehicleclient
+
+import (
+	vehiclev1 "app/proto/vehicle/v1"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrNotFound is returned when the vehicle, the vehicles matching the criteria or the tenant do not exist.
+	ErrNotFound = errors.New("vehicleclient: not found")
+	// ErrAlreadyExists is returned when a vehicle with the same id already exists.
+	ErrAlreadyExists = errors.New("vehicleclient: already exists")
+	// ErrInvalidArgument is returned when the arguments of a call are invalid.
+	ErrInvalidArgument = errors.New("vehicleclient: invalid argument")
+	// ErrUnauthenticated is returned when the api key is missing or invalid.
+	ErrUnauthenticated = errors.New("vehicleclient: unauthenticated")
+	// ErrPermissionDenied is returned when the api key is not allowed to make the call.
+	ErrPermissionDenied = errors.New("vehicleclient: permission denied")
+	// ErrRateLimited is returned when the api key exceeds its rate limit or its daily quota.
+	ErrRateLimited = errors.New("vehicleclient: rate limited")
+	// ErrUnavailable is returned when the api is not ready or can not be reached.
+	ErrUnavailable = errors.New("vehicleclient: unavailable")
+	// ErrTimeout is returned when the deadline of the call is exceeded.
+	ErrTimeout = errors.New("vehicleclient: timeout")
+	// ErrInternal is returned for any other error.
+	ErrInternal = errors.New("vehicleclient: internal error")
+)
+
+// Options is an struct that represents the options of a client.
+type Options struct {
+	// APIKey is sent as the bearer of the authorization metadata. Empty sends none.
+	APIKey string
+	// Tenant is the tenant of the calls. Empty leaves it to the api: the tenant of the api key, or the default one.
+	Tenant string
+	// TenantHeader is the metadata key of the tenant, x-tenant-id if empty.
+	TenantHeader string
+}
+
+// New returns a new instance of a client over the connection.
+func New(conn grpc.ClientConnInterface, opts Options) *Client {
+	if opts.TenantHeader == "" {
+		opts.TenantHeader = "x-tenant-id"
+	}
+	return &Client{rpc: vehiclev1.NewVehicleServiceClient(conn), opts: opts}
+}
+
+// Dial returns a new instance of a client connected to the target, closed with Close.
+// Without dial options the connection is not encrypted, as between internal services.
+func Dial(target string, opts Options, dialOpts ...grpc.DialOption) (c *Client, err error) {
+	if len(dialOpts) == 0 {
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	conn, err := grpc.NewClient(target, dialOpts...)
+	if err != nil {
+		return
+	}
+	c = New(conn, opts)
+	c.conn = conn
+	return
+}
+
+// Client is an struct that represents a client of the gRPC api of the vehicle catalog.
+type Client struct {
+	rpc  vehiclev1.VehicleServiceClient
+	opts Options
+	// conn is the connection opened by Dial, nil if the client was given one.
+	conn *grpc.ClientConn
+}
+
+// Close closes the connection opened by Dial.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// Context returns a copy of ctx that carries the api key and the tenant of the client,
+// to make calls with the generated client of the service.
+func (c *Client) Context(ctx context.Context) context.Context {
+	var kv []string
+	if c.opts.APIKey != "" {
+		kv = append(kv, "authorization", "Bearer "+c.opts.APIKey)
+	}
+	if c.opts.Tenant != "" {
+		kv = append(kv, c.opts.TenantHeader, c.opts.Tenant)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// GetVehicle returns the vehicle with the id.
+func (c *Client) GetVehicle(ctx context.Context, id int64) (v *vehiclev1.Vehicle, err error) {
+	v, err = c.rpc.GetVehicle(c.Context(ctx), &vehiclev1.GetVehicleRequest{Id: id})
+	err = statusError(err)
+	return
+}
+
+// ListVehicles returns every vehicle.
+func (c *Client) ListVehicles(ctx context.Context) ([]*vehiclev1.Vehicle, error) {
+	return collect(c.rpc.ListVehicles(c.Context(ctx), &vehiclev1.ListVehiclesRequest{}))
+}
+
+// ListVehiclesByColorAndYear returns the vehicles of a color made in a year.
+func (c *Client) ListVehiclesByColorAndYear(ctx context.Context, color string, year int32) ([]*vehiclev1.Vehicle, error) {
+	return collect(c.rpc.ListVehiclesByColorAndYear(c.Context(ctx), &vehiclev1.ListVehiclesByColorAndYearRequest{Color: color, Year: year}))
+}
+
+// ListVehiclesByBrandAndPeriod returns the vehicles of a brand made between two years, both included.
+func (c *Client) ListVehiclesByBrandAndPeriod(ctx context.Context, brand string, startYear, endYear int32) ([]*vehiclev1.Vehicle, error) {
+	return collect(c.rpc.ListVehiclesByBrandAndPeriod(c.Context(ctx), &vehiclev1.ListVehiclesByBrandAndPeriodRequest{
+		Brand: brand, StartYear: startYear, EndYear: endYear,
+	}))
+}
+
+// ListVehiclesByFuelType returns the vehicles of a fuel type.
+func (c *Client) ListVehiclesByFuelType(ctx context.Context, fuelType string) ([]*vehiclev1.Vehicle, error) {
+	return collect(c.rpc.ListVehiclesByFuelType(c.Context(ctx), &vehiclev1.ListVehiclesByFuelTypeRequest{FuelType: fuelType}))
+}
+
+// ListVehiclesByWeight returns the vehicles whose weight is in a range, open on the sides whose bound is nil.
+func (c *Client) ListVehiclesByWeight(ctx context.Context, min, max *float64) ([]*vehiclev1.Vehicle, error) {
+	return collect(c.rpc.ListVehiclesByWeight(c.Context(ctx), &vehiclev1.ListVehiclesByWeightRequest{Min: min, Max: max}))
+}
+
+// GetSpeedAverageByBrand returns the average max speed of the vehicles of a brand.
+func (c *Client) GetSpeedAverageByBrand(ctx context.Context, brand string) (average float64, err error) {
+	res, err := c.rpc.GetSpeedAverageByBrand(c.Context(ctx), &vehiclev1.GetSpeedAverageByBrandRequest{Brand: brand})
+	if err != nil {
+		err = statusError(err)
+		return
+	}
+	average = res.GetAverage()
+	return
+}
+
+// AddVehicle adds a vehicle.
+func (c *Client) AddVehicle(ctx context.Context, vehicle *vehiclev1.Vehicle) (v *vehiclev1.Vehicle, err error) {
+	v, err = c.rpc.AddVehicle(c.Context(ctx), &vehiclev1.AddVehicleRequest{Vehicle: vehicle})
+	err = statusError(err)
+	return
+}
+
+// AddVehicles adds several vehicles, all or none.
+func (c *Client) AddVehicles(ctx context.Context, vehicles []*vehiclev1.Vehicle) (v []*vehiclev1.Vehicle, err error) {
+	res, err := c.rpc.AddVehicles(c.Context(ctx), &vehiclev1.AddVehiclesRequest{Vehicles: vehicles})
+	if err != nil {
+		err = statusError(err)
+		return
+	}
+	v = res.GetVehicles()
+	return
+}
+
+// UpdateSpeed updates the max speed of a vehicle.
+func (c *Client) UpdateSpeed(ctx context.Context, id int64, maxSpeed int32) (v *vehiclev1.Vehicle, err error) {
+	v, err = c.rpc.UpdateSpeed(c.Context(ctx), &vehiclev1.UpdateSpeedRequest{Id: id, MaxSpeed: maxSpeed})
+	err = statusError(err)
+	return
+}
+
+// DeleteVehicle deletes a vehicle and returns it as it was.
+func (c *Client) DeleteVehicle(ctx context.Context, id int64) (v *vehiclev1.Vehicle, err error) {
+	v, err = c.rpc.DeleteVehicle(c.Context(ctx), &vehiclev1.DeleteVehicleRequest{Id: id})
+	err = statusError(err)
+	return
+}
+
+// collect returns the vehicles of a stream once it ends.
+func collect(stream grpc.ServerStreamingClient[vehiclev1.Vehicle], err error) (v []*vehiclev1.Vehicle, _ error) {
+	if err != nil {
+		return nil, statusError(err)
+	}
+	for {
+		vehicle, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return v, nil
+		}
+		if err != nil {
+			return nil, statusError(err)
+		}
+		v = append(v, vehicle)
+	}
+}
+
+// statusError returns the error of this package for the status of a call, nil if it succeeded.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	st := status.Convert(err)
+	var e error
+	switch st.Code() {
+	case codes.NotFound:
+		e = ErrNotFound
+	case codes.AlreadyExists:
+		e = ErrAlreadyExists
+	case codes.InvalidArgument:
+		e = ErrInvalidArgument
+	case codes.Unauthenticated:
+		e = ErrUnauthenticated
+	case codes.PermissionDenied:
+		e = ErrPermissionDenied
+	case codes.ResourceExhausted:
+		e = ErrRateLimited
+	case codes.Unavailable:
+		e = ErrUnavailable
+	case codes.DeadlineExceeded:
+		e = ErrTimeout
+	case codes.Canceled:
+		e = context.Canceled
+	default:
+		e = ErrInternal
+	}
+	return fmt.Errorf("%w. %v", e, st.Message())
+}
@@ -0,0 +1,140 @@
+package vehicleclient_test
+
+import (
+	"app/pkg/vehicleclient"
+	vehiclev1 "app/proto/vehicle/v1"
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// server is an struct that implements the calls of the vehicle service the tests make, over a fixed fleet.
+// It only answers the clients of the api key, and serves the fleet of the tenant of the call.
+type server struct {
+	vehiclev1.UnimplementedVehicleServiceServer
+	fleets map[string][]*vehiclev1.Vehicle
+}
+
+// fleet returns the fleet of the tenant of the call, checking its api key.
+func (s *server) fleet(ctx context.Context) (v []*vehiclev1.Vehicle, err error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if auth := md.Get("authorization"); len(auth) != 1 || auth[0] != "Bearer key" {
+		err = status.Error(codes.Unauthenticated, "api key missing or invalid")
+		return
+	}
+	tenant := "default"
+	if t := md.Get("x-tenant"); len(t) == 1 {
+		tenant = t[0]
+	}
+	v, ok := s.fleets[tenant]
+	if !ok {
+		err = status.Errorf(codes.NotFound, "tenant %s not found", tenant)
+	}
+	return
+}
+
+func (s *server) GetVehicle(ctx context.Context, req *vehiclev1.GetVehicleRequest) (*vehiclev1.Vehicle, error) {
+	fleet, err := s.fleet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range fleet {
+		if v.GetId() == req.GetId() {
+			return v, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "vehicle %d not found", req.GetId())
+}
+
+func (s *server) ListVehicles(req *vehiclev1.ListVehiclesRequest, stream grpc.ServerStreamingServer[vehiclev1.Vehicle]) error {
+	fleet, err := s.fleet(stream.Context())
+	if err != nil {
+		return err
+	}
+	for _, v := range fleet {
+		if err = stream.Send(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dial serves the server over an in-memory connection and returns a client of it.
+func dial(t *testing.T, opts vehicleclient.Options) *vehicleclient.Client {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	vehiclev1.RegisterVehicleServiceServer(srv, &server{fleets: map[string][]*vehiclev1.Vehicle{
+		"default": {{Id: 1, Brand: "Ford"}, {Id: 2, Brand: "Toyota"}},
+		"acme":    {{Id: 3, Brand: "BMW"}},
+	}})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	c, err := vehicleclient.Dial("passthrough:///bufconn", opts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestClient_GetVehicle(t *testing.T) {
+	cases := []struct {
+		name  string
+		opts  vehicleclient.Options
+		id    int64
+		brand string
+		err   error
+	}{
+		{"found", vehicleclient.Options{APIKey: "key"}, 2, "Toyota", nil},
+		{"tenant", vehicleclient.Options{APIKey: "key", Tenant: "acme", TenantHeader: "x-tenant"}, 3, "BMW", nil},
+		{"not found", vehicleclient.Options{APIKey: "key"}, 3, "", vehicleclient.ErrNotFound},
+		{"unknown tenant", vehicleclient.Options{APIKey: "key", Tenant: "globex", TenantHeader: "x-tenant"}, 1, "", vehicleclient.ErrNotFound},
+		{"unauthenticated", vehicleclient.Options{}, 1, "", vehicleclient.ErrUnauthenticated},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := dial(t, c.opts).GetVehicle(context.Background(), c.id)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("err = %v, want %v", err, c.err)
+			}
+			if got := v.GetBrand(); got != c.brand {
+				t.Errorf("brand = %q, want %q", got, c.brand)
+			}
+		})
+	}
+}
+
+func TestClient_ListVehicles(t *testing.T) {
+	v, err := dial(t, vehicleclient.Options{APIKey: "key"}).ListVehicles(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v) != 2 || v[0].GetId() != 1 || v[1].GetId() != 2 {
+		t.Errorf("vehicles = %v, want the vehicles 1 and 2", v)
+	}
+
+	_, err = dial(t, vehicleclient.Options{}).ListVehicles(context.Background())
+	if !errors.Is(err, vehicleclient.ErrUnauthenticated) {
+		t.Errorf("err = %v, want %v", err, vehicleclient.ErrUnauthenticated)
+	}
+}
+
+func TestClient_Unimplemented(t *testing.T) {
+	_, err := dial(t, vehicleclient.Options{APIKey: "key"}).GetSpeedAverageByBrand(context.Background(), "Ford")
+	if !errors.Is(err, vehicleclient.ErrInternal) {
+		t.Errorf("err = %v, want %v", err, vehicleclient.ErrInternal)
+	}
+}
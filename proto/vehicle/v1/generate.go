@@ -0,0 +1,8 @@
+// Package vehiclev1 is the protobuf messages and the gRPC service of the vehicle catalog, generated from vehicle.proto.
+// The generated code is committed, so building the module does not need protoc; regenerate it after changing the
+// definition with protoc, protoc-gen-go and protoc-gen-go-grpc installed:
+//
+//	go generate ./proto/...
+package vehiclev1
+
+//go:generate protoc -I ../../.. --go_out=../../.. --go_opt=paths=source_relative --go-grpc_out=../../.. --go-grpc_opt=paths=source_relative proto/vehicle/v1/vehicle.proto
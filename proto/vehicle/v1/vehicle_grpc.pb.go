@@ -0,0 +1,544 @@
+// The vehicle catalog of every tenant, mirroring the operations of the vehicle service.
+//
+// The api key goes in the authorization metadata as "Bearer <key>", or in x-api-key,
+// and the tenant in the x-tenant-id metadata, as on the REST api.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/vehicle/v1/vehicle.proto
+
+package vehiclev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	VehicleService_GetVehicle_FullMethodName                   = "/vehicle.v1.VehicleService/GetVehicle"
+	VehicleService_ListVehicles_FullMethodName                 = "/vehicle.v1.VehicleService/ListVehicles"
+	VehicleService_ListVehiclesByColorAndYear_FullMethodName   = "/vehicle.v1.VehicleService/ListVehiclesByColorAndYear"
+	VehicleService_ListVehiclesByBrandAndPeriod_FullMethodName = "/vehicle.v1.VehicleService/ListVehiclesByBrandAndPeriod"
+	VehicleService_ListVehiclesByFuelType_FullMethodName       = "/vehicle.v1.VehicleService/ListVehiclesByFuelType"
+	VehicleService_ListVehiclesByWeight_FullMethodName         = "/vehicle.v1.VehicleService/ListVehiclesByWeight"
+	VehicleService_GetSpeedAverageByBrand_FullMethodName       = "/vehicle.v1.VehicleService/GetSpeedAverageByBrand"
+	VehicleService_AddVehicle_FullMethodName                   = "/vehicle.v1.VehicleService/AddVehicle"
+	VehicleService_AddVehicles_FullMethodName                  = "/vehicle.v1.VehicleService/AddVehicles"
+	VehicleService_UpdateSpeed_FullMethodName                  = "/vehicle.v1.VehicleService/UpdateSpeed"
+	VehicleService_DeleteVehicle_FullMethodName                = "/vehicle.v1.VehicleService/DeleteVehicle"
+)
+
+// VehicleServiceClient is the client API for VehicleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VehicleServiceClient interface {
+	// GetVehicle returns the vehicle with the id.
+	GetVehicle(ctx context.Context, in *GetVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error)
+	// ListVehicles streams every vehicle.
+	ListVehicles(ctx context.Context, in *ListVehiclesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// ListVehiclesByColorAndYear streams the vehicles of a color made in a year.
+	ListVehiclesByColorAndYear(ctx context.Context, in *ListVehiclesByColorAndYearRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// ListVehiclesByBrandAndPeriod streams the vehicles of a brand made between two years, both included.
+	ListVehiclesByBrandAndPeriod(ctx context.Context, in *ListVehiclesByBrandAndPeriodRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// ListVehiclesByFuelType streams the vehicles of a fuel type.
+	ListVehiclesByFuelType(ctx context.Context, in *ListVehiclesByFuelTypeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// ListVehiclesByWeight streams the vehicles whose weight is in a range, open on the sides without a bound.
+	ListVehiclesByWeight(ctx context.Context, in *ListVehiclesByWeightRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// GetSpeedAverageByBrand returns the average max speed of the vehicles of a brand.
+	GetSpeedAverageByBrand(ctx context.Context, in *GetSpeedAverageByBrandRequest, opts ...grpc.CallOption) (*SpeedAverage, error)
+	// AddVehicle adds a vehicle.
+	AddVehicle(ctx context.Context, in *AddVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error)
+	// AddVehicles adds several vehicles, all or none.
+	AddVehicles(ctx context.Context, in *AddVehiclesRequest, opts ...grpc.CallOption) (*AddVehiclesResponse, error)
+	// UpdateSpeed updates the max speed of a vehicle.
+	UpdateSpeed(ctx context.Context, in *UpdateSpeedRequest, opts ...grpc.CallOption) (*Vehicle, error)
+	// DeleteVehicle deletes a vehicle and returns it as it was.
+	DeleteVehicle(ctx context.Context, in *DeleteVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error)
+}
+
+type vehicleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVehicleServiceClient(cc grpc.ClientConnInterface) VehicleServiceClient {
+	return &vehicleServiceClient{cc}
+}
+
+func (c *vehicleServiceClient) GetVehicle(ctx context.Context, in *GetVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, VehicleService_GetVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) ListVehicles(ctx context.Context, in *ListVehiclesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[0], VehicleService_ListVehicles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListVehiclesRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VehicleService_ListVehiclesClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *vehicleServiceClient) ListVehiclesByColorAndYear(ctx context.Context, in *ListVehiclesByColorAndYearRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[1], VehicleService_ListVehiclesByColorAndYear_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListVehiclesByColorAndYearRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VehicleService_ListVehiclesByColorAndYearClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *vehicleServiceClient) ListVehiclesByBrandAndPeriod(ctx context.Context, in *ListVehiclesByBrandAndPeriodRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[2], VehicleService_ListVehiclesByBrandAndPeriod_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListVehiclesByBrandAndPeriodRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VehicleService_ListVehiclesByBrandAndPeriodClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *vehicleServiceClient) ListVehiclesByFuelType(ctx context.Context, in *ListVehiclesByFuelTypeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[3], VehicleService_ListVehiclesByFuelType_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListVehiclesByFuelTypeRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VehicleService_ListVehiclesByFuelTypeClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *vehicleServiceClient) ListVehiclesByWeight(ctx context.Context, in *ListVehiclesByWeightRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[4], VehicleService_ListVehiclesByWeight_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListVehiclesByWeightRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VehicleService_ListVehiclesByWeightClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *vehicleServiceClient) GetSpeedAverageByBrand(ctx context.Context, in *GetSpeedAverageByBrandRequest, opts ...grpc.CallOption) (*SpeedAverage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SpeedAverage)
+	err := c.cc.Invoke(ctx, VehicleService_GetSpeedAverageByBrand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) AddVehicle(ctx context.Context, in *AddVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, VehicleService_AddVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) AddVehicles(ctx context.Context, in *AddVehiclesRequest, opts ...grpc.CallOption) (*AddVehiclesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddVehiclesResponse)
+	err := c.cc.Invoke(ctx, VehicleService_AddVehicles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) UpdateSpeed(ctx context.Context, in *UpdateSpeedRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, VehicleService_UpdateSpeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) DeleteVehicle(ctx context.Context, in *DeleteVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, VehicleService_DeleteVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VehicleServiceServer is the server API for VehicleService service.
+// All implementations must embed UnimplementedVehicleServiceServer
+// for forward compatibility.
+type VehicleServiceServer interface {
+	// GetVehicle returns the vehicle with the id.
+	GetVehicle(context.Context, *GetVehicleRequest) (*Vehicle, error)
+	// ListVehicles streams every vehicle.
+	ListVehicles(*ListVehiclesRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// ListVehiclesByColorAndYear streams the vehicles of a color made in a year.
+	ListVehiclesByColorAndYear(*ListVehiclesByColorAndYearRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// ListVehiclesByBrandAndPeriod streams the vehicles of a brand made between two years, both included.
+	ListVehiclesByBrandAndPeriod(*ListVehiclesByBrandAndPeriodRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// ListVehiclesByFuelType streams the vehicles of a fuel type.
+	ListVehiclesByFuelType(*ListVehiclesByFuelTypeRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// ListVehiclesByWeight streams the vehicles whose weight is in a range, open on the sides without a bound.
+	ListVehiclesByWeight(*ListVehiclesByWeightRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// GetSpeedAverageByBrand returns the average max speed of the vehicles of a brand.
+	GetSpeedAverageByBrand(context.Context, *GetSpeedAverageByBrandRequest) (*SpeedAverage, error)
+	// AddVehicle adds a vehicle.
+	AddVehicle(context.Context, *AddVehicleRequest) (*Vehicle, error)
+	// AddVehicles adds several vehicles, all or none.
+	AddVehicles(context.Context, *AddVehiclesRequest) (*AddVehiclesResponse, error)
+	// UpdateSpeed updates the max speed of a vehicle.
+	UpdateSpeed(context.Context, *UpdateSpeedRequest) (*Vehicle, error)
+	// DeleteVehicle deletes a vehicle and returns it as it was.
+	DeleteVehicle(context.Context, *DeleteVehicleRequest) (*Vehicle, error)
+	mustEmbedUnimplementedVehicleServiceServer()
+}
+
+// UnimplementedVehicleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVehicleServiceServer struct{}
+
+func (UnimplementedVehicleServiceServer) GetVehicle(context.Context, *GetVehicleRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehicle not implemented")
+}
+func (UnimplementedVehicleServiceServer) ListVehicles(*ListVehiclesRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method ListVehicles not implemented")
+}
+func (UnimplementedVehicleServiceServer) ListVehiclesByColorAndYear(*ListVehiclesByColorAndYearRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method ListVehiclesByColorAndYear not implemented")
+}
+func (UnimplementedVehicleServiceServer) ListVehiclesByBrandAndPeriod(*ListVehiclesByBrandAndPeriodRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method ListVehiclesByBrandAndPeriod not implemented")
+}
+func (UnimplementedVehicleServiceServer) ListVehiclesByFuelType(*ListVehiclesByFuelTypeRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method ListVehiclesByFuelType not implemented")
+}
+func (UnimplementedVehicleServiceServer) ListVehiclesByWeight(*ListVehiclesByWeightRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method ListVehiclesByWeight not implemented")
+}
+func (UnimplementedVehicleServiceServer) GetSpeedAverageByBrand(context.Context, *GetSpeedAverageByBrandRequest) (*SpeedAverage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSpeedAverageByBrand not implemented")
+}
+func (UnimplementedVehicleServiceServer) AddVehicle(context.Context, *AddVehicleRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddVehicle not implemented")
+}
+func (UnimplementedVehicleServiceServer) AddVehicles(context.Context, *AddVehiclesRequest) (*AddVehiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddVehicles not implemented")
+}
+func (UnimplementedVehicleServiceServer) UpdateSpeed(context.Context, *UpdateSpeedRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSpeed not implemented")
+}
+func (UnimplementedVehicleServiceServer) DeleteVehicle(context.Context, *DeleteVehicleRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVehicle not implemented")
+}
+func (UnimplementedVehicleServiceServer) mustEmbedUnimplementedVehicleServiceServer() {}
+func (UnimplementedVehicleServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeVehicleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VehicleServiceServer will
+// result in compilation errors.
+type UnsafeVehicleServiceServer interface {
+	mustEmbedUnimplementedVehicleServiceServer()
+}
+
+func RegisterVehicleServiceServer(s grpc.ServiceRegistrar, srv VehicleServiceServer) {
+	// If the following call pancis, it indicates UnimplementedVehicleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VehicleService_ServiceDesc, srv)
+}
+
+func _VehicleService_GetVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetVehicle(ctx, req.(*GetVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_ListVehicles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListVehiclesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).ListVehicles(m, &grpc.GenericServerStream[ListVehiclesRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VehicleService_ListVehiclesServer = grpc.ServerStreamingServer[Vehicle]
+
+func _VehicleService_ListVehiclesByColorAndYear_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListVehiclesByColorAndYearRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).ListVehiclesByColorAndYear(m, &grpc.GenericServerStream[ListVehiclesByColorAndYearRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VehicleService_ListVehiclesByColorAndYearServer = grpc.ServerStreamingServer[Vehicle]
+
+func _VehicleService_ListVehiclesByBrandAndPeriod_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListVehiclesByBrandAndPeriodRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).ListVehiclesByBrandAndPeriod(m, &grpc.GenericServerStream[ListVehiclesByBrandAndPeriodRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VehicleService_ListVehiclesByBrandAndPeriodServer = grpc.ServerStreamingServer[Vehicle]
+
+func _VehicleService_ListVehiclesByFuelType_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListVehiclesByFuelTypeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).ListVehiclesByFuelType(m, &grpc.GenericServerStream[ListVehiclesByFuelTypeRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VehicleService_ListVehiclesByFuelTypeServer = grpc.ServerStreamingServer[Vehicle]
+
+func _VehicleService_ListVehiclesByWeight_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListVehiclesByWeightRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).ListVehiclesByWeight(m, &grpc.GenericServerStream[ListVehiclesByWeightRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VehicleService_ListVehiclesByWeightServer = grpc.ServerStreamingServer[Vehicle]
+
+func _VehicleService_GetSpeedAverageByBrand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSpeedAverageByBrandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetSpeedAverageByBrand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetSpeedAverageByBrand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetSpeedAverageByBrand(ctx, req.(*GetSpeedAverageByBrandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_AddVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).AddVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_AddVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).AddVehicle(ctx, req.(*AddVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_AddVehicles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddVehiclesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).AddVehicles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_AddVehicles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).AddVehicles(ctx, req.(*AddVehiclesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_UpdateSpeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSpeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).UpdateSpeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_UpdateSpeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).UpdateSpeed(ctx, req.(*UpdateSpeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_DeleteVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).DeleteVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_DeleteVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).DeleteVehicle(ctx, req.(*DeleteVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VehicleService_ServiceDesc is the grpc.ServiceDesc for VehicleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VehicleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vehicle.v1.VehicleService",
+	HandlerType: (*VehicleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetVehicle",
+			Handler:    _VehicleService_GetVehicle_Handler,
+		},
+		{
+			MethodName: "GetSpeedAverageByBrand",
+			Handler:    _VehicleService_GetSpeedAverageByBrand_Handler,
+		},
+		{
+			MethodName: "AddVehicle",
+			Handler:    _VehicleService_AddVehicle_Handler,
+		},
+		{
+			MethodName: "AddVehicles",
+			Handler:    _VehicleService_AddVehicles_Handler,
+		},
+		{
+			MethodName: "UpdateSpeed",
+			Handler:    _VehicleService_UpdateSpeed_Handler,
+		},
+		{
+			MethodName: "DeleteVehicle",
+			Handler:    _VehicleService_DeleteVehicle_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListVehicles",
+			Handler:       _VehicleService_ListVehicles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListVehiclesByColorAndYear",
+			Handler:       _VehicleService_ListVehiclesByColorAndYear_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListVehiclesByBrandAndPeriod",
+			Handler:       _VehicleService_ListVehiclesByBrandAndPeriod_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListVehiclesByFuelType",
+			Handler:       _VehicleService_ListVehiclesByFuelType_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListVehiclesByWeight",
+			Handler:       _VehicleService_ListVehiclesByWeight_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/vehicle/v1/vehicle.proto",
+}